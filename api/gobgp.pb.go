@@ -179,6 +179,9 @@ It has these top-level messages:
 	TableInfo
 	GetRibInfoRequest
 	GetRibInfoResponse
+	GetZebraNexthopsRequest
+	ZebraNexthop
+	GetZebraNexthopsResponse
 */
 package gobgpapi
 
@@ -5368,6 +5371,78 @@ func (m *GetRibInfoResponse) GetInfo() *TableInfo {
 	return nil
 }
 
+type GetZebraNexthopsRequest struct {
+}
+
+func (m *GetZebraNexthopsRequest) Reset()                    { *m = GetZebraNexthopsRequest{} }
+func (m *GetZebraNexthopsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetZebraNexthopsRequest) ProtoMessage()               {}
+func (*GetZebraNexthopsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{171} }
+
+type ZebraNexthop struct {
+	Address    string   `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Family     string   `protobuf:"bytes,2,opt,name=family" json:"family,omitempty"`
+	Zone       string   `protobuf:"bytes,3,opt,name=zone" json:"zone,omitempty"`
+	VrfIds     []uint32 `protobuf:"varint,4,rep,packed,name=vrf_ids,json=vrfIds" json:"vrf_ids,omitempty"`
+	Registered bool     `protobuf:"varint,5,opt,name=registered" json:"registered,omitempty"`
+}
+
+func (m *ZebraNexthop) Reset()                    { *m = ZebraNexthop{} }
+func (m *ZebraNexthop) String() string            { return proto.CompactTextString(m) }
+func (*ZebraNexthop) ProtoMessage()               {}
+func (*ZebraNexthop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{172} }
+
+func (m *ZebraNexthop) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ZebraNexthop) GetFamily() string {
+	if m != nil {
+		return m.Family
+	}
+	return ""
+}
+
+func (m *ZebraNexthop) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *ZebraNexthop) GetVrfIds() []uint32 {
+	if m != nil {
+		return m.VrfIds
+	}
+	return nil
+}
+
+func (m *ZebraNexthop) GetRegistered() bool {
+	if m != nil {
+		return m.Registered
+	}
+	return false
+}
+
+type GetZebraNexthopsResponse struct {
+	Nexthops []*ZebraNexthop `protobuf:"bytes,1,rep,name=nexthops" json:"nexthops,omitempty"`
+}
+
+func (m *GetZebraNexthopsResponse) Reset()                    { *m = GetZebraNexthopsResponse{} }
+func (m *GetZebraNexthopsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetZebraNexthopsResponse) ProtoMessage()               {}
+func (*GetZebraNexthopsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{173} }
+
+func (m *GetZebraNexthopsResponse) GetNexthops() []*ZebraNexthop {
+	if m != nil {
+		return m.Nexthops
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GetNeighborRequest)(nil), "gobgpapi.GetNeighborRequest")
 	proto.RegisterType((*GetNeighborResponse)(nil), "gobgpapi.GetNeighborResponse")
@@ -5540,6 +5615,9 @@ func init() {
 	proto.RegisterType((*TableInfo)(nil), "gobgpapi.TableInfo")
 	proto.RegisterType((*GetRibInfoRequest)(nil), "gobgpapi.GetRibInfoRequest")
 	proto.RegisterType((*GetRibInfoResponse)(nil), "gobgpapi.GetRibInfoResponse")
+	proto.RegisterType((*GetZebraNexthopsRequest)(nil), "gobgpapi.GetZebraNexthopsRequest")
+	proto.RegisterType((*ZebraNexthop)(nil), "gobgpapi.ZebraNexthop")
+	proto.RegisterType((*GetZebraNexthopsResponse)(nil), "gobgpapi.GetZebraNexthopsResponse")
 	proto.RegisterEnum("gobgpapi.Family", Family_name, Family_value)
 	proto.RegisterEnum("gobgpapi.Resource", Resource_name, Resource_value)
 	proto.RegisterEnum("gobgpapi.TableLookupOption", TableLookupOption_name, TableLookupOption_value)
@@ -5602,6 +5680,7 @@ type GobgpApiClient interface {
 	SoftResetRpki(ctx context.Context, in *SoftResetRpkiRequest, opts ...grpc.CallOption) (*SoftResetRpkiResponse, error)
 	GetRoa(ctx context.Context, in *GetRoaRequest, opts ...grpc.CallOption) (*GetRoaResponse, error)
 	EnableZebra(ctx context.Context, in *EnableZebraRequest, opts ...grpc.CallOption) (*EnableZebraResponse, error)
+	GetZebraNexthops(ctx context.Context, in *GetZebraNexthopsRequest, opts ...grpc.CallOption) (*GetZebraNexthopsResponse, error)
 	AddVrf(ctx context.Context, in *AddVrfRequest, opts ...grpc.CallOption) (*AddVrfResponse, error)
 	DeleteVrf(ctx context.Context, in *DeleteVrfRequest, opts ...grpc.CallOption) (*DeleteVrfResponse, error)
 	GetVrf(ctx context.Context, in *GetVrfRequest, opts ...grpc.CallOption) (*GetVrfResponse, error)
@@ -6014,6 +6093,15 @@ func (c *gobgpApiClient) EnableZebra(ctx context.Context, in *EnableZebraRequest
 	return out, nil
 }
 
+func (c *gobgpApiClient) GetZebraNexthops(ctx context.Context, in *GetZebraNexthopsRequest, opts ...grpc.CallOption) (*GetZebraNexthopsResponse, error) {
+	out := new(GetZebraNexthopsResponse)
+	err := grpc.Invoke(ctx, "/gobgpapi.GobgpApi/GetZebraNexthops", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gobgpApiClient) AddVrf(ctx context.Context, in *AddVrfRequest, opts ...grpc.CallOption) (*AddVrfResponse, error) {
 	out := new(AddVrfResponse)
 	err := grpc.Invoke(ctx, "/gobgpapi.GobgpApi/AddVrf", in, out, c.cc, opts...)
@@ -6229,6 +6317,7 @@ type GobgpApiServer interface {
 	SoftResetRpki(context.Context, *SoftResetRpkiRequest) (*SoftResetRpkiResponse, error)
 	GetRoa(context.Context, *GetRoaRequest) (*GetRoaResponse, error)
 	EnableZebra(context.Context, *EnableZebraRequest) (*EnableZebraResponse, error)
+	GetZebraNexthops(context.Context, *GetZebraNexthopsRequest) (*GetZebraNexthopsResponse, error)
 	AddVrf(context.Context, *AddVrfRequest) (*AddVrfResponse, error)
 	DeleteVrf(context.Context, *DeleteVrfRequest) (*DeleteVrfResponse, error)
 	GetVrf(context.Context, *GetVrfRequest) (*GetVrfResponse, error)
@@ -6848,6 +6937,24 @@ func _GobgpApi_EnableZebra_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GobgpApi_GetZebraNexthops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetZebraNexthopsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GobgpApiServer).GetZebraNexthops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gobgpapi.GobgpApi/GetZebraNexthops",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GobgpApiServer).GetZebraNexthops(ctx, req.(*GetZebraNexthopsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GobgpApi_AddVrf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddVrfRequest)
 	if err := dec(in); err != nil {
@@ -7324,6 +7431,10 @@ var _GobgpApi_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EnableZebra",
 			Handler:    _GobgpApi_EnableZebra_Handler,
 		},
+		{
+			MethodName: "GetZebraNexthops",
+			Handler:    _GobgpApi_GetZebraNexthops_Handler,
+		},
 		{
 			MethodName: "AddVrf",
 			Handler:    _GobgpApi_AddVrf_Handler,
@@ -7433,478 +7544,480 @@ var _GobgpApi_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gobgp.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 7554 bytes of a gzipped FileDescriptorProto
+	// 7586 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x4b, 0x6c, 0x23, 0x49,
-	0x96, 0x98, 0x48, 0x51, 0x14, 0xf9, 0x48, 0x8a, 0xa9, 0xd0, 0x8f, 0x25, 0x75, 0xfd, 0x72, 0xba,
-	0xba, 0xaa, 0xab, 0xbb, 0xab, 0xab, 0xaa, 0xab, 0xd5, 0x3d, 0xfd, 0x9b, 0x66, 0x49, 0x2c, 0x15,
-	0xa7, 0x25, 0x91, 0x9d, 0x62, 0x55, 0x57, 0x8f, 0x67, 0x9c, 0x4e, 0x31, 0x83, 0x54, 0xba, 0xc9,
-	0xcc, 0xec, 0xcc, 0xa4, 0x5a, 0x05, 0x03, 0x76, 0x55, 0x7b, 0x66, 0x6c, 0xc0, 0x98, 0x8b, 0xaf,
-	0x1e, 0xc3, 0x87, 0xb9, 0x0d, 0xe0, 0x83, 0x01, 0x03, 0x06, 0x7c, 0xb2, 0x01, 0xcf, 0xd8, 0xf0,
-	0x00, 0xbe, 0x78, 0xcf, 0xbb, 0xa7, 0xbd, 0xef, 0x02, 0x7b, 0xd8, 0xc3, 0x1e, 0x16, 0xf1, 0xc9,
-	0xc8, 0xc8, 0x0f, 0x25, 0x55, 0x4f, 0xf5, 0x2e, 0x16, 0xd8, 0x93, 0x98, 0xef, 0xbd, 0x78, 0xf1,
-	0xe2, 0xf7, 0xde, 0x8b, 0x17, 0x11, 0x4f, 0x50, 0x19, 0x3a, 0x87, 0x43, 0xf7, 0x96, 0xeb, 0x39,
-	0x81, 0x83, 0x4a, 0xf4, 0xc3, 0x70, 0x2d, 0xf5, 0x27, 0x80, 0x76, 0x70, 0xb0, 0x8f, 0xad, 0xe1,
-	0xd1, 0xa1, 0xe3, 0x69, 0xf8, 0xeb, 0x09, 0xf6, 0x03, 0x74, 0x13, 0x14, 0x6c, 0x1b, 0x87, 0x23,
-	0xdc, 0x34, 0x8f, 0xb1, 0x17, 0x58, 0x3e, 0x36, 0x1b, 0xb9, 0x2b, 0xb9, 0x1b, 0x25, 0x2d, 0x05,
-	0x47, 0x0d, 0x98, 0x37, 0x4c, 0xd3, 0xc3, 0xbe, 0xdf, 0xc8, 0x5f, 0xc9, 0xdd, 0x28, 0x6b, 0xe1,
-	0xa7, 0xfa, 0x21, 0x2c, 0xc5, 0x78, 0xfb, 0xae, 0x63, 0xfb, 0x18, 0xbd, 0x0a, 0x73, 0x2e, 0xc6,
-	0x9e, 0xdf, 0xc8, 0x5d, 0x99, 0xbd, 0x51, 0xb9, 0xbb, 0x70, 0x2b, 0x14, 0xe6, 0x56, 0x17, 0x63,
-	0x4f, 0x63, 0x48, 0xf5, 0x79, 0x0e, 0xca, 0x4d, 0x6f, 0x38, 0x19, 0x63, 0x3b, 0xf0, 0xd1, 0x2d,
-	0x28, 0x79, 0xd8, 0x77, 0x26, 0x5e, 0x1f, 0x53, 0x41, 0x16, 0xee, 0xa2, 0xa8, 0x98, 0xc6, 0x31,
-	0x9a, 0xa0, 0x41, 0xab, 0x50, 0x1c, 0x18, 0x63, 0x6b, 0xf4, 0x94, 0xca, 0x54, 0xd3, 0xf8, 0x17,
-	0x42, 0x50, 0xb0, 0x8d, 0x31, 0x6e, 0xcc, 0x52, 0x49, 0xe9, 0x6f, 0xd2, 0x80, 0xfe, 0xc4, 0xf3,
-	0xb0, 0x1d, 0x34, 0x0a, 0xb4, 0x8d, 0xe1, 0xa7, 0xfa, 0x2f, 0x60, 0xa1, 0x69, 0x9a, 0x5d, 0x23,
-	0x38, 0x0a, 0x3b, 0xe6, 0x45, 0xe5, 0x58, 0x81, 0xe2, 0xb1, 0x37, 0xd0, 0x2d, 0x93, 0xf7, 0xcd,
-	0xdc, 0xb1, 0x37, 0x68, 0x9b, 0x48, 0x85, 0x82, 0x6b, 0x04, 0x47, 0x54, 0x8c, 0x78, 0x0f, 0x90,
-	0xba, 0x28, 0x4e, 0xbd, 0x06, 0x75, 0x51, 0x39, 0xef, 0x39, 0x04, 0x85, 0xc9, 0xc4, 0x62, 0x43,
-	0x51, 0xd5, 0xe8, 0x6f, 0xf5, 0xb7, 0x39, 0x58, 0xdc, 0xc6, 0x23, 0x1c, 0xe0, 0xef, 0x41, 0xce,
-	0xa8, 0x1b, 0x67, 0x63, 0xdd, 0x18, 0xca, 0x5f, 0x98, 0x2e, 0xbf, 0x10, 0x76, 0x4e, 0x12, 0x76,
-	0x19, 0x90, 0x2c, 0x2b, 0x6b, 0x96, 0xfa, 0x3e, 0xa0, 0xa6, 0x69, 0x26, 0xe7, 0x20, 0xa9, 0x03,
-	0x63, 0x8f, 0x8a, 0x9f, 0x9e, 0x25, 0x14, 0xa7, 0xae, 0xc0, 0x52, 0xac, 0x24, 0x67, 0xf8, 0x21,
-	0xac, 0xb0, 0x6a, 0xbe, 0x0b, 0xcf, 0x06, 0xac, 0x26, 0x0b, 0x73, 0xb6, 0x8f, 0x61, 0x59, 0xc3,
-	0x7e, 0x7a, 0xb5, 0x48, 0x2b, 0x20, 0x17, 0x5b, 0x01, 0xe8, 0x55, 0xa8, 0xf5, 0x9d, 0xf1, 0x78,
-	0x62, 0x5b, 0x7d, 0x23, 0xb0, 0x1c, 0x9b, 0xf7, 0x6e, 0x1c, 0xa8, 0xae, 0xc1, 0x4a, 0x82, 0x2f,
-	0xaf, 0xf0, 0xbf, 0xe7, 0xa0, 0x71, 0xe0, 0x0c, 0x82, 0x17, 0xac, 0xf5, 0x00, 0xca, 0xa6, 0xe5,
-	0xe1, 0xbe, 0xa8, 0x71, 0xe1, 0xee, 0xbb, 0x51, 0x53, 0xa7, 0x31, 0x8c, 0x10, 0xdb, 0x61, 0x61,
-	0x2d, 0xe2, 0xa3, 0xbe, 0x0d, 0x28, 0x4d, 0x80, 0x8a, 0x90, 0x6f, 0xef, 0x2b, 0x33, 0x68, 0x1e,
-	0x66, 0x3b, 0x8f, 0x7a, 0x4a, 0x0e, 0x95, 0xa0, 0x70, 0xbf, 0xd3, 0x7b, 0xa8, 0xe4, 0xd5, 0x0d,
-	0xb8, 0x90, 0x51, 0x15, 0x6f, 0xd9, 0x97, 0xb0, 0x76, 0x70, 0x34, 0x09, 0x4c, 0xe7, 0x1b, 0xfb,
-	0x65, 0xf7, 0xe6, 0x3a, 0x34, 0xd2, 0xac, 0x79, 0xb5, 0x77, 0x60, 0xa5, 0x45, 0xf5, 0xd7, 0xb9,
-	0x2b, 0x25, 0xd3, 0x21, 0x59, 0x84, 0x33, 0x7b, 0x02, 0xab, 0xdb, 0x96, 0xff, 0x42, 0xdc, 0xce,
-	0xd9, 0x84, 0x0b, 0xb0, 0x96, 0xe2, 0xcc, 0x2b, 0x1d, 0x82, 0xc2, 0xc4, 0xd9, 0xf3, 0x82, 0xb0,
-	0xba, 0x0d, 0x28, 0x9b, 0x93, 0xb1, 0xab, 0x07, 0x4f, 0x5d, 0xb6, 0xda, 0xe7, 0xb4, 0x12, 0x01,
-	0xf4, 0x9e, 0xba, 0x18, 0xad, 0x43, 0x69, 0x60, 0x8d, 0x30, 0xd5, 0x7a, 0xac, 0x32, 0xf1, 0x4d,
-	0x70, 0x96, 0x1d, 0x60, 0xef, 0xd8, 0x18, 0xd1, 0x05, 0x5e, 0xd0, 0xc4, 0xb7, 0xba, 0x04, 0x8b,
-	0x52, 0x45, 0xbc, 0xf6, 0x25, 0x58, 0xe4, 0x82, 0x45, 0xd5, 0xd3, 0x45, 0x2d, 0x01, 0x39, 0xe9,
-	0xbf, 0x02, 0xa5, 0x6d, 0xff, 0x73, 0xdc, 0x0f, 0x24, 0x41, 0x5f, 0x92, 0x56, 0x22, 0x06, 0xc4,
-	0x08, 0x8e, 0xfc, 0xc6, 0x6c, 0xca, 0x80, 0x10, 0xb5, 0xc2, 0x90, 0x44, 0x56, 0x49, 0x00, 0x2e,
-	0xd5, 0xff, 0xc8, 0x41, 0xad, 0x69, 0x9a, 0xf7, 0xc7, 0xee, 0xd9, 0x63, 0x85, 0xa0, 0xe0, 0x3a,
-	0x5e, 0xc0, 0x2d, 0x08, 0xfd, 0x8d, 0x3e, 0x82, 0x02, 0xed, 0xe5, 0x59, 0x2a, 0xfd, 0x8d, 0xa8,
-	0xe6, 0x18, 0xd3, 0x5b, 0x7b, 0x8e, 0x6d, 0x05, 0x8e, 0x67, 0xd9, 0xc3, 0xae, 0x33, 0xb2, 0xfa,
-	0x4f, 0x35, 0x5a, 0x4a, 0xdd, 0x02, 0x25, 0x89, 0x21, 0x2b, 0xa7, 0xab, 0xb5, 0x94, 0x19, 0xb2,
-	0x72, 0xba, 0x9d, 0x83, 0xd8, 0x1a, 0x42, 0x65, 0x98, 0xdb, 0xed, 0x6c, 0x35, 0x77, 0x95, 0x59,
-	0x42, 0xd7, 0xdc, 0xdd, 0x55, 0x0a, 0xaa, 0x42, 0x8d, 0x12, 0xad, 0x8c, 0x37, 0xea, 0x53, 0x50,
-	0x98, 0xc6, 0xfa, 0xae, 0xcd, 0xa2, 0xe3, 0x1a, 0x71, 0xe0, 0x6c, 0x7b, 0xb0, 0xc8, 0xa5, 0xd5,
-	0xac, 0xc3, 0x90, 0xef, 0x35, 0x98, 0x0b, 0xc8, 0x50, 0x73, 0x15, 0x5a, 0x8f, 0x7a, 0xa0, 0x47,
-	0xc0, 0x1a, 0xc3, 0xca, 0x36, 0x35, 0x1f, 0xb7, 0xa9, 0x2d, 0x28, 0x69, 0xdd, 0xcf, 0xda, 0x5b,
-	0x8e, 0x3d, 0x38, 0x45, 0xc8, 0xcb, 0x50, 0xf1, 0xf0, 0xd8, 0x09, 0xb0, 0x2e, 0x64, 0x2d, 0x6b,
-	0xc0, 0x40, 0x5d, 0x22, 0xf1, 0xaf, 0x0b, 0x50, 0x26, 0x7c, 0x0e, 0x02, 0x23, 0xa0, 0xe6, 0x7e,
-	0xe2, 0x06, 0xd6, 0x98, 0x89, 0x35, 0xab, 0xf1, 0x2f, 0x32, 0xc1, 0x89, 0x1e, 0xa0, 0x98, 0x3c,
-	0xc5, 0x88, 0x6f, 0xb4, 0x00, 0xf9, 0x89, 0x4b, 0x07, 0xb2, 0xa4, 0xe5, 0x27, 0x2e, 0xab, 0xb2,
-	0xef, 0x78, 0xa6, 0x6e, 0xb9, 0xc7, 0xf7, 0xa8, 0x69, 0xab, 0x91, 0x2a, 0x09, 0xa8, 0xed, 0x1e,
-	0xdf, 0x8b, 0x13, 0x6c, 0x52, 0xbb, 0x26, 0x13, 0x6c, 0x12, 0x02, 0xd7, 0xc3, 0x03, 0xeb, 0x84,
-	0x71, 0x28, 0x32, 0x02, 0x06, 0x0a, 0x39, 0x44, 0x04, 0x9b, 0x8d, 0xf9, 0x04, 0xc1, 0x26, 0x69,
-	0x87, 0x8f, 0x3d, 0xcb, 0x18, 0x35, 0x4a, 0xcc, 0xde, 0xb2, 0x2f, 0xf4, 0x03, 0xa8, 0x79, 0xb8,
-	0x8f, 0xad, 0x63, 0xcc, 0xa5, 0x2b, 0xd3, 0xc6, 0x54, 0x43, 0x20, 0xe5, 0x9e, 0x20, 0xda, 0x6c,
-	0x40, 0x8a, 0x68, 0x93, 0x10, 0x31, 0x9e, 0xba, 0xed, 0x04, 0xd6, 0xe0, 0x69, 0xa3, 0xc2, 0x88,
-	0x18, 0x70, 0x9f, 0xc2, 0x88, 0x9c, 0x7d, 0xa3, 0x7f, 0x84, 0x75, 0x8f, 0x28, 0xef, 0x46, 0x95,
-	0x92, 0x00, 0x05, 0x51, 0x75, 0x8e, 0xae, 0xc1, 0x82, 0x20, 0xa0, 0x93, 0xa5, 0x51, 0xa3, 0x34,
-	0xb5, 0x90, 0x86, 0xf9, 0x2b, 0x97, 0xa0, 0x82, 0x6d, 0x53, 0x77, 0x06, 0xba, 0x69, 0x04, 0x46,
-	0x63, 0x81, 0xd2, 0x94, 0xb1, 0x6d, 0x76, 0x06, 0xdb, 0x46, 0x60, 0xa0, 0x65, 0x98, 0xc3, 0x9e,
-	0xe7, 0x78, 0x8d, 0x3a, 0xc5, 0xb0, 0x0f, 0x74, 0x15, 0xb8, 0x34, 0xfa, 0xd7, 0x13, 0xec, 0x3d,
-	0x6d, 0x28, 0x14, 0x59, 0x61, 0xb0, 0xcf, 0x09, 0x88, 0x0d, 0x85, 0x8f, 0x03, 0x4e, 0xb1, 0xc8,
-	0x04, 0xa4, 0x20, 0x4a, 0xa0, 0x7e, 0x09, 0x05, 0xcd, 0xfd, 0xca, 0x42, 0xaf, 0x41, 0xa1, 0xef,
-	0xd8, 0x03, 0x3e, 0x5b, 0x65, 0x6d, 0xc3, 0xe7, 0xa0, 0x46, 0xf1, 0xe8, 0x75, 0x98, 0xf3, 0xc9,
-	0x4c, 0xa2, 0xb3, 0xa4, 0x72, 0x77, 0x29, 0x4e, 0x48, 0x27, 0x99, 0xc6, 0x28, 0xd4, 0x1b, 0xb0,
-	0xb0, 0x83, 0x03, 0xc2, 0x3d, 0x5c, 0x13, 0x91, 0x97, 0x94, 0x93, 0xbd, 0x24, 0xf5, 0x43, 0xa8,
-	0x0b, 0x4a, 0xde, 0x23, 0x37, 0x60, 0xde, 0xc7, 0xde, 0x71, 0xa6, 0xf7, 0x4b, 0x09, 0x43, 0xb4,
-	0xfa, 0x13, 0xba, 0xcc, 0xe5, 0x6a, 0x5e, 0x4c, 0x53, 0xad, 0x43, 0x69, 0x64, 0x0d, 0x30, 0x9d,
-	0xfa, 0xb3, 0x6c, 0xea, 0x87, 0xdf, 0xea, 0x22, 0x75, 0x2d, 0x65, 0xc1, 0xd4, 0x66, 0xa8, 0x01,
-	0xbe, 0x73, 0x8d, 0x91, 0x73, 0x17, 0x63, 0xfc, 0x56, 0x68, 0x47, 0xce, 0xc5, 0x98, 0x30, 0x91,
-	0xc9, 0x39, 0x93, 0x5b, 0xc2, 0xc4, 0x9c, 0x8f, 0xcb, 0x0a, 0x2c, 0xc5, 0xe8, 0x39, 0x9b, 0x37,
-	0x41, 0xa1, 0xf3, 0xf7, 0x7c, 0x4c, 0x96, 0x60, 0x51, 0xa2, 0xe6, 0x2c, 0x6e, 0xc3, 0xb2, 0xf0,
-	0x6a, 0xce, 0xc7, 0x66, 0x0d, 0x56, 0x12, 0x25, 0x38, 0xab, 0x3f, 0xe4, 0xc2, 0xb6, 0xfe, 0x04,
-	0x1f, 0x7a, 0x46, 0xc8, 0x49, 0x81, 0xd9, 0x89, 0x37, 0xe2, 0x5c, 0xc8, 0x4f, 0x3a, 0xdb, 0x9d,
-	0x49, 0x80, 0xa9, 0x81, 0x27, 0xbb, 0xac, 0x59, 0xaa, 0x0c, 0x09, 0x88, 0x98, 0x78, 0x9f, 0x54,
-	0x4e, 0xe6, 0x0c, 0xf1, 0x27, 0x98, 0x9f, 0x1e, 0x7e, 0xa2, 0x7b, 0xb0, 0x6a, 0xe3, 0x93, 0xe0,
-	0xc8, 0x71, 0xf5, 0xc0, 0xb3, 0x86, 0x43, 0xec, 0xe9, 0x6c, 0x03, 0xc7, 0xb7, 0x3a, 0xcb, 0x1c,
-	0xdb, 0x63, 0x48, 0x26, 0x0e, 0xba, 0x0b, 0x2b, 0xc9, 0x52, 0x26, 0x1e, 0x19, 0x4f, 0xb9, 0xce,
-	0x5b, 0x8a, 0x17, 0xda, 0x26, 0x28, 0xd2, 0xe5, 0xb1, 0xc6, 0xf0, 0x46, 0xd6, 0xa1, 0xb6, 0x83,
-	0x83, 0xc7, 0xde, 0x20, 0xf4, 0x16, 0xde, 0xa1, 0xcb, 0x87, 0x02, 0xf8, 0x9a, 0xb8, 0x0a, 0x85,
-	0x63, 0x6f, 0x10, 0x2e, 0x88, 0x5a, 0xb4, 0x20, 0x08, 0x11, 0x45, 0xa9, 0xb7, 0xa9, 0xd5, 0x8e,
-	0xb8, 0xa0, 0xcb, 0x30, 0x7b, 0xec, 0x85, 0xcb, 0x3a, 0x51, 0x84, 0x60, 0xb8, 0x95, 0x94, 0xaa,
-	0x51, 0xdf, 0x09, 0xad, 0xe4, 0x8b, 0xb0, 0x11, 0x86, 0x51, 0xe6, 0xf4, 0x08, 0x96, 0x77, 0x70,
-	0xb0, 0x8d, 0x07, 0x96, 0x8d, 0xcd, 0x03, 0x2c, 0xdc, 0x9b, 0xd7, 0xb9, 0x73, 0xc0, 0x5c, 0x9b,
-	0x95, 0x88, 0x1d, 0x27, 0x25, 0x83, 0xc5, 0x3c, 0x01, 0xb1, 0x0f, 0xcd, 0x47, 0xfb, 0x50, 0xb5,
-	0x09, 0x2b, 0x09, 0xb6, 0x42, 0x69, 0x14, 0x7c, 0x1c, 0x84, 0x1d, 0xb4, 0x9c, 0xe2, 0x4b, 0x68,
-	0x29, 0x85, 0xfa, 0x09, 0x2c, 0x37, 0x4d, 0x33, 0x2d, 0xd9, 0x6b, 0x30, 0x4b, 0x14, 0x39, 0x6b,
-	0x67, 0x36, 0x03, 0x42, 0x40, 0xe6, 0x6a, 0xa2, 0x3c, 0x6f, 0xf2, 0x01, 0xac, 0xb1, 0x7e, 0xf8,
-	0xce, 0xbc, 0xc9, 0xbc, 0x36, 0x46, 0x23, 0xee, 0x0e, 0x90, 0x9f, 0xc4, 0x53, 0x4f, 0x33, 0xe5,
-	0x15, 0xde, 0x87, 0x86, 0x86, 0xdd, 0x91, 0xd1, 0xff, 0xee, 0x35, 0x92, 0x1d, 0x48, 0x06, 0x0f,
-	0x5e, 0xc1, 0x0a, 0x0d, 0x4e, 0x50, 0xcd, 0x3e, 0xc6, 0xb6, 0x70, 0x66, 0x3f, 0xa3, 0x63, 0x2b,
-	0x81, 0xf9, 0x18, 0xbc, 0x03, 0xe0, 0x87, 0xc0, 0x70, 0x24, 0x24, 0x2b, 0x11, 0x15, 0x90, 0xc8,
-	0xd4, 0x87, 0x74, 0x7b, 0x9a, 0xac, 0x03, 0xdd, 0x81, 0xb2, 0x20, 0xe2, 0xad, 0xc8, 0x64, 0x15,
-	0x51, 0xa9, 0xab, 0x74, 0x60, 0x53, 0x62, 0xa9, 0x3f, 0x0b, 0x37, 0xab, 0x2f, 0xa1, 0x92, 0x8c,
-	0x11, 0xba, 0x10, 0x0e, 0x7b, 0xba, 0xe6, 0x5d, 0x58, 0xe3, 0x9d, 0xfb, 0x32, 0xda, 0xb7, 0x2e,
-	0x86, 0x3b, 0x5d, 0x13, 0x02, 0x65, 0x07, 0x07, 0xdc, 0x91, 0xe6, 0xc3, 0xd4, 0x84, 0x45, 0x09,
-	0xc6, 0xc7, 0xe8, 0x4d, 0x28, 0xb9, 0x04, 0x62, 0xe1, 0x70, 0x84, 0x14, 0x69, 0x6b, 0xc0, 0x68,
-	0x05, 0x85, 0x7a, 0x02, 0x4a, 0xd3, 0x34, 0x63, 0x6c, 0xd1, 0x0d, 0x28, 0x52, 0xfc, 0x53, 0x2e,
-	0x76, 0xba, 0x3c, 0xc7, 0xa3, 0x0f, 0xe0, 0x82, 0x87, 0x07, 0x44, 0x9d, 0x9e, 0x58, 0x7e, 0x60,
-	0xd9, 0x43, 0x5d, 0x9a, 0x1e, 0xac, 0x07, 0xd7, 0x28, 0x41, 0x8b, 0xe3, 0x0f, 0xa2, 0x69, 0xb1,
-	0x04, 0x8b, 0x52, 0xcd, 0xbc, 0x95, 0xdf, 0xe6, 0x60, 0x89, 0xc7, 0x46, 0xbe, 0xa3, 0x48, 0x6f,
-	0xc3, 0x92, 0x4b, 0x5c, 0x20, 0xef, 0x18, 0xa7, 0x85, 0x41, 0x21, 0x2a, 0x92, 0x23, 0x1c, 0xef,
-	0xd9, 0x68, 0xbc, 0x57, 0x61, 0x39, 0x2e, 0x03, 0x17, 0xee, 0x3f, 0xe7, 0x60, 0x99, 0x8f, 0xcf,
-	0xdf, 0x43, 0x87, 0x4d, 0x6b, 0xd9, 0xec, 0xb4, 0x96, 0xb1, 0x88, 0x4a, 0x4c, 0x5c, 0xb1, 0x67,
-	0x5f, 0x17, 0xf3, 0xa6, 0xe9, 0xfb, 0xd6, 0xd0, 0x96, 0x27, 0xee, 0x07, 0x00, 0x86, 0x00, 0xf2,
-	0x16, 0xad, 0x27, 0x5b, 0x24, 0x15, 0x93, 0xa8, 0xd5, 0x2f, 0x61, 0x23, 0x93, 0x33, 0x9f, 0x9b,
-	0x7f, 0x0c, 0xeb, 0x27, 0xb0, 0x2e, 0xe6, 0xcb, 0xcb, 0x15, 0xfa, 0x22, 0x6c, 0x64, 0x72, 0xe6,
-	0xbd, 0x35, 0x86, 0x8b, 0xf2, 0x74, 0x78, 0xa9, 0x75, 0x67, 0x68, 0x9b, 0x2b, 0x70, 0x69, 0x5a,
-	0x75, 0x5c, 0xa0, 0x9f, 0xc2, 0xa5, 0xd8, 0xb8, 0xbe, 0xdc, 0xde, 0xb8, 0x0a, 0x97, 0xa7, 0x72,
-	0x8f, 0xe9, 0xa2, 0x03, 0xea, 0xa3, 0x87, 0xba, 0xe8, 0x63, 0xaa, 0x8b, 0x42, 0x98, 0xb0, 0xd9,
-	0xc5, 0xe1, 0xc8, 0x39, 0x34, 0x46, 0xe9, 0x85, 0xb1, 0x43, 0xe1, 0x1a, 0xc7, 0xab, 0x9f, 0x00,
-	0x3a, 0x08, 0x0c, 0x2f, 0xce, 0xf4, 0x05, 0xca, 0xaf, 0xc0, 0x52, 0xac, 0x7c, 0x14, 0xaa, 0x39,
-	0x08, 0x1c, 0x37, 0x2e, 0xea, 0x32, 0xa9, 0x2b, 0x02, 0x72, 0xd2, 0xdf, 0xcc, 0xc2, 0x02, 0xd9,
-	0xe6, 0x3c, 0x36, 0x46, 0x96, 0x49, 0x23, 0x50, 0xe8, 0x5e, 0xb8, 0x1f, 0x62, 0xbe, 0xcc, 0xa5,
-	0xf8, 0x7e, 0x28, 0x22, 0xbc, 0x25, 0x6f, 0x8d, 0xd0, 0x7b, 0x50, 0xf4, 0xb0, 0xe1, 0x8b, 0xa8,
-	0xe3, 0xe5, 0xa9, 0xc5, 0x34, 0x4a, 0xa6, 0x71, 0x72, 0x74, 0x1d, 0xe6, 0xc7, 0x46, 0xd0, 0x3f,
-	0xc2, 0x26, 0x8f, 0xe9, 0x48, 0xbe, 0x98, 0xe6, 0x18, 0x5a, 0x88, 0x45, 0xb7, 0xa1, 0x3a, 0xb1,
-	0xf9, 0x87, 0x6e, 0xf8, 0x8d, 0x42, 0x16, 0x75, 0x45, 0x90, 0x34, 0x7d, 0xf4, 0x3e, 0x28, 0x51,
-	0x89, 0x11, 0xb6, 0x87, 0xc1, 0x51, 0x63, 0x2e, 0xab, 0x54, 0x5d, 0x90, 0xed, 0x52, 0x2a, 0xb5,
-	0x0b, 0x73, 0x2c, 0xba, 0xb0, 0x00, 0x70, 0xd0, 0x6b, 0xf6, 0x5a, 0xfa, 0x7e, 0x67, 0xbf, 0xa5,
-	0xcc, 0xa0, 0x25, 0xa8, 0x87, 0xdf, 0x3d, 0xfd, 0x41, 0xe7, 0xd1, 0xfe, 0xb6, 0x92, 0x43, 0x75,
-	0xa8, 0x30, 0xe0, 0xe3, 0xe6, 0x6e, 0x7b, 0x5b, 0xc9, 0xa3, 0x45, 0xa8, 0x31, 0x40, 0x7b, 0x9f,
-	0x81, 0x66, 0xd5, 0x0f, 0xa1, 0xc8, 0x1a, 0x4e, 0xa8, 0xb5, 0x56, 0xf3, 0xa0, 0xd3, 0x0b, 0x79,
-	0xd6, 0xa0, 0x4c, 0x01, 0xfb, 0x7a, 0xf3, 0x40, 0xc9, 0x91, 0xc2, 0xfc, 0x73, 0xb7, 0xb5, 0xbf,
-	0x43, 0xe3, 0xa9, 0x7f, 0x51, 0x80, 0x42, 0x97, 0x07, 0xd6, 0xed, 0x91, 0x67, 0x85, 0xa7, 0x00,
-	0xe4, 0x37, 0xd9, 0x82, 0xba, 0x46, 0x10, 0x78, 0x6c, 0x77, 0x50, 0xd5, 0xf8, 0x17, 0x5d, 0x64,
-	0xc3, 0x70, 0x03, 0x48, 0x7e, 0x92, 0xd2, 0x87, 0xd8, 0x0f, 0x8f, 0x3a, 0xe8, 0x6f, 0xb2, 0xc1,
-	0xb0, 0x7c, 0xfd, 0x1b, 0x2b, 0x38, 0x32, 0x3d, 0xe3, 0x1b, 0xea, 0xe5, 0x97, 0x34, 0xb0, 0xfc,
-	0x2f, 0x38, 0x04, 0x5d, 0x02, 0x38, 0x16, 0x83, 0x47, 0x03, 0x1b, 0x73, 0x9a, 0x04, 0x41, 0x2d,
-	0x58, 0x8c, 0xbe, 0x74, 0x13, 0x07, 0x86, 0x35, 0xa2, 0xe1, 0x8d, 0xca, 0xdd, 0xc6, 0xb4, 0x39,
-	0xa0, 0x29, 0x51, 0x91, 0x6d, 0x5a, 0x02, 0xdd, 0x86, 0x65, 0xdb, 0xd1, 0xad, 0xb1, 0x4b, 0x4c,
-	0x74, 0x10, 0x09, 0x54, 0x62, 0x8a, 0xde, 0x76, 0xda, 0x1c, 0x25, 0x04, 0x8b, 0xb6, 0xde, 0xe5,
-	0xd8, 0x01, 0xc5, 0x45, 0x00, 0x16, 0x43, 0xd4, 0x0d, 0xdf, 0xa6, 0x81, 0x90, 0x9a, 0x56, 0x66,
-	0x90, 0xa6, 0x6f, 0xa3, 0x0d, 0xe0, 0x1f, 0xba, 0x65, 0xd2, 0x08, 0x48, 0x59, 0x2b, 0x31, 0x40,
-	0xdb, 0xe4, 0x11, 0xd3, 0x00, 0x7b, 0xd8, 0xa4, 0xa1, 0x8f, 0x92, 0x26, 0xbe, 0xd1, 0x32, 0x5d,
-	0x17, 0x23, 0x16, 0xef, 0x28, 0x69, 0xec, 0x03, 0xdd, 0x00, 0xc5, 0xf2, 0xf5, 0x81, 0xe7, 0x8c,
-	0x75, 0x7c, 0x12, 0x60, 0xcf, 0x36, 0x46, 0x34, 0xd8, 0x51, 0xd2, 0x16, 0x2c, 0xff, 0x81, 0xe7,
-	0x8c, 0x5b, 0x1c, 0x4a, 0x7a, 0xda, 0xe6, 0x21, 0x5d, 0xdd, 0x72, 0x69, 0xdc, 0xa3, 0xac, 0x41,
-	0x08, 0x6a, 0xbb, 0xe2, 0xd4, 0x44, 0x89, 0x4e, 0x4d, 0xd0, 0x9b, 0x80, 0x2c, 0x5f, 0x0f, 0x77,
-	0x64, 0x96, 0x4d, 0xfb, 0x8d, 0x06, 0x3d, 0x4a, 0x9a, 0x62, 0xf9, 0xfb, 0x0c, 0xd1, 0x66, 0x70,
-	0x32, 0x56, 0x96, 0x89, 0xed, 0xc0, 0x1a, 0x58, 0xd8, 0x6b, 0x20, 0x16, 0x63, 0x8a, 0x20, 0xe8,
-	0x75, 0x50, 0x46, 0x4e, 0xdf, 0x18, 0xe9, 0x12, 0xd5, 0x12, 0xa5, 0xaa, 0x53, 0x78, 0x5b, 0x80,
-	0xd5, 0xff, 0x94, 0x83, 0xca, 0x36, 0x26, 0xd6, 0x98, 0x0d, 0x33, 0x99, 0x65, 0x34, 0x58, 0xc5,
-	0x77, 0xa7, 0xfc, 0x2b, 0x0a, 0xc8, 0xe6, 0x4f, 0x09, 0xc8, 0xa2, 0xeb, 0x50, 0x1f, 0x39, 0x36,
-	0xd9, 0x4c, 0xb2, 0x62, 0x38, 0xb4, 0xe0, 0x0b, 0x0c, 0xdc, 0xe5, 0x50, 0x22, 0xa1, 0x7f, 0xe4,
-	0x78, 0x81, 0x4c, 0xc9, 0xa6, 0x6b, 0x9d, 0xc3, 0x43, 0x52, 0xf5, 0xbf, 0xe5, 0x60, 0x8e, 0x06,
-	0x1e, 0xd1, 0x6b, 0xb1, 0xcd, 0x57, 0x56, 0x5c, 0x79, 0xea, 0xce, 0x6b, 0xea, 0x31, 0xd7, 0x0f,
-	0xa1, 0x6a, 0x46, 0xcd, 0x0f, 0xb5, 0x4d, 0x6c, 0x63, 0x27, 0xb0, 0x5a, 0x8c, 0x94, 0x86, 0xfa,
-	0x1c, 0x3f, 0xd0, 0xb9, 0x77, 0xc4, 0x97, 0x14, 0x01, 0x31, 0xdb, 0xa2, 0x6e, 0xd2, 0x8d, 0xf1,
-	0x0b, 0x47, 0x56, 0xd5, 0xf7, 0x58, 0xf8, 0x89, 0x94, 0xe3, 0xa6, 0xe6, 0x9c, 0x05, 0xc7, 0xb0,
-	0x48, 0xbf, 0x77, 0x1d, 0xe7, 0xab, 0x89, 0xcb, 0x7a, 0x70, 0xea, 0x88, 0x7e, 0x0a, 0xb5, 0x11,
-	0xa5, 0xd3, 0x1d, 0x57, 0x3a, 0x46, 0xda, 0x48, 0xf0, 0x66, 0xbc, 0x3a, 0x2e, 0xeb, 0x80, 0x91,
-	0xf4, 0xa5, 0xfe, 0xc7, 0x1c, 0x15, 0x54, 0x3e, 0x94, 0xfc, 0x3e, 0x86, 0xe8, 0x3d, 0x28, 0x49,
-	0x73, 0x84, 0x0c, 0x4f, 0xb6, 0x8c, 0xac, 0xbd, 0x9a, 0x20, 0x56, 0x47, 0x80, 0xb8, 0x2e, 0xc2,
-	0xd2, 0x20, 0x9c, 0x57, 0xc4, 0x69, 0xe7, 0xcb, 0x51, 0x7f, 0xce, 0xca, 0xfd, 0x49, 0x8c, 0x74,
-	0xac, 0x36, 0x6e, 0x79, 0xff, 0x77, 0x0e, 0xca, 0xcd, 0x07, 0xed, 0x07, 0xa2, 0x70, 0x56, 0x1c,
-	0x11, 0xdd, 0x82, 0xa5, 0xb1, 0xab, 0x0f, 0x3d, 0xa3, 0x8f, 0x07, 0x93, 0x91, 0xee, 0x61, 0x9f,
-	0xd8, 0x7b, 0xee, 0x39, 0x2d, 0x8e, 0xdd, 0x1d, 0x8e, 0xd1, 0x18, 0x02, 0x7d, 0x04, 0xeb, 0x64,
-	0x45, 0x8d, 0x68, 0x24, 0x38, 0x55, 0x8c, 0xad, 0xb9, 0x86, 0xa0, 0x48, 0x96, 0xbe, 0x07, 0xab,
-	0x51, 0x69, 0x5e, 0x48, 0xa7, 0x61, 0x44, 0x16, 0x12, 0x5f, 0x16, 0x58, 0x5e, 0xa2, 0x67, 0x8d,
-	0xb1, 0xfa, 0x9b, 0x39, 0x28, 0x74, 0x31, 0xf6, 0xa8, 0xf6, 0x24, 0x62, 0x87, 0x9b, 0xb0, 0x9a,
-	0x26, 0xbe, 0xd1, 0xfb, 0x50, 0x35, 0x5c, 0x77, 0xf4, 0x34, 0x5c, 0x15, 0x2c, 0xd8, 0x2a, 0xad,
-	0xa7, 0x26, 0xc1, 0x72, 0x97, 0xbd, 0x62, 0x44, 0x1f, 0x22, 0x8e, 0x3b, 0x9b, 0x8c, 0xe3, 0x92,
-	0x3a, 0xa5, 0x38, 0xee, 0x87, 0x50, 0xc3, 0x87, 0x43, 0x57, 0x1f, 0x4f, 0x46, 0x81, 0x75, 0xe4,
-	0xb8, 0xfc, 0x84, 0x7a, 0x35, 0x2a, 0xd0, 0x3a, 0x1c, 0xba, 0x7b, 0x1c, 0xab, 0x55, 0xb1, 0xf4,
-	0x85, 0x9a, 0x50, 0x67, 0x71, 0x36, 0x0f, 0x0f, 0x46, 0xb8, 0x1f, 0x38, 0x1e, 0x5d, 0xb7, 0x71,
-	0x1b, 0x46, 0x08, 0xb4, 0x10, 0xaf, 0x2d, 0x78, 0xb1, 0x6f, 0x74, 0x1d, 0x0a, 0x96, 0x3d, 0x70,
-	0xa8, 0x89, 0x8c, 0xed, 0x7a, 0x89, 0x9c, 0xcc, 0x57, 0xa2, 0x04, 0xc4, 0xbf, 0x23, 0x7d, 0xea,
-	0xf9, 0xdc, 0x4c, 0x4a, 0xfe, 0x5d, 0x8f, 0xc2, 0x35, 0x8e, 0x27, 0xbb, 0xe9, 0xc0, 0x33, 0x6c,
-	0x9f, 0xc6, 0x5b, 0x4b, 0x49, 0xbe, 0xbd, 0x10, 0xa5, 0x45, 0x54, 0xa4, 0x9f, 0x59, 0x43, 0x58,
-	0x30, 0x99, 0xda, 0xc6, 0x58, 0x3f, 0xd3, 0x56, 0x70, 0x2f, 0x90, 0xc5, 0x16, 0xd9, 0x07, 0xda,
-	0x84, 0xaa, 0x31, 0xb0, 0x74, 0x31, 0x82, 0x90, 0x0c, 0x74, 0x88, 0xd9, 0xaa, 0x55, 0x8c, 0x81,
-	0xf5, 0x20, 0x1c, 0xd9, 0x6d, 0x50, 0x52, 0x13, 0xad, 0x42, 0x6b, 0xbd, 0x20, 0x39, 0xae, 0xf1,
-	0x99, 0xa6, 0xd5, 0x87, 0x89, 0xa9, 0x77, 0x0b, 0xca, 0xa4, 0x76, 0xdf, 0x18, 0x58, 0x7e, 0xa3,
-	0x4a, 0xab, 0x5e, 0x94, 0xaa, 0x1e, 0x58, 0x07, 0xc6, 0xc0, 0xd2, 0x4a, 0x06, 0xfb, 0x41, 0xf6,
-	0x85, 0x65, 0xc3, 0x34, 0x75, 0x66, 0x7b, 0x6a, 0xc9, 0xa9, 0xc1, 0x6f, 0x4f, 0xf8, 0x5a, 0xc9,
-	0xe0, 0xbf, 0xd4, 0xff, 0x93, 0x83, 0x8a, 0x34, 0xc7, 0xd0, 0x7b, 0x50, 0xb6, 0x6c, 0x3d, 0xb6,
-	0x83, 0x3d, 0x6d, 0xb3, 0x50, 0xb2, 0x6c, 0x5e, 0xf0, 0x47, 0x50, 0xc3, 0x27, 0xa4, 0xaf, 0xe3,
-	0x53, 0xf9, 0xb4, 0xc2, 0x55, 0x56, 0x20, 0x62, 0x60, 0x8d, 0x65, 0x06, 0xb3, 0x67, 0x33, 0x60,
-	0x05, 0xb8, 0xfd, 0xf8, 0x97, 0x50, 0x61, 0x3a, 0x6d, 0xd7, 0x1a, 0x5b, 0x53, 0xcf, 0x20, 0xd0,
-	0x55, 0xa8, 0x8e, 0x8d, 0x93, 0xc8, 0x8e, 0x32, 0x75, 0x55, 0x19, 0x1b, 0x27, 0xc2, 0xdc, 0xde,
-	0x83, 0x55, 0x9f, 0x1f, 0x98, 0xeb, 0xc1, 0x91, 0x87, 0xfd, 0x23, 0x67, 0x64, 0xea, 0x6e, 0x3f,
-	0xe0, 0xaa, 0x76, 0x39, 0xc4, 0xf6, 0x42, 0x64, 0xb7, 0x1f, 0xa8, 0x7f, 0x5e, 0x84, 0x52, 0xb8,
-	0xf8, 0xd0, 0x0f, 0xa0, 0x66, 0x4c, 0x82, 0x23, 0xdd, 0x35, 0x7c, 0xff, 0x1b, 0xc7, 0x33, 0xb9,
-	0x35, 0xa9, 0x12, 0x60, 0x97, 0xc3, 0xd0, 0x15, 0xa8, 0x98, 0xd8, 0xef, 0x7b, 0x96, 0x2b, 0x9d,
-	0x7c, 0xcb, 0x20, 0x74, 0x01, 0x4a, 0xcc, 0x35, 0x31, 0xfc, 0x30, 0x90, 0x4d, 0xbf, 0x9b, 0xd4,
-	0x27, 0x10, 0x8e, 0x53, 0x18, 0x68, 0x2f, 0x50, 0x0e, 0xf5, 0x10, 0xde, 0xe4, 0x67, 0x13, 0x6b,
-	0x30, 0xef, 0x62, 0xec, 0x11, 0x26, 0x2c, 0x5e, 0x5d, 0x24, 0x9f, 0x4d, 0x9f, 0x38, 0x85, 0x14,
-	0x31, 0xf4, 0x9c, 0x89, 0x4b, 0x97, 0x68, 0x59, 0x2b, 0x13, 0xc8, 0x0e, 0x01, 0x10, 0xa7, 0x90,
-	0xa2, 0xa9, 0x01, 0x60, 0x67, 0x73, 0x25, 0x02, 0xa0, 0xc7, 0xe8, 0xfb, 0xb0, 0xe8, 0xe1, 0xb1,
-	0x73, 0x8c, 0x75, 0xd7, 0xb3, 0x8e, 0x8d, 0x80, 0x38, 0x96, 0x74, 0x35, 0x2e, 0xdc, 0x55, 0xd3,
-	0xda, 0xe8, 0x96, 0x46, 0x69, 0xbb, 0x8c, 0xb4, 0xe9, 0x6b, 0x75, 0x2f, 0x0e, 0x20, 0x3e, 0x1d,
-	0x5b, 0xa2, 0x83, 0x91, 0xe1, 0xea, 0xa6, 0x31, 0x76, 0x2d, 0x7b, 0x48, 0x17, 0x6a, 0x49, 0x53,
-	0x28, 0xe6, 0xc1, 0xc8, 0x70, 0xb7, 0x19, 0x1c, 0x5d, 0x83, 0x05, 0x1f, 0xdb, 0xa6, 0xce, 0xaf,
-	0x09, 0x04, 0x4f, 0xb9, 0x4b, 0x5b, 0x23, 0xd0, 0xad, 0x10, 0x48, 0x1a, 0xc8, 0x4f, 0x4d, 0xfb,
-	0x86, 0xdb, 0xa8, 0xd0, 0x9d, 0x40, 0x99, 0x41, 0xb6, 0x0c, 0xda, 0x40, 0xd6, 0xbd, 0x04, 0x5b,
-	0xa5, 0x58, 0xd6, 0xdf, 0x04, 0xb9, 0x00, 0x79, 0xcb, 0xa4, 0x8b, 0xa8, 0xac, 0xe5, 0x2d, 0x13,
-	0x7d, 0x00, 0x35, 0x7e, 0x56, 0x39, 0x22, 0x13, 0xcc, 0x6f, 0x2c, 0x24, 0x9d, 0x1f, 0x69, 0xfa,
-	0x69, 0x55, 0x37, 0xfa, 0xf0, 0xc9, 0x74, 0xe0, 0xe3, 0xc8, 0x47, 0x8a, 0xf9, 0xb9, 0x55, 0x36,
-	0x98, 0x7c, 0x98, 0xde, 0x02, 0x14, 0xb9, 0xc2, 0x76, 0x80, 0xbd, 0x81, 0xd1, 0xc7, 0xd4, 0xef,
-	0x2d, 0x6b, 0x8b, 0xc2, 0x23, 0x0e, 0x11, 0x64, 0x27, 0x73, 0xec, 0x0d, 0xa8, 0xd7, 0x5b, 0xa6,
-	0xb1, 0x79, 0x32, 0x25, 0x52, 0x3a, 0x07, 0x31, 0x37, 0x71, 0xf8, 0x42, 0x16, 0x71, 0xe9, 0x0c,
-	0x8b, 0x78, 0x05, 0xaa, 0xc6, 0x68, 0xe4, 0x7c, 0xa3, 0x93, 0x15, 0x62, 0xf8, 0x8d, 0x65, 0xe6,
-	0x53, 0x53, 0x58, 0xe7, 0x1b, 0xbb, 0xe9, 0xa3, 0xd7, 0xa0, 0xee, 0xb1, 0xc8, 0x81, 0x1e, 0x4e,
-	0xbd, 0x15, 0xca, 0xb4, 0xc6, 0xc1, 0x5d, 0x3a, 0x03, 0xd5, 0x3b, 0x50, 0x4f, 0xcc, 0x0c, 0x54,
-	0x82, 0x02, 0xdf, 0x02, 0xf2, 0x13, 0xfe, 0x1c, 0xaa, 0xc0, 0xbc, 0xd6, 0xea, 0xee, 0x36, 0xb7,
-	0x5a, 0x4a, 0x5e, 0xfd, 0x0c, 0xaa, 0xb2, 0xc9, 0x42, 0x0d, 0x98, 0x67, 0x27, 0x38, 0xe1, 0x8d,
-	0xbc, 0xf0, 0x93, 0x2e, 0x75, 0x4e, 0xa5, 0x07, 0xc1, 0x48, 0x2c, 0x75, 0x0e, 0xeb, 0x05, 0x23,
-	0xf5, 0x5f, 0xe7, 0x60, 0x21, 0x6e, 0xc1, 0xc8, 0xea, 0x4f, 0x18, 0x3d, 0xbd, 0x3f, 0xb2, 0xc2,
-	0xe0, 0x49, 0x49, 0x5b, 0x8e, 0x5b, 0xb8, 0x2d, 0x8a, 0x43, 0x1f, 0xc2, 0x7a, 0xba, 0xd4, 0xc4,
-	0x27, 0x2e, 0xbb, 0xb8, 0xad, 0xb1, 0x96, 0x2c, 0x49, 0xf1, 0x6d, 0x53, 0xfd, 0x2f, 0x45, 0x28,
-	0x0b, 0x7b, 0xf8, 0x77, 0xa0, 0x3b, 0x6e, 0x41, 0x69, 0x8c, 0x7d, 0xdf, 0x18, 0xf2, 0x7d, 0x44,
-	0xcc, 0x4a, 0xec, 0x71, 0x8c, 0x26, 0x68, 0x32, 0x75, 0xcd, 0xdc, 0x99, 0xba, 0xa6, 0x78, 0x8a,
-	0xae, 0x99, 0x3f, 0x55, 0xd7, 0x94, 0x12, 0xba, 0xe6, 0x06, 0x14, 0xbf, 0x9e, 0xe0, 0x09, 0xf6,
-	0xb9, 0xe1, 0x96, 0x7c, 0x83, 0xcf, 0x29, 0x5c, 0xe3, 0x78, 0x74, 0x33, 0x4b, 0x2b, 0x31, 0xd5,
-	0x70, 0x4e, 0x8d, 0x53, 0x39, 0xb7, 0xc6, 0xa9, 0x66, 0x69, 0x1c, 0x7a, 0x9d, 0xc0, 0xf7, 0xc9,
-	0xae, 0x9f, 0xc5, 0x8b, 0x6a, 0x94, 0xaa, 0xca, 0x81, 0x6c, 0x84, 0xdf, 0x85, 0x55, 0x7f, 0xe2,
-	0x12, 0xdb, 0x85, 0x4d, 0xa2, 0x7b, 0x8c, 0x43, 0x6b, 0x64, 0x05, 0xc4, 0xbd, 0x58, 0xa0, 0x47,
-	0x99, 0x2b, 0x02, 0xbb, 0x25, 0x21, 0x49, 0x1f, 0x11, 0x57, 0x8e, 0xf1, 0x65, 0x1a, 0xa4, 0x74,
-	0x38, 0x74, 0x19, 0xcf, 0x1f, 0x41, 0xc5, 0x30, 0xc7, 0x56, 0x58, 0xad, 0x92, 0x0c, 0x53, 0x89,
-	0xf9, 0x75, 0xab, 0x49, 0xc8, 0x98, 0xeb, 0x05, 0x86, 0xf8, 0x4d, 0xfc, 0xd4, 0xf0, 0x62, 0x04,
-	0x55, 0x2a, 0x35, 0x4d, 0x7c, 0x13, 0x9c, 0xd1, 0xef, 0x63, 0x37, 0xc0, 0x26, 0xdf, 0x40, 0x8b,
-	0x6f, 0xb2, 0xbd, 0x36, 0xa2, 0x4b, 0xb1, 0x4b, 0x5c, 0x15, 0x44, 0xd7, 0x61, 0x97, 0x60, 0xce,
-	0x99, 0x04, 0xfa, 0xd7, 0x5c, 0x4b, 0x14, 0x9c, 0x49, 0xf0, 0x39, 0x5a, 0x86, 0xb9, 0xc1, 0xc8,
-	0x71, 0x99, 0x56, 0xa8, 0x69, 0xec, 0x43, 0xbd, 0x09, 0x10, 0x09, 0x87, 0x8a, 0x90, 0x7f, 0xd4,
-	0x65, 0xf7, 0x80, 0xb6, 0x3b, 0x5f, 0xec, 0x2b, 0x39, 0x04, 0x50, 0xec, 0x3e, 0x78, 0xa2, 0x6f,
-	0xf5, 0x94, 0xbc, 0xfa, 0xcf, 0xa0, 0x14, 0xce, 0x54, 0xf4, 0x96, 0x24, 0x3a, 0x73, 0x5a, 0x16,
-	0x53, 0xf3, 0x59, 0x6a, 0xcd, 0x35, 0x28, 0xf8, 0xe1, 0x45, 0x9c, 0x4c, 0x52, 0x8a, 0x56, 0x7f,
-	0x97, 0x83, 0x79, 0x0e, 0x41, 0x2a, 0x54, 0xf7, 0x3b, 0xbd, 0xf6, 0x83, 0xf6, 0x56, 0xb3, 0xd7,
-	0xee, 0xec, 0xd3, 0x5a, 0x0a, 0x5a, 0x0c, 0x46, 0x3c, 0x8e, 0x47, 0xdd, 0xed, 0x66, 0xaf, 0x45,
-	0x19, 0x17, 0x34, 0xfe, 0x45, 0x76, 0x6f, 0x9d, 0x6e, 0x6b, 0x9f, 0x5f, 0x28, 0xa3, 0xbf, 0xd1,
-	0x2b, 0x50, 0xfe, 0xac, 0xd5, 0xea, 0x36, 0x77, 0xdb, 0x8f, 0x5b, 0x74, 0x09, 0x16, 0xb4, 0x08,
-	0x40, 0x54, 0x9a, 0xd6, 0x7a, 0xa0, 0xb5, 0x0e, 0x1e, 0xd2, 0x65, 0x56, 0xd0, 0xc2, 0x4f, 0x52,
-	0x6e, 0xbb, 0x7d, 0xb0, 0xd5, 0xd4, 0xb6, 0x5b, 0xdb, 0x74, 0x81, 0x15, 0xb4, 0x08, 0x40, 0x7a,
-	0xb5, 0xd7, 0xe9, 0x35, 0x77, 0xe9, 0xf2, 0x2a, 0x68, 0xec, 0x43, 0xdd, 0x84, 0x22, 0x5b, 0x25,
-	0x04, 0x6f, 0xd9, 0xee, 0x24, 0xe0, 0x2e, 0x11, 0xfb, 0x20, 0x72, 0x3b, 0x93, 0x80, 0x80, 0xf9,
-	0xd6, 0x8d, 0x7d, 0xa9, 0x18, 0x8a, 0xcc, 0xf3, 0x46, 0xb7, 0xa0, 0x48, 0x36, 0x13, 0xd6, 0x90,
-	0xf7, 0xee, 0x6a, 0xd2, 0x37, 0xdf, 0xa2, 0x58, 0x8d, 0x53, 0xa1, 0x37, 0xe2, 0x97, 0x47, 0x56,
-	0x92, 0xe4, 0xb1, 0xeb, 0x23, 0xbf, 0xcb, 0x41, 0x55, 0xe6, 0x42, 0x96, 0x50, 0xdf, 0xb1, 0x6d,
-	0xdc, 0x0f, 0x74, 0x0f, 0x07, 0xde, 0xd3, 0xb0, 0xb3, 0x39, 0x50, 0x23, 0x30, 0xb2, 0x16, 0xa8,
-	0x57, 0x26, 0x6e, 0x32, 0x15, 0xb4, 0x12, 0x01, 0x10, 0x4e, 0xc4, 0x92, 0x7e, 0x85, 0xb1, 0x6b,
-	0x10, 0xe3, 0xa5, 0x27, 0x2e, 0xf4, 0x2d, 0x0a, 0x4c, 0x9b, 0x23, 0xd0, 0x36, 0x5c, 0x1a, 0x5b,
-	0xb6, 0x35, 0x9e, 0x8c, 0x75, 0x31, 0x6f, 0x89, 0x83, 0x19, 0x15, 0x65, 0x23, 0xf4, 0x0a, 0xa7,
-	0x6a, 0xca, 0x44, 0x21, 0x17, 0xf5, 0xb7, 0x79, 0xa8, 0x48, 0xcd, 0xfb, 0x07, 0xda, 0x0c, 0x1a,
-	0x72, 0xc4, 0x43, 0x27, 0xb0, 0x0c, 0xa2, 0x9c, 0x22, 0xe1, 0xd8, 0x44, 0x44, 0x11, 0xee, 0x61,
-	0x28, 0x66, 0x74, 0xd7, 0x8c, 0x4d, 0xc8, 0xac, 0xbb, 0x66, 0x6c, 0x42, 0x8a, 0x6f, 0xf5, 0xaf,
-	0x73, 0x50, 0x16, 0x3b, 0xb5, 0xb4, 0x7b, 0x94, 0xcb, 0x70, 0x8f, 0x2e, 0x02, 0x30, 0x22, 0xe9,
-	0x9e, 0x0d, 0x73, 0xdf, 0xba, 0x9c, 0xc7, 0x38, 0x98, 0xe8, 0xa6, 0xe5, 0xf7, 0x9d, 0x63, 0xec,
-	0x3d, 0xe5, 0xdb, 0xfa, 0xea, 0x38, 0x98, 0x6c, 0x87, 0x30, 0xe2, 0x11, 0x10, 0xab, 0x4a, 0xfa,
-	0x73, 0xec, 0x98, 0xe1, 0x9d, 0x8f, 0x0a, 0x87, 0xed, 0x39, 0x26, 0x26, 0x7a, 0x9e, 0xbb, 0x8c,
-	0x71, 0x4b, 0x57, 0x63, 0xd0, 0x66, 0xf6, 0x7d, 0xbc, 0x62, 0x78, 0xf7, 0x2d, 0xbc, 0x8f, 0x47,
-	0x0c, 0x61, 0xd0, 0x77, 0xf5, 0xb1, 0xef, 0x73, 0xd7, 0xb9, 0x18, 0xf4, 0xdd, 0x3d, 0xdf, 0x57,
-	0x3f, 0x86, 0x8a, 0xb4, 0xdb, 0x44, 0xb7, 0x60, 0x49, 0xde, 0x9a, 0xc6, 0x7d, 0x8d, 0x45, 0x69,
-	0x2b, 0xca, 0x1c, 0x0d, 0xf5, 0xaf, 0x72, 0x50, 0x4f, 0xfa, 0x63, 0xa7, 0xba, 0x40, 0xb1, 0x88,
-	0x05, 0x77, 0x81, 0xbc, 0x28, 0x50, 0x41, 0x5a, 0x72, 0x84, 0x47, 0x2e, 0xf6, 0x74, 0xc7, 0x1e,
-	0x85, 0xdd, 0x06, 0x0c, 0xd4, 0xb1, 0x47, 0xd4, 0xa4, 0x99, 0x78, 0x80, 0x3d, 0xcf, 0x18, 0xc9,
-	0x61, 0x8f, 0x6a, 0x08, 0xa4, 0x5c, 0xee, 0xc0, 0x32, 0xbd, 0x3f, 0xc7, 0x6f, 0xec, 0xea, 0xa1,
-	0x3c, 0x2c, 0xce, 0xb7, 0x24, 0xe3, 0x5a, 0x5c, 0xb6, 0x37, 0x60, 0x31, 0xf2, 0x41, 0x43, 0xfa,
-	0x22, 0x33, 0xbf, 0x02, 0xc1, 0x89, 0xd5, 0x77, 0x60, 0x6d, 0x2f, 0x19, 0xd7, 0xe1, 0xfa, 0x62,
-	0x6a, 0xeb, 0xd5, 0xff, 0x95, 0x83, 0xd5, 0x54, 0x29, 0xb6, 0x3a, 0xa7, 0x77, 0x99, 0x6c, 0x07,
-	0x59, 0x44, 0x29, 0xb2, 0x1c, 0x71, 0x5b, 0xc7, 0xbb, 0x4a, 0xb2, 0x75, 0x6f, 0xc1, 0x12, 0xbf,
-	0xdf, 0xe7, 0x59, 0x87, 0xba, 0x60, 0x53, 0x08, 0x5f, 0x8a, 0x98, 0x9d, 0x01, 0x8d, 0x76, 0x09,
-	0x43, 0x54, 0x97, 0xc8, 0xa9, 0x4d, 0x62, 0xfd, 0x55, 0x0d, 0x49, 0x0f, 0xc8, 0x90, 0xff, 0x32,
-	0x07, 0x8b, 0xa9, 0x66, 0xa0, 0x1f, 0x26, 0x94, 0xf2, 0x55, 0xc9, 0x8e, 0x65, 0xf7, 0x94, 0xd0,
-	0xcf, 0x9b, 0x71, 0xfd, 0x7c, 0xe5, 0x94, 0x92, 0x31, 0x55, 0xdd, 0x84, 0x1a, 0x8f, 0x39, 0xf0,
-	0xae, 0x9f, 0xb6, 0xc9, 0x96, 0x7a, 0x37, 0x1f, 0x1f, 0x92, 0x7f, 0x9b, 0x83, 0x2a, 0xe7, 0x21,
-	0x6e, 0xaa, 0xbe, 0x18, 0x0b, 0x32, 0x61, 0x03, 0x27, 0x20, 0x8a, 0x80, 0xdf, 0x79, 0xa6, 0x4b,
-	0x8f, 0x82, 0x68, 0x50, 0x83, 0x2c, 0x61, 0x4e, 0x20, 0x07, 0xcb, 0x6b, 0x5a, 0x8d, 0xd1, 0x84,
-	0x01, 0xcf, 0x3f, 0xc9, 0xc3, 0x06, 0x5f, 0x89, 0x23, 0x76, 0x7b, 0x9f, 0x45, 0x6a, 0x43, 0x3b,
-	0xf4, 0x26, 0x20, 0x63, 0xf4, 0x8d, 0xf1, 0xd4, 0x27, 0x3e, 0x9f, 0x6b, 0x78, 0x58, 0x1f, 0x47,
-	0xaf, 0x7e, 0x18, 0x66, 0x8b, 0x21, 0xf6, 0xb0, 0x89, 0xee, 0xc0, 0x8a, 0x35, 0xb4, 0x1d, 0x8f,
-	0x78, 0x9c, 0x54, 0xb2, 0xf0, 0x6c, 0x8d, 0x5f, 0x37, 0x60, 0xc8, 0xa6, 0x4f, 0x44, 0x64, 0xe7,
-	0x69, 0x64, 0xcf, 0x10, 0x9e, 0x8e, 0x88, 0x2a, 0xe8, 0x82, 0xa7, 0x7b, 0x06, 0x36, 0xbb, 0xd6,
-	0x42, 0x0a, 0x5e, 0x15, 0x15, 0xd8, 0x6b, 0x93, 0xed, 0xe8, 0x05, 0x31, 0xf1, 0x74, 0xcb, 0x36,
-	0xfa, 0x01, 0xd1, 0x6a, 0xb4, 0x78, 0x78, 0x38, 0xb0, 0x26, 0x08, 0xda, 0x1c, 0x4f, 0x4b, 0x53,
-	0xe5, 0xc5, 0x3a, 0x53, 0x37, 0xac, 0xa1, 0x1b, 0xc6, 0xe2, 0xf9, 0x43, 0x26, 0x6b, 0xe8, 0xa2,
-	0x0f, 0x60, 0x9d, 0x37, 0xc6, 0xc6, 0x27, 0x81, 0x4e, 0x4f, 0x59, 0x86, 0xae, 0x3e, 0xc6, 0x81,
-	0x67, 0xf5, 0xf9, 0x1a, 0x5d, 0x65, 0x14, 0xfb, 0xf8, 0x24, 0x78, 0xe8, 0xb8, 0xed, 0xa1, 0xbb,
-	0x47, 0xb1, 0xea, 0xff, 0xcf, 0xc3, 0x7a, 0x66, 0xb7, 0xb2, 0xf1, 0xfe, 0xc7, 0x5e, 0xfd, 0x4e,
-	0xbd, 0xfa, 0xef, 0x73, 0xb0, 0x92, 0xd9, 0xab, 0xe8, 0xe3, 0x84, 0x1e, 0xb8, 0x96, 0x8a, 0x6a,
-	0x66, 0xcd, 0x6e, 0xa1, 0x0b, 0x3e, 0x88, 0xeb, 0x82, 0x57, 0xcf, 0x28, 0x1d, 0xd3, 0x07, 0x77,
-	0x61, 0xf5, 0x91, 0x8f, 0xe9, 0x4e, 0xdc, 0x1d, 0xd1, 0x27, 0x4c, 0xfe, 0x99, 0x3a, 0xf9, 0x0e,
-	0xac, 0x24, 0xcb, 0x9c, 0xa1, 0x91, 0xd5, 0x9f, 0x01, 0x90, 0x1d, 0x3f, 0x67, 0x7d, 0x13, 0x16,
-	0x59, 0xf0, 0x61, 0xcc, 0x79, 0x90, 0x2d, 0x1e, 0x2b, 0x51, 0xa7, 0x88, 0x90, 0x77, 0x93, 0xc6,
-	0x5d, 0xc6, 0xc6, 0x09, 0x75, 0x89, 0xc2, 0xf3, 0x38, 0x6a, 0xba, 0x38, 0x90, 0xc5, 0x40, 0x7f,
-	0x0a, 0xe5, 0x96, 0xd8, 0x46, 0xbd, 0x74, 0xee, 0x3a, 0x14, 0x08, 0x77, 0xf4, 0x66, 0x62, 0x98,
-	0x96, 0xe3, 0x11, 0xf8, 0xc4, 0xa8, 0x4c, 0xbf, 0x7e, 0x2d, 0x44, 0x0d, 0x07, 0xe1, 0x0e, 0x40,
-	0x3b, 0xea, 0x9d, 0x94, 0x4c, 0xb9, 0x0c, 0x99, 0x6e, 0x43, 0xb9, 0x2d, 0x5a, 0x7c, 0xae, 0x12,
-	0x3a, 0x14, 0xda, 0x67, 0xb4, 0xa2, 0xfd, 0x22, 0xad, 0x68, 0x27, 0x5b, 0xf1, 0x87, 0x1c, 0x28,
-	0xc9, 0x79, 0x81, 0xde, 0x4f, 0xd4, 0x26, 0x19, 0xaa, 0xec, 0x79, 0x27, 0x6a, 0x7e, 0x37, 0x5e,
-	0xf3, 0xe5, 0xe9, 0x05, 0x63, 0xf7, 0x35, 0x54, 0x28, 0xe0, 0xc3, 0xa1, 0x9b, 0x7e, 0x86, 0x48,
-	0x7a, 0x5d, 0xa3, 0x38, 0x42, 0x63, 0x11, 0x9a, 0xd4, 0x53, 0xbf, 0x36, 0xa5, 0x21, 0x38, 0xf5,
-	0x3e, 0xb7, 0x2c, 0x3d, 0xc3, 0x1b, 0xe2, 0x60, 0x0f, 0x8f, 0x0f, 0xb1, 0xe7, 0x1f, 0x59, 0xd2,
-	0x20, 0xc5, 0x3d, 0xaa, 0x5c, 0xda, 0xa3, 0x52, 0x9b, 0x5c, 0x8d, 0x26, 0x79, 0x88, 0x51, 0x3b,
-	0x9b, 0x85, 0x50, 0x1a, 0x49, 0x1e, 0x67, 0x2a, 0x8d, 0x6c, 0xc1, 0xcf, 0xab, 0x34, 0x32, 0x45,
-	0x0e, 0x47, 0xfa, 0x67, 0x70, 0x69, 0xd7, 0xb1, 0x87, 0xbb, 0x19, 0x81, 0xc5, 0xb3, 0x94, 0xc7,
-	0x39, 0xdc, 0x59, 0xf5, 0x4f, 0x73, 0x70, 0x71, 0x1a, 0xff, 0xef, 0xd3, 0xf5, 0xbb, 0x09, 0x8b,
-	0x34, 0x80, 0x95, 0x71, 0x40, 0x58, 0x27, 0x08, 0xe9, 0x6c, 0x90, 0x98, 0xa8, 0x14, 0xad, 0xa7,
-	0xe3, 0x13, 0xd7, 0xf2, 0x84, 0xcb, 0xbc, 0x96, 0x28, 0xe4, 0xb5, 0x18, 0x5a, 0xfd, 0x0f, 0x39,
-	0x68, 0x4c, 0x6b, 0x20, 0xfa, 0x34, 0x31, 0xae, 0xd2, 0x83, 0xac, 0xd3, 0x3b, 0x5d, 0x0c, 0xed,
-	0xc7, 0xf1, 0xa1, 0xbd, 0x7e, 0x36, 0x83, 0xd8, 0xe8, 0xfe, 0x6a, 0x0e, 0xe6, 0xb9, 0x7f, 0x87,
-	0x3e, 0xcb, 0x3e, 0xa6, 0x65, 0x92, 0x6d, 0x9c, 0xe2, 0x74, 0x66, 0x9d, 0xe1, 0xbe, 0x2d, 0x5a,
-	0xc6, 0x04, 0x5b, 0x4b, 0x9d, 0x83, 0x25, 0x1a, 0x92, 0x3c, 0x5b, 0x9d, 0x3d, 0xf7, 0xd9, 0xea,
-	0x17, 0xb0, 0x16, 0x6e, 0xc9, 0xb8, 0xf1, 0xe3, 0x87, 0xfe, 0x61, 0xb4, 0xf4, 0xf2, 0x19, 0x46,
-	0x52, 0x5b, 0xf1, 0x32, 0x4d, 0xf5, 0x43, 0x40, 0x13, 0x1f, 0x47, 0xa6, 0x85, 0xe9, 0xdb, 0xb9,
-	0xe4, 0x41, 0x57, 0x52, 0x45, 0x69, 0xca, 0x24, 0xa9, 0x19, 0x53, 0x87, 0x11, 0xc5, 0x64, 0xeb,
-	0xa6, 0x1f, 0x46, 0x88, 0xe6, 0x05, 0x74, 0x99, 0xea, 0x63, 0xb1, 0x4e, 0xf9, 0xd1, 0xeb, 0xe5,
-	0x33, 0x96, 0x33, 0x6f, 0x5e, 0x4a, 0xa9, 0x18, 0xb0, 0x41, 0xf6, 0x6d, 0xfa, 0x94, 0x53, 0x05,
-	0x76, 0x54, 0xab, 0x9e, 0x3d, 0xa1, 0xd8, 0xc9, 0x43, 0xe6, 0xfc, 0x8e, 0x1d, 0x70, 0x96, 0xcf,
-	0x71, 0xc0, 0xd9, 0x12, 0x2f, 0xd6, 0x25, 0xd7, 0x84, 0x2f, 0xea, 0x70, 0xf9, 0xf3, 0x4f, 0x74,
-	0x01, 0x4a, 0x34, 0xc4, 0x3b, 0x36, 0x4e, 0xb8, 0x66, 0x99, 0x27, 0xdf, 0x7b, 0xc6, 0x89, 0xba,
-	0x4d, 0xdf, 0x5b, 0xc4, 0xbd, 0x95, 0x17, 0xe7, 0xf2, 0x15, 0x94, 0x42, 0x2e, 0xe8, 0x76, 0x62,
-	0xa5, 0x36, 0xd2, 0xcd, 0x48, 0x4c, 0xe8, 0xb7, 0xe2, 0x2b, 0x73, 0x2d, 0x5d, 0x20, 0xb6, 0x12,
-	0x27, 0x50, 0xe4, 0x77, 0x5a, 0x36, 0xa0, 0x6c, 0xb9, 0x7a, 0xec, 0x5a, 0x4b, 0xc9, 0x0a, 0x2f,
-	0xbc, 0xbc, 0x06, 0xf5, 0xb1, 0xe1, 0x7f, 0xc5, 0xfd, 0x6a, 0x7d, 0x6c, 0xd9, 0x5c, 0xea, 0x1a,
-	0x01, 0x33, 0x9f, 0x7a, 0xcf, 0xb2, 0x53, 0x74, 0xc6, 0x09, 0xdf, 0x79, 0xc9, 0x74, 0xc6, 0x89,
-	0xfa, 0xab, 0x1c, 0x40, 0xf4, 0xba, 0xe0, 0x8f, 0x7c, 0x02, 0x42, 0x60, 0x23, 0xcb, 0x0f, 0xe8,
-	0x25, 0xc8, 0xb2, 0x46, 0x7f, 0xd3, 0x5b, 0xed, 0xf1, 0x1b, 0x2e, 0x4a, 0x72, 0xda, 0x4b, 0xd7,
-	0x5a, 0x76, 0xa0, 0xb4, 0x67, 0x04, 0xfd, 0x23, 0x22, 0xcc, 0xf5, 0x98, 0x30, 0x92, 0x3b, 0x42,
-	0x29, 0xce, 0x78, 0x8d, 0xf2, 0x18, 0xaa, 0xb1, 0x7d, 0xc6, 0xad, 0x18, 0x33, 0x69, 0xf9, 0xca,
-	0x54, 0x12, 0xcf, 0x55, 0x28, 0x4a, 0x7b, 0x97, 0x9a, 0xc6, 0xbf, 0xd4, 0xff, 0x3b, 0x07, 0xb0,
-	0xe5, 0xd8, 0xa6, 0xc5, 0x74, 0xc4, 0x1d, 0xe0, 0xef, 0x1f, 0xf5, 0xe8, 0x49, 0x07, 0x4a, 0x48,
-	0x7a, 0x80, 0x03, 0xad, 0xcc, 0xa8, 0x48, 0xb3, 0xde, 0x85, 0xaa, 0x38, 0x9e, 0x21, 0x85, 0xf2,
-	0x53, 0x0b, 0x89, 0xbb, 0x76, 0xa4, 0xd8, 0x47, 0xb0, 0x90, 0xd8, 0x54, 0xcd, 0x26, 0xa3, 0xbb,
-	0x72, 0x53, 0xb4, 0xaa, 0x21, 0x37, 0xff, 0x2e, 0x54, 0xc2, 0xd2, 0xa4, 0xce, 0xc2, 0x74, 0x41,
-	0x59, 0x31, 0x52, 0xe3, 0x7b, 0xe2, 0xb1, 0x77, 0xf0, 0x94, 0x96, 0x9a, 0x9b, 0x5a, 0xaa, 0x2a,
-	0x08, 0x49, 0xc1, 0x4f, 0x60, 0x91, 0xec, 0x98, 0xe2, 0x85, 0x8b, 0x53, 0x0b, 0xd7, 0xf1, 0x49,
-	0xb0, 0x25, 0x97, 0xbf, 0x0c, 0x15, 0xcf, 0xfd, 0xca, 0x22, 0xaa, 0x68, 0x32, 0x0a, 0xa8, 0x9a,
-	0x9b, 0xd3, 0xc0, 0x63, 0x8f, 0xcf, 0x26, 0xa3, 0x00, 0x7d, 0x0c, 0x10, 0xbd, 0x28, 0xe3, 0xc7,
-	0xd8, 0xd2, 0xe1, 0x49, 0x34, 0x3e, 0x5c, 0x23, 0x92, 0x61, 0x2d, 0x8b, 0x07, 0x67, 0xe8, 0x3e,
-	0x2c, 0x8d, 0x88, 0x36, 0x4c, 0x48, 0x58, 0x9e, 0x2a, 0xe1, 0x22, 0x25, 0x8f, 0xc9, 0x78, 0x1d,
-	0x94, 0x68, 0x5b, 0x68, 0xeb, 0x74, 0xda, 0x03, 0x9d, 0xf6, 0x35, 0x9b, 0xef, 0x06, 0xed, 0x5d,
-	0x32, 0xff, 0x6f, 0x43, 0x25, 0xbc, 0x14, 0xa2, 0x5b, 0x36, 0x3d, 0xd5, 0x5e, 0x90, 0x97, 0x00,
-	0xbf, 0x8e, 0x52, 0xe6, 0xb7, 0x42, 0xda, 0xb6, 0x7a, 0x04, 0x65, 0x21, 0x36, 0x5a, 0x82, 0xba,
-	0xd6, 0x79, 0xd4, 0x6b, 0xe9, 0xbd, 0x2f, 0xbb, 0xe2, 0x06, 0xef, 0x1a, 0x2c, 0x49, 0xc0, 0xf6,
-	0x7e, 0xaf, 0xa5, 0xed, 0x37, 0x77, 0x95, 0x5c, 0x02, 0xd1, 0x7a, 0xc2, 0x11, 0x79, 0xb4, 0x0c,
-	0x8a, 0x84, 0xe0, 0x8f, 0xb2, 0xd5, 0x01, 0xd4, 0x45, 0xa3, 0x9a, 0x2c, 0x23, 0xc2, 0x9d, 0xd8,
-	0x3a, 0xb9, 0x28, 0x77, 0x6a, 0x8c, 0x50, 0x5a, 0x2a, 0x57, 0xa0, 0x12, 0x76, 0xa4, 0x25, 0xde,
-	0xf7, 0xc9, 0x20, 0x75, 0x1f, 0xca, 0x7b, 0xd8, 0xe4, 0x35, 0xbc, 0x11, 0xab, 0x61, 0x4d, 0x3e,
-	0xcf, 0x31, 0x53, 0xbc, 0x97, 0x61, 0xee, 0xd8, 0x18, 0x4d, 0xc2, 0xe7, 0xcf, 0xec, 0x43, 0xd5,
-	0xa1, 0xde, 0xf4, 0xbb, 0x1e, 0x76, 0xb1, 0x1d, 0x72, 0x55, 0x60, 0xd6, 0xf0, 0x6d, 0xee, 0x57,
-	0x93, 0x9f, 0x64, 0x05, 0x13, 0x0a, 0x43, 0x1c, 0x94, 0xb0, 0x2f, 0xa4, 0x42, 0x8d, 0x98, 0xf5,
-	0x11, 0x1e, 0x04, 0xfa, 0xd8, 0xf1, 0xc3, 0x1b, 0x65, 0x95, 0x89, 0x8f, 0x77, 0xf1, 0x20, 0xd8,
-	0x73, 0xe8, 0x9d, 0xf8, 0x1a, 0xbf, 0x96, 0xca, 0xd9, 0x9f, 0xfa, 0x94, 0xd4, 0xc7, 0xa3, 0x01,
-	0xf7, 0x40, 0xe9, 0x6f, 0xf5, 0x3a, 0xd4, 0x77, 0x69, 0xa8, 0xdb, 0xc3, 0x03, 0xce, 0x40, 0x34,
-	0x84, 0x1f, 0xe6, 0xb0, 0x86, 0xfc, 0xbf, 0x59, 0x98, 0x67, 0x04, 0x7e, 0x74, 0xeb, 0xc9, 0x60,
-	0x99, 0x2f, 0x52, 0x3a, 0x98, 0x4e, 0x0a, 0x46, 0xcd, 0x6f, 0x3d, 0x71, 0xde, 0xef, 0x41, 0x39,
-	0x3a, 0xe7, 0xcc, 0x27, 0xaf, 0x2d, 0x25, 0x06, 0x4e, 0x8b, 0x68, 0xd1, 0x35, 0x98, 0x1d, 0x73,
-	0xf7, 0x38, 0xb6, 0xdf, 0x13, 0x23, 0xa1, 0x11, 0x3c, 0x7a, 0x1f, 0x80, 0x28, 0x0f, 0xd6, 0xdf,
-	0x5c, 0x77, 0x5c, 0x88, 0xa9, 0x1d, 0x79, 0x28, 0xa8, 0x0a, 0x61, 0x00, 0xf4, 0x09, 0xd4, 0x62,
-	0x9a, 0x80, 0xab, 0x90, 0x53, 0xa4, 0xab, 0xca, 0xca, 0x00, 0xdd, 0x81, 0x79, 0x7e, 0x6f, 0x98,
-	0xeb, 0x0f, 0x69, 0xba, 0xc4, 0x06, 0x48, 0x0b, 0xe9, 0x88, 0xb0, 0xfc, 0xe0, 0xc1, 0xc3, 0x03,
-	0xee, 0x22, 0x5d, 0x90, 0xbd, 0x98, 0xd8, 0xb8, 0x84, 0x67, 0x12, 0x1e, 0x1e, 0xa0, 0xfb, 0x50,
-	0x4f, 0xa8, 0x05, 0xee, 0x04, 0x9d, 0x22, 0xee, 0x42, 0x5c, 0x33, 0xa8, 0xdf, 0xe6, 0xa0, 0x2c,
-	0x1e, 0xf2, 0x08, 0xc3, 0x94, 0x93, 0x6c, 0xe4, 0x3d, 0x80, 0xbe, 0xd0, 0x4f, 0x7c, 0xb4, 0x96,
-	0xb3, 0x74, 0x97, 0x26, 0xd1, 0xa1, 0x37, 0x60, 0x9e, 0x4d, 0x0b, 0x9f, 0x8f, 0x96, 0x7c, 0xb1,
-	0x8c, 0x21, 0xb4, 0x90, 0x42, 0xfd, 0x1c, 0x8a, 0xdc, 0x37, 0xce, 0x12, 0x20, 0xfe, 0x14, 0x30,
-	0x7f, 0xbe, 0xa7, 0x80, 0x7f, 0x96, 0x03, 0x25, 0x79, 0xa3, 0x0b, 0xdd, 0x88, 0xad, 0xe4, 0xe5,
-	0xe4, 0xdd, 0x2f, 0x69, 0x19, 0xcb, 0x99, 0x33, 0xf2, 0xe7, 0xc8, 0x9c, 0x91, 0x95, 0xe7, 0x48,
-	0x7e, 0x1e, 0x57, 0x38, 0xeb, 0x79, 0x1c, 0x7a, 0x1b, 0xe6, 0x4d, 0x3c, 0x30, 0x88, 0xfd, 0x98,
-	0x3b, 0x6d, 0x21, 0x85, 0x54, 0xea, 0xbf, 0xcb, 0xc1, 0xac, 0xe6, 0x18, 0x68, 0x01, 0xf2, 0x46,
-	0x18, 0x55, 0xc9, 0x1b, 0x3e, 0x7a, 0x05, 0xb8, 0xed, 0x1e, 0xe1, 0xd0, 0xd7, 0x8a, 0x00, 0x44,
-	0xc9, 0x8c, 0x0d, 0x8a, 0xe2, 0xf7, 0x7a, 0xd9, 0x97, 0x74, 0x91, 0xb6, 0x10, 0xbb, 0x98, 0x1c,
-	0x5e, 0x04, 0x9d, 0x3b, 0xfd, 0x41, 0xbf, 0x7a, 0x9d, 0x5d, 0xaf, 0x76, 0x8c, 0xb3, 0x1e, 0xe9,
-	0xb3, 0xf7, 0xc8, 0x94, 0x30, 0x7a, 0x8f, 0xec, 0x39, 0x46, 0xc6, 0x7b, 0x64, 0x42, 0x44, 0x51,
-	0xaa, 0x0f, 0xb3, 0x8f, 0xbd, 0x41, 0xe6, 0xec, 0x58, 0x80, 0xbc, 0xc7, 0xb6, 0xd3, 0x55, 0x2d,
-	0xef, 0x99, 0xd4, 0x1b, 0x65, 0x17, 0xfd, 0x3c, 0xe6, 0xd7, 0x55, 0xb5, 0x12, 0x03, 0x68, 0x34,
-	0x73, 0x0b, 0xbf, 0x46, 0xe8, 0x05, 0x74, 0x4c, 0xaa, 0x5a, 0x89, 0x01, 0xb4, 0x80, 0xdf, 0xc8,
-	0x62, 0x57, 0xd8, 0xf2, 0x96, 0xa9, 0xfe, 0x21, 0x07, 0x45, 0xf6, 0xf6, 0x27, 0xd5, 0xc7, 0x1b,
-	0x50, 0x8e, 0x62, 0xbe, 0x3c, 0xcb, 0x8b, 0x17, 0x06, 0x79, 0x2f, 0x43, 0x85, 0x58, 0x57, 0x6c,
-	0xb3, 0xb3, 0xbb, 0x59, 0xe6, 0x0d, 0x30, 0x10, 0x3d, 0xbb, 0x7b, 0x1d, 0x14, 0x4e, 0xc0, 0x75,
-	0x32, 0x9f, 0x20, 0x65, 0xad, 0xce, 0xe0, 0xcd, 0x10, 0x1c, 0xbb, 0xdd, 0x3b, 0x97, 0xb8, 0xdd,
-	0xfb, 0x66, 0xe6, 0x76, 0x8f, 0x9f, 0x70, 0x25, 0xb7, 0x74, 0xea, 0xef, 0x73, 0x50, 0xa6, 0xf7,
-	0xb3, 0xdb, 0xf6, 0xc0, 0xf9, 0x5e, 0xae, 0x86, 0x5f, 0x87, 0xba, 0x3d, 0x19, 0xeb, 0xd2, 0xb5,
-	0x7c, 0x7e, 0x22, 0xbc, 0x60, 0x4f, 0xc6, 0xf2, 0xb3, 0x86, 0x0b, 0x50, 0xb2, 0x79, 0x38, 0x30,
-	0xbc, 0x80, 0x60, 0xb3, 0x48, 0x20, 0xba, 0x0a, 0x55, 0x82, 0x12, 0xb7, 0x41, 0xd8, 0x91, 0x6f,
-	0xc5, 0x9e, 0x8c, 0x9b, 0x1c, 0xa4, 0x7e, 0x44, 0x9f, 0x7f, 0x69, 0xd6, 0x21, 0x69, 0x48, 0x38,
-	0xdb, 0xc2, 0x7b, 0xc0, 0xa9, 0xd7, 0xaf, 0xa2, 0xc9, 0xec, 0x1e, 0xb0, 0xfa, 0x31, 0xcd, 0xbf,
-	0x26, 0x4a, 0xf3, 0x29, 0x78, 0xde, 0xe2, 0x37, 0xff, 0x26, 0x0f, 0x45, 0x7e, 0x7b, 0x7c, 0x0e,
-	0x72, 0xba, 0x32, 0x83, 0x00, 0x0a, 0xed, 0xee, 0xf1, 0x3d, 0xe5, 0xf9, 0xb3, 0x02, 0xff, 0xbd,
-	0xa9, 0x3c, 0x7f, 0x56, 0x42, 0x35, 0x98, 0x27, 0x70, 0x7d, 0x6f, 0x4b, 0xf9, 0xf6, 0x59, 0x81,
-	0x7f, 0x6e, 0xb2, 0xcf, 0x12, 0xaa, 0x43, 0x99, 0x61, 0xbb, 0xbb, 0x07, 0xca, 0xcf, 0x9f, 0x15,
-	0x38, 0x60, 0x33, 0x04, 0x94, 0xd0, 0x02, 0x94, 0x28, 0xc5, 0xe3, 0xee, 0xbe, 0xf2, 0xec, 0x79,
-	0x81, 0x7f, 0x6f, 0xf2, 0xef, 0x12, 0x5a, 0x84, 0x4a, 0x88, 0x27, 0x4c, 0x9f, 0x3f, 0x2f, 0x70,
-	0xd0, 0x66, 0x04, 0x2a, 0x11, 0x89, 0x1e, 0x13, 0x8e, 0xff, 0xf3, 0x99, 0x49, 0x7e, 0xb7, 0x48,
-	0xe9, 0xdf, 0x3f, 0x33, 0x51, 0x19, 0x66, 0xb5, 0xde, 0x96, 0xf2, 0xf3, 0xe7, 0x05, 0xa4, 0x00,
-	0x50, 0x46, 0xad, 0xfd, 0xad, 0x66, 0x57, 0xf9, 0x37, 0xcf, 0x42, 0xc8, 0xa6, 0x80, 0x94, 0xd0,
-	0x32, 0x2c, 0x3c, 0xd8, 0xed, 0x7c, 0xa1, 0x1f, 0x74, 0x5b, 0x5b, 0x3a, 0x6d, 0xee, 0x2f, 0x9e,
-	0x17, 0x52, 0xd0, 0x4d, 0xe5, 0x17, 0xcf, 0x4b, 0xa8, 0x01, 0x28, 0x4e, 0x4b, 0x45, 0xfe, 0xe5,
-	0xf3, 0x42, 0x0a, 0xb3, 0xc9, 0x31, 0x25, 0xb4, 0x0a, 0x4a, 0x84, 0xd9, 0xbd, 0xcb, 0xe1, 0x26,
-	0x5a, 0x80, 0x62, 0xa7, 0xdb, 0xfc, 0xfc, 0x51, 0x4b, 0xf9, 0xcb, 0xe7, 0xff, 0xf5, 0x59, 0xe1,
-	0xe6, 0x16, 0x94, 0xc2, 0x09, 0x8a, 0x00, 0x8a, 0x3b, 0xbb, 0x9d, 0xfb, 0xcd, 0x5d, 0x65, 0x26,
-	0xca, 0xdb, 0x43, 0x2f, 0xf1, 0x34, 0xb7, 0x7f, 0xac, 0xb7, 0xf7, 0x95, 0x3c, 0xaa, 0xc0, 0x3c,
-	0xf9, 0xdd, 0x79, 0xd4, 0x63, 0x09, 0x7d, 0x1e, 0x6b, 0x0f, 0x94, 0xc2, 0xcd, 0xdd, 0xd8, 0xc3,
-	0x0c, 0x16, 0x3c, 0x41, 0x0a, 0x54, 0x77, 0x3b, 0x9d, 0xcf, 0x1e, 0x75, 0xf5, 0xd6, 0x93, 0xe6,
-	0x56, 0x4f, 0x99, 0x41, 0x8b, 0x50, 0xe3, 0x90, 0xdd, 0xce, 0xfe, 0x4e, 0x4b, 0x53, 0x72, 0x08,
-	0xc1, 0x02, 0x07, 0x1d, 0x3c, 0xec, 0x68, 0xbd, 0x96, 0xa6, 0xe4, 0x6f, 0x7e, 0x4b, 0xdf, 0xec,
-	0x88, 0xcd, 0x26, 0xbd, 0x44, 0xa4, 0xb5, 0x1e, 0xb4, 0x9f, 0x28, 0x33, 0xa8, 0x0a, 0xa5, 0xfd,
-	0x56, 0x7b, 0xe7, 0xe1, 0xfd, 0x0e, 0x29, 0x3d, 0x0f, 0xb3, 0xbd, 0xe6, 0x0e, 0x17, 0xeb, 0x40,
-	0xef, 0x36, 0x7b, 0x0f, 0x95, 0x59, 0x54, 0x83, 0xf2, 0x56, 0x67, 0x6f, 0xef, 0xd1, 0x7e, 0xbb,
-	0xf7, 0xa5, 0x42, 0xc6, 0xb0, 0xd6, 0x7a, 0xd2, 0xd3, 0x23, 0xd0, 0x1c, 0xf1, 0xa8, 0x77, 0x9b,
-	0xda, 0x4e, 0x4b, 0x02, 0x16, 0x19, 0xeb, 0x27, 0x3d, 0xfd, 0x61, 0xa7, 0xab, 0xcc, 0xdf, 0x7c,
-	0x1d, 0xca, 0x62, 0x8f, 0x49, 0xef, 0x35, 0xee, 0x7f, 0x29, 0x5f, 0x70, 0x04, 0x28, 0xb6, 0xf7,
-	0x1f, 0xb7, 0xb4, 0x9e, 0x92, 0xbf, 0x79, 0x13, 0x94, 0xe4, 0x0e, 0x12, 0x15, 0x21, 0xdf, 0xfa,
-	0x5c, 0x99, 0x21, 0x7f, 0x77, 0x5a, 0x4a, 0x8e, 0xfc, 0xdd, 0x6d, 0x29, 0xf9, 0x9b, 0x6f, 0xf3,
-	0xbb, 0x04, 0xdc, 0x6d, 0x8b, 0xae, 0x4e, 0x92, 0x4e, 0xde, 0xda, 0x6a, 0x75, 0x7b, 0x8c, 0xb9,
-	0xd6, 0xfa, 0x71, 0x6b, 0x8b, 0x30, 0x7f, 0x04, 0x4b, 0x19, 0x6e, 0x37, 0x69, 0x94, 0x90, 0x5d,
-	0x6f, 0x6e, 0x6f, 0x2b, 0x33, 0xc4, 0xbf, 0x8f, 0x40, 0x5a, 0x6b, 0xaf, 0xf3, 0x98, 0x54, 0xbc,
-	0x02, 0x8b, 0x32, 0x94, 0xdf, 0xc9, 0xbc, 0xf9, 0x16, 0xd4, 0x62, 0xbe, 0x36, 0xe9, 0xc1, 0xbd,
-	0xd6, 0xb6, 0xbe, 0xd7, 0x21, 0xac, 0xea, 0x50, 0x21, 0x1f, 0x21, 0x79, 0xee, 0xe6, 0x9b, 0x00,
-	0x91, 0x41, 0x17, 0x29, 0xd3, 0x48, 0x27, 0xec, 0x75, 0x3b, 0x1a, 0x97, 0xb9, 0xf5, 0x84, 0xfe,
-	0xce, 0xdf, 0xfd, 0xf5, 0x15, 0x28, 0xed, 0x90, 0xf5, 0xde, 0x74, 0x2d, 0xb4, 0x0b, 0x15, 0xe9,
-	0x71, 0x27, 0x7a, 0x25, 0xe6, 0x66, 0x24, 0xde, 0x8c, 0xae, 0x5f, 0x9c, 0x82, 0xe5, 0x8f, 0x4d,
-	0x66, 0x50, 0x1b, 0x20, 0x7a, 0xfe, 0x89, 0x36, 0x64, 0xf2, 0xc4, 0x4b, 0xd1, 0xf5, 0x57, 0xb2,
-	0x91, 0x82, 0xd5, 0x03, 0x28, 0x8b, 0x47, 0xaf, 0x48, 0x8a, 0x06, 0x24, 0x5f, 0xc7, 0xae, 0x6f,
-	0x64, 0xe2, 0x04, 0x9f, 0x5d, 0xa8, 0x48, 0x19, 0xfc, 0xe4, 0x06, 0xa6, 0x53, 0x02, 0xca, 0x0d,
-	0xcc, 0x4a, 0xfb, 0x37, 0x83, 0x1e, 0xc1, 0x42, 0x3c, 0x77, 0x1f, 0xba, 0x2c, 0x87, 0x60, 0x32,
-	0x52, 0x02, 0xae, 0x5f, 0x99, 0x4e, 0x20, 0x0b, 0x29, 0x25, 0xb2, 0x94, 0x85, 0x4c, 0xe7, 0xce,
-	0x94, 0x85, 0xcc, 0xc8, 0x7e, 0xa9, 0xce, 0x20, 0x0d, 0x6a, 0xb1, 0xa4, 0x78, 0xe8, 0x52, 0xcc,
-	0xdc, 0xa5, 0x39, 0x5e, 0x9e, 0x8a, 0x17, 0x3c, 0xff, 0x29, 0x2c, 0xa6, 0x92, 0xed, 0x21, 0xf5,
-	0xec, 0xa4, 0x7f, 0xeb, 0x3f, 0x38, 0x95, 0x46, 0xf0, 0xff, 0x27, 0xa0, 0x24, 0x93, 0xea, 0x21,
-	0xe9, 0x06, 0xc6, 0x94, 0x5c, 0x7e, 0xeb, 0xea, 0x69, 0x24, 0xf2, 0xa8, 0xc5, 0x53, 0xec, 0xc9,
-	0xa3, 0x96, 0x99, 0xaf, 0x4f, 0x1e, 0xb5, 0x29, 0xd9, 0xf9, 0x66, 0xd0, 0x13, 0xa8, 0x27, 0xb2,
-	0xe8, 0x21, 0x79, 0xb0, 0x33, 0x53, 0xf7, 0xad, 0x5f, 0x3d, 0x85, 0x42, 0x70, 0xfe, 0x18, 0x8a,
-	0xcc, 0x68, 0xa3, 0xb5, 0xd8, 0x60, 0x47, 0x0f, 0xc9, 0xd6, 0x1b, 0x69, 0x84, 0x28, 0xfe, 0x1e,
-	0xcc, 0xf3, 0x97, 0x71, 0x28, 0x4e, 0x26, 0x3d, 0x96, 0x5b, 0x4f, 0x3c, 0xa2, 0x54, 0x67, 0x6e,
-	0xe7, 0xc8, 0x3c, 0x94, 0x5e, 0x91, 0xc9, 0xf3, 0x30, 0xfd, 0x94, 0x4d, 0x9e, 0x87, 0x59, 0x4f,
-	0xcf, 0x66, 0xd0, 0xa7, 0x30, 0xcf, 0x23, 0xa9, 0x28, 0x1d, 0x8d, 0x0d, 0xb9, 0x5c, 0xc8, 0xc0,
-	0xc8, 0xfa, 0x24, 0x4a, 0xe7, 0x29, 0xeb, 0x93, 0x54, 0x42, 0x52, 0x59, 0x9f, 0x64, 0x64, 0x00,
-	0x9d, 0x41, 0xdb, 0x00, 0x51, 0xb2, 0x39, 0x99, 0x55, 0x2a, 0x05, 0xdd, 0x7a, 0xf6, 0xfb, 0x4b,
-	0xda, 0x41, 0x1f, 0x8a, 0x04, 0x7b, 0xd1, 0xfd, 0x72, 0xf9, 0xf1, 0x52, 0x98, 0x4f, 0x76, 0x3d,
-	0x91, 0xfa, 0x93, 0x16, 0x7e, 0x00, 0x65, 0x91, 0xf1, 0x50, 0x56, 0x69, 0xc9, 0x7c, 0x8b, 0xb2,
-	0x4a, 0x4b, 0xa7, 0x48, 0x64, 0xbd, 0x22, 0xf2, 0x21, 0xc6, 0x7a, 0x25, 0x99, 0x3a, 0x31, 0xd6,
-	0x2b, 0xe9, 0x14, 0x8a, 0x33, 0xe8, 0x21, 0x94, 0x45, 0x0e, 0x43, 0x59, 0xa4, 0x64, 0x66, 0x45,
-	0x59, 0xa4, 0x74, 0xd2, 0xc3, 0x99, 0x1b, 0x39, 0x32, 0x65, 0x59, 0xd6, 0x40, 0xb4, 0x36, 0x25,
-	0x69, 0xe1, 0x7a, 0x23, 0x8d, 0x90, 0xd5, 0xbd, 0x48, 0x10, 0x28, 0x0b, 0x92, 0xcc, 0x3b, 0xb8,
-	0xbe, 0x91, 0x89, 0x93, 0xe7, 0x1c, 0x4f, 0x89, 0x96, 0x98, 0xfa, 0x52, 0x2e, 0x2d, 0x79, 0xce,
-	0x25, 0xf2, 0xa7, 0x89, 0x59, 0x9b, 0xe4, 0x10, 0x4f, 0x95, 0x96, 0x98, 0xb5, 0x09, 0x0e, 0x62,
-	0xd6, 0x52, 0x26, 0x29, 0x81, 0x65, 0x3e, 0xaf, 0x64, 0x23, 0x65, 0x56, 0x51, 0xb6, 0x32, 0x94,
-	0x9a, 0x17, 0x53, 0x58, 0x65, 0x24, 0x38, 0xa3, 0x36, 0x46, 0x4a, 0x59, 0x86, 0xd2, 0x33, 0x43,
-	0x66, 0x76, 0x71, 0x0a, 0x56, 0x1e, 0x2f, 0x91, 0x70, 0x4c, 0x1e, 0xaf, 0x64, 0xde, 0x32, 0x79,
-	0xbc, 0xd2, 0x19, 0xca, 0xa8, 0xad, 0x8a, 0x25, 0x2f, 0x93, 0x6d, 0x55, 0x56, 0x1e, 0x34, 0xd9,
-	0x56, 0x65, 0x67, 0x3d, 0x13, 0xda, 0xd3, 0x31, 0x92, 0xda, 0x53, 0x6c, 0xd6, 0x93, 0xda, 0x33,
-	0xda, 0x9c, 0xb3, 0x8e, 0x92, 0x12, 0x8d, 0xa1, 0x54, 0xbf, 0xca, 0xc9, 0xd4, 0xe4, 0x8e, 0xca,
-	0xca, 0x4e, 0x36, 0xc3, 0xd7, 0x05, 0xd9, 0xcc, 0xc7, 0xd7, 0x45, 0x94, 0x24, 0x2c, 0xb1, 0x2e,
-	0xe4, 0x44, 0x60, 0xd2, 0xba, 0x20, 0x1c, 0x52, 0xeb, 0x42, 0x62, 0xb2, 0x91, 0x89, 0x4b, 0xf4,
-	0x49, 0x42, 0x8c, 0x58, 0xe2, 0xb4, 0x44, 0x9f, 0xc4, 0x8b, 0x6b, 0x34, 0xda, 0x21, 0x9d, 0x43,
-	0x5d, 0x8a, 0x11, 0xa7, 0x52, 0x68, 0xc9, 0xc3, 0x94, 0x99, 0x73, 0x8c, 0xf1, 0x8c, 0xe5, 0x02,
-	0x93, 0x79, 0x66, 0x25, 0x19, 0x93, 0x79, 0x66, 0x27, 0x11, 0xa3, 0x6e, 0x44, 0x32, 0xe3, 0x97,
-	0xec, 0x46, 0x4c, 0x49, 0x31, 0x26, 0xbb, 0x11, 0x53, 0x13, 0x86, 0x51, 0x1f, 0x28, 0x95, 0xee,
-	0x4b, 0xf6, 0x81, 0xa6, 0xe5, 0x13, 0x93, 0x7d, 0xa0, 0xe9, 0xf9, 0xc2, 0x66, 0x50, 0x07, 0xaa,
-	0x72, 0x6a, 0x30, 0x14, 0x77, 0xf4, 0x92, 0x59, 0xb0, 0xd6, 0x2f, 0x4d, 0x43, 0xcb, 0x0c, 0xe5,
-	0xa4, 0x5e, 0x28, 0xee, 0xde, 0x9e, 0xc6, 0x30, 0x33, 0x17, 0x18, 0xf3, 0x78, 0xe2, 0xe9, 0xba,
-	0x50, 0xca, 0xbd, 0x4d, 0xb1, 0xbd, 0x7a, 0x0a, 0x85, 0x3c, 0x70, 0xc9, 0xfc, 0x5c, 0xf2, 0xc0,
-	0x4d, 0xc9, 0x04, 0xb6, 0xae, 0x9e, 0x46, 0x92, 0xd8, 0x4b, 0xf0, 0x88, 0x6b, 0x7c, 0x2f, 0x11,
-	0xcb, 0x36, 0x95, 0xd8, 0x4b, 0x24, 0x52, 0x3b, 0x51, 0x3e, 0x22, 0x9b, 0x91, 0xcc, 0x27, 0x99,
-	0xe6, 0x4b, 0xe6, 0x93, 0x4e, 0xc4, 0x45, 0xc7, 0x45, 0xce, 0x43, 0x24, 0x8f, 0x4b, 0x46, 0x86,
-	0x2e, 0x79, 0x5c, 0x32, 0x93, 0x67, 0x71, 0x8f, 0x5f, 0x4a, 0x2c, 0x14, 0xf7, 0xf8, 0xd3, 0x69,
-	0xb5, 0xe2, 0x1e, 0x7f, 0x56, 0x1e, 0xab, 0x19, 0x64, 0xd2, 0xfc, 0x75, 0xa9, 0x90, 0xf2, 0xab,
-	0x19, 0x5d, 0x94, 0xca, 0x92, 0xb4, 0x7e, 0xed, 0x0c, 0x2a, 0xb9, 0x96, 0x8c, 0x04, 0x51, 0x72,
-	0x2d, 0xd3, 0x33, 0x53, 0xc9, 0xb5, 0x9c, 0x96, 0x65, 0x6a, 0x06, 0x8d, 0xc3, 0x2c, 0x76, 0xa9,
-	0x8a, 0xae, 0x67, 0xf7, 0x6d, 0xba, 0xae, 0x1b, 0x67, 0x13, 0x8a, 0xea, 0x5c, 0x91, 0xba, 0x2e,
-	0x1d, 0x91, 0x9f, 0xd2, 0xf1, 0xe9, 0x0a, 0x5f, 0x3f, 0x07, 0xa5, 0xec, 0x27, 0x44, 0x51, 0x3e,
-	0xb4, 0x91, 0xdc, 0x1b, 0x48, 0x91, 0xc3, 0xf5, 0x57, 0xb2, 0x91, 0x21, 0xab, 0xc3, 0x22, 0xfd,
-	0x0f, 0x0e, 0xef, 0xfc, 0x6d, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc6, 0x60, 0x6e, 0x92, 0xd0, 0x61,
+	0x96, 0x58, 0x91, 0xa2, 0x28, 0xf2, 0x91, 0x14, 0x53, 0x21, 0xa9, 0xc4, 0xa2, 0xba, 0x3e, 0x9d,
+	0x33, 0x3d, 0x55, 0x5d, 0xd3, 0x5d, 0xdd, 0x55, 0xdd, 0xa3, 0xee, 0xed, 0x9e, 0x9e, 0x1d, 0xb6,
+	0xc4, 0x52, 0x71, 0x5b, 0x12, 0xd9, 0x29, 0x56, 0x4d, 0xf5, 0x78, 0xc7, 0xe9, 0x2c, 0x32, 0x28,
+	0xa5, 0x9b, 0xcc, 0xcc, 0xc9, 0x4c, 0xaa, 0xab, 0x6c, 0xc0, 0xae, 0x1a, 0xef, 0xae, 0x0d, 0x18,
+	0x73, 0xf1, 0xd5, 0x36, 0x7c, 0xd8, 0xdb, 0x02, 0x3e, 0x18, 0x30, 0x60, 0xc0, 0x27, 0x1b, 0xf0,
+	0xae, 0x0d, 0x2f, 0xe0, 0x8b, 0x7d, 0xb6, 0x4f, 0xbe, 0xdb, 0x80, 0x0f, 0x36, 0xe0, 0x83, 0xf1,
+	0x22, 0x22, 0x23, 0x23, 0x3f, 0x94, 0x54, 0xbd, 0xdd, 0x36, 0x0c, 0xec, 0x49, 0xcc, 0xf7, 0x5e,
+	0xbc, 0x78, 0xf1, 0x7b, 0xf1, 0xe2, 0xc5, 0x8b, 0x27, 0xa8, 0x9d, 0xb8, 0xcf, 0x4e, 0xbc, 0x7b,
+	0x9e, 0xef, 0x86, 0x2e, 0xa9, 0xb0, 0x0f, 0xcb, 0xb3, 0xf5, 0x5f, 0x02, 0xd9, 0xa7, 0xe1, 0x11,
+	0xb5, 0x4f, 0x4e, 0x9f, 0xb9, 0xbe, 0x41, 0x7f, 0x3d, 0xa7, 0x41, 0x48, 0xee, 0x82, 0x46, 0x1d,
+	0xeb, 0xd9, 0x94, 0x76, 0xc6, 0x67, 0xd4, 0x0f, 0xed, 0x80, 0x8e, 0x5b, 0x85, 0x5b, 0x85, 0x3b,
+	0x15, 0x23, 0x03, 0x27, 0x2d, 0x58, 0xb1, 0xc6, 0x63, 0x9f, 0x06, 0x41, 0xab, 0x78, 0xab, 0x70,
+	0xa7, 0x6a, 0x44, 0x9f, 0xfa, 0xa7, 0xb0, 0x9e, 0xe0, 0x1d, 0x78, 0xae, 0x13, 0x50, 0xf2, 0x43,
+	0x58, 0xf6, 0x28, 0xf5, 0x83, 0x56, 0xe1, 0xd6, 0xd2, 0x9d, 0xda, 0x83, 0xd5, 0x7b, 0x91, 0x30,
+	0xf7, 0x06, 0x94, 0xfa, 0x06, 0x47, 0xea, 0xaf, 0x0a, 0x50, 0xed, 0xf8, 0x27, 0xf3, 0x19, 0x75,
+	0xc2, 0x80, 0xdc, 0x83, 0x8a, 0x4f, 0x03, 0x77, 0xee, 0x8f, 0x28, 0x13, 0x64, 0xf5, 0x01, 0x89,
+	0x8b, 0x19, 0x02, 0x63, 0x48, 0x1a, 0x72, 0x15, 0xca, 0x13, 0x6b, 0x66, 0x4f, 0x5f, 0x30, 0x99,
+	0x1a, 0x86, 0xf8, 0x22, 0x04, 0x4a, 0x8e, 0x35, 0xa3, 0xad, 0x25, 0x26, 0x29, 0xfb, 0x8d, 0x0d,
+	0x18, 0xcd, 0x7d, 0x9f, 0x3a, 0x61, 0xab, 0xc4, 0xda, 0x18, 0x7d, 0xea, 0x7f, 0x13, 0x56, 0x3b,
+	0xe3, 0xf1, 0xc0, 0x0a, 0x4f, 0xa3, 0x8e, 0x79, 0x5d, 0x39, 0x36, 0xa1, 0x7c, 0xe6, 0x4f, 0x4c,
+	0x7b, 0x2c, 0xfa, 0x66, 0xf9, 0xcc, 0x9f, 0xf4, 0xc6, 0x44, 0x87, 0x92, 0x67, 0x85, 0xa7, 0x4c,
+	0x8c, 0x64, 0x0f, 0x60, 0x5d, 0x0c, 0xa7, 0xbf, 0x05, 0x4d, 0x59, 0xb9, 0xe8, 0x39, 0x02, 0xa5,
+	0xf9, 0xdc, 0xe6, 0x43, 0x51, 0x37, 0xd8, 0x6f, 0xfd, 0x4f, 0x0a, 0xb0, 0xb6, 0x47, 0xa7, 0x34,
+	0xa4, 0xdf, 0x83, 0x9c, 0x71, 0x37, 0x2e, 0x25, 0xba, 0x31, 0x92, 0xbf, 0xb4, 0x58, 0x7e, 0x29,
+	0xec, 0xb2, 0x22, 0xec, 0x06, 0x10, 0x55, 0x56, 0xde, 0x2c, 0xfd, 0x63, 0x20, 0x9d, 0xf1, 0x38,
+	0x3d, 0x07, 0xb1, 0x0e, 0x4a, 0xfd, 0x56, 0x21, 0x53, 0x07, 0xce, 0x12, 0x86, 0xd3, 0x37, 0x61,
+	0x3d, 0x51, 0x52, 0x30, 0xfc, 0x14, 0x36, 0x79, 0x35, 0xdf, 0x86, 0x67, 0x0b, 0xae, 0xa6, 0x0b,
+	0x0b, 0xb6, 0x4f, 0x60, 0xc3, 0xa0, 0x41, 0x76, 0xb5, 0x28, 0x2b, 0xa0, 0x90, 0x58, 0x01, 0xe4,
+	0x87, 0xd0, 0x18, 0xb9, 0xb3, 0xd9, 0xdc, 0xb1, 0x47, 0x56, 0x68, 0xbb, 0x8e, 0xe8, 0xdd, 0x24,
+	0x50, 0xdf, 0x82, 0xcd, 0x14, 0x5f, 0x51, 0xe1, 0xbf, 0x2c, 0x40, 0xeb, 0xd8, 0x9d, 0x84, 0xaf,
+	0x59, 0xeb, 0x31, 0x54, 0xc7, 0xb6, 0x4f, 0x47, 0xb2, 0xc6, 0xd5, 0x07, 0x3f, 0x89, 0x9b, 0xba,
+	0x88, 0x61, 0x8c, 0xd8, 0x8b, 0x0a, 0x1b, 0x31, 0x1f, 0xfd, 0x3d, 0x20, 0x59, 0x02, 0x52, 0x86,
+	0x62, 0xef, 0x48, 0xbb, 0x42, 0x56, 0x60, 0xa9, 0xff, 0x78, 0xa8, 0x15, 0x48, 0x05, 0x4a, 0x9f,
+	0xf7, 0x87, 0x8f, 0xb4, 0xa2, 0xbe, 0x0d, 0xd7, 0x72, 0xaa, 0x12, 0x2d, 0xfb, 0x0a, 0xb6, 0x8e,
+	0x4f, 0xe7, 0xe1, 0xd8, 0xfd, 0xc6, 0xf9, 0xae, 0x7b, 0xb3, 0x0d, 0xad, 0x2c, 0x6b, 0x51, 0xed,
+	0x7d, 0xd8, 0xec, 0x32, 0xfd, 0x75, 0xe9, 0x4a, 0x71, 0x3a, 0xa4, 0x8b, 0x08, 0x66, 0x4f, 0xe1,
+	0xea, 0x9e, 0x1d, 0xbc, 0x16, 0xb7, 0x4b, 0x36, 0xe1, 0x1a, 0x6c, 0x65, 0x38, 0x8b, 0x4a, 0x4f,
+	0x40, 0xe3, 0xe2, 0x1c, 0xfa, 0x61, 0x54, 0xdd, 0x36, 0x54, 0xc7, 0xf3, 0x99, 0x67, 0x86, 0x2f,
+	0x3c, 0xbe, 0xda, 0x97, 0x8d, 0x0a, 0x02, 0x86, 0x2f, 0x3c, 0x4a, 0xda, 0x50, 0x99, 0xd8, 0x53,
+	0xca, 0xb4, 0x1e, 0xaf, 0x4c, 0x7e, 0x23, 0xce, 0x76, 0x42, 0xea, 0x9f, 0x59, 0x53, 0xb6, 0xc0,
+	0x4b, 0x86, 0xfc, 0xd6, 0xd7, 0x61, 0x4d, 0xa9, 0x48, 0xd4, 0xbe, 0x0e, 0x6b, 0x42, 0xb0, 0xb8,
+	0x7a, 0xb6, 0xa8, 0xed, 0x20, 0x4d, 0xfa, 0xb7, 0x41, 0xeb, 0x39, 0x7f, 0x9d, 0x8e, 0x42, 0x45,
+	0xd0, 0xef, 0x48, 0x2b, 0xe1, 0x06, 0x62, 0x85, 0xa7, 0x41, 0x6b, 0x29, 0xb3, 0x81, 0xa0, 0x5a,
+	0xe1, 0x48, 0x94, 0x55, 0x11, 0x40, 0x48, 0xf5, 0xaf, 0x0a, 0xd0, 0xe8, 0x8c, 0xc7, 0x9f, 0xcf,
+	0xbc, 0x8b, 0xc7, 0x8a, 0x40, 0xc9, 0x73, 0xfd, 0x50, 0xec, 0x20, 0xec, 0x37, 0xf9, 0x29, 0x94,
+	0x58, 0x2f, 0x2f, 0x31, 0xe9, 0xef, 0xc4, 0x35, 0x27, 0x98, 0xde, 0x3b, 0x74, 0x1d, 0x3b, 0x74,
+	0x7d, 0xdb, 0x39, 0x19, 0xb8, 0x53, 0x7b, 0xf4, 0xc2, 0x60, 0xa5, 0xf4, 0x5d, 0xd0, 0xd2, 0x18,
+	0x5c, 0x39, 0x03, 0xa3, 0xab, 0x5d, 0xc1, 0x95, 0x33, 0xe8, 0x1f, 0x27, 0xd6, 0x10, 0xa9, 0xc2,
+	0xf2, 0x41, 0x7f, 0xb7, 0x73, 0xa0, 0x2d, 0x21, 0x5d, 0xe7, 0xe0, 0x40, 0x2b, 0xe9, 0x1a, 0xac,
+	0x46, 0x95, 0x89, 0x46, 0xfd, 0x1c, 0x34, 0xae, 0xb1, 0xbe, 0x6d, 0xb3, 0xd8, 0xb8, 0xc6, 0x1c,
+	0x04, 0xdb, 0x21, 0xac, 0x09, 0x69, 0x0d, 0xfb, 0x59, 0xc4, 0xf7, 0x2d, 0x58, 0x0e, 0x71, 0xa8,
+	0x85, 0x0a, 0x6d, 0xc6, 0x3d, 0x30, 0x44, 0xb0, 0xc1, 0xb1, 0xea, 0x9e, 0x5a, 0x4c, 0xee, 0xa9,
+	0x5d, 0xa8, 0x18, 0x83, 0x2f, 0x7a, 0xbb, 0xae, 0x33, 0x39, 0x47, 0xc8, 0x9b, 0x50, 0xf3, 0xe9,
+	0xcc, 0x0d, 0xa9, 0x29, 0x65, 0xad, 0x1a, 0xc0, 0x41, 0x03, 0x94, 0xf8, 0x1f, 0x95, 0xa0, 0x8a,
+	0x7c, 0x8e, 0x43, 0x2b, 0x64, 0xdb, 0xfd, 0xdc, 0x0b, 0xed, 0x19, 0x17, 0x6b, 0xc9, 0x10, 0x5f,
+	0x38, 0xc1, 0x51, 0x0f, 0x30, 0x4c, 0x91, 0x61, 0xe4, 0x37, 0x59, 0x85, 0xe2, 0xdc, 0x63, 0x03,
+	0x59, 0x31, 0x8a, 0x73, 0x8f, 0x57, 0x39, 0x72, 0xfd, 0xb1, 0x69, 0x7b, 0x67, 0x1f, 0xb2, 0xad,
+	0xad, 0x61, 0x00, 0x07, 0xf5, 0xbc, 0xb3, 0x0f, 0x93, 0x04, 0x3b, 0xad, 0xe5, 0x14, 0xc1, 0x0e,
+	0x12, 0x78, 0x3e, 0x9d, 0xd8, 0xcf, 0x39, 0x87, 0x32, 0x27, 0xe0, 0xa0, 0x88, 0x43, 0x4c, 0xb0,
+	0xd3, 0x5a, 0x49, 0x11, 0xec, 0x60, 0x3b, 0x02, 0xea, 0xdb, 0xd6, 0xb4, 0x55, 0xe1, 0xfb, 0x2d,
+	0xff, 0x22, 0x3f, 0x80, 0x86, 0x4f, 0x47, 0xd4, 0x3e, 0xa3, 0x42, 0xba, 0x2a, 0x6b, 0x4c, 0x3d,
+	0x02, 0x32, 0xee, 0x29, 0xa2, 0x9d, 0x16, 0x64, 0x88, 0x76, 0x90, 0x88, 0xf3, 0x34, 0x1d, 0x37,
+	0xb4, 0x27, 0x2f, 0x5a, 0x35, 0x4e, 0xc4, 0x81, 0x47, 0x0c, 0x86, 0x72, 0x8e, 0xac, 0xd1, 0x29,
+	0x35, 0x7d, 0x1a, 0xd0, 0xb0, 0x55, 0x67, 0x24, 0xc0, 0x40, 0x4c, 0x9d, 0x93, 0xb7, 0x60, 0x55,
+	0x12, 0xb0, 0xc9, 0xd2, 0x6a, 0x30, 0x9a, 0x46, 0x44, 0xc3, 0x80, 0xe4, 0x06, 0xd4, 0xa8, 0x33,
+	0x36, 0xdd, 0x89, 0x39, 0xb6, 0x42, 0xab, 0xb5, 0xca, 0x68, 0xaa, 0xd4, 0x19, 0xf7, 0x27, 0x7b,
+	0x56, 0x68, 0x91, 0x0d, 0x58, 0xa6, 0xbe, 0xef, 0xfa, 0xad, 0x26, 0xc3, 0xf0, 0x0f, 0xf2, 0x26,
+	0x08, 0x69, 0xcc, 0x5f, 0xcf, 0xa9, 0xff, 0xa2, 0xa5, 0x31, 0x64, 0x8d, 0xc3, 0xbe, 0x44, 0x10,
+	0x1f, 0x8a, 0x80, 0x86, 0x82, 0x62, 0x8d, 0x0b, 0xc8, 0x40, 0x8c, 0x40, 0xff, 0x0a, 0x4a, 0x86,
+	0xf7, 0xb5, 0x4d, 0x7e, 0x04, 0xa5, 0x91, 0xeb, 0x4c, 0xc4, 0x6c, 0x55, 0xb5, 0x8d, 0x98, 0x83,
+	0x06, 0xc3, 0x93, 0xb7, 0x61, 0x39, 0xc0, 0x99, 0xc4, 0x66, 0x49, 0xed, 0xc1, 0x7a, 0x92, 0x90,
+	0x4d, 0x32, 0x83, 0x53, 0xe8, 0x77, 0x60, 0x75, 0x9f, 0x86, 0xc8, 0x3d, 0x5a, 0x13, 0xb1, 0x95,
+	0x54, 0x50, 0xad, 0x24, 0xfd, 0x53, 0x68, 0x4a, 0x4a, 0xd1, 0x23, 0x77, 0x60, 0x25, 0xa0, 0xfe,
+	0x59, 0xae, 0xf5, 0xcb, 0x08, 0x23, 0xb4, 0xfe, 0x4b, 0xb6, 0xcc, 0xd5, 0x6a, 0x5e, 0x4f, 0x53,
+	0xb5, 0xa1, 0x32, 0xb5, 0x27, 0x94, 0x4d, 0xfd, 0x25, 0x3e, 0xf5, 0xa3, 0x6f, 0x7d, 0x0d, 0x9a,
+	0x92, 0xb7, 0x58, 0xec, 0x9d, 0x48, 0x03, 0x7c, 0xeb, 0x1a, 0x63, 0xe3, 0x2e, 0xc1, 0xf8, 0xdd,
+	0x68, 0x1f, 0xb9, 0x14, 0x63, 0x64, 0xa2, 0x92, 0x0b, 0x26, 0xf7, 0xe4, 0x16, 0x73, 0x39, 0x2e,
+	0x9b, 0xb0, 0x9e, 0xa0, 0x17, 0x6c, 0xde, 0x01, 0x8d, 0xcd, 0xdf, 0xcb, 0x31, 0x59, 0x87, 0x35,
+	0x85, 0x5a, 0xb0, 0x78, 0x1f, 0x36, 0xa4, 0x55, 0x73, 0x39, 0x36, 0x5b, 0xb0, 0x99, 0x2a, 0x21,
+	0x58, 0xfd, 0x79, 0x21, 0x6a, 0xeb, 0x2f, 0xe9, 0x33, 0xdf, 0x8a, 0x38, 0x69, 0xb0, 0x34, 0xf7,
+	0xa7, 0x82, 0x0b, 0xfe, 0x64, 0xb3, 0xdd, 0x9d, 0x87, 0x94, 0x6d, 0xf0, 0x78, 0xca, 0x5a, 0x62,
+	0xca, 0x10, 0x41, 0xb8, 0xc5, 0x07, 0x58, 0x39, 0xce, 0x19, 0xb4, 0x27, 0xb8, 0x9d, 0x1e, 0x7d,
+	0x92, 0x0f, 0xe1, 0xaa, 0x43, 0x9f, 0x87, 0xa7, 0xae, 0x67, 0x86, 0xbe, 0x7d, 0x72, 0x42, 0x7d,
+	0x93, 0x1f, 0xe0, 0xc4, 0x51, 0x67, 0x43, 0x60, 0x87, 0x1c, 0xc9, 0xc5, 0x21, 0x0f, 0x60, 0x33,
+	0x5d, 0x6a, 0x4c, 0xa7, 0xd6, 0x0b, 0xa1, 0xf3, 0xd6, 0x93, 0x85, 0xf6, 0x10, 0x85, 0x5d, 0x9e,
+	0x68, 0x8c, 0x68, 0x64, 0x13, 0x1a, 0xfb, 0x34, 0x7c, 0xe2, 0x4f, 0x22, 0x6b, 0xe1, 0x03, 0x58,
+	0x8d, 0x00, 0x62, 0x4d, 0xbc, 0x09, 0xa5, 0x33, 0x7f, 0x12, 0x2d, 0x88, 0x46, 0xbc, 0x20, 0x90,
+	0x88, 0xa1, 0xf4, 0xf7, 0xd9, 0xae, 0x1d, 0x73, 0x21, 0x37, 0x61, 0xe9, 0xcc, 0x8f, 0x96, 0x75,
+	0xaa, 0x08, 0x62, 0xc4, 0x2e, 0xa9, 0x54, 0xa3, 0x7f, 0x10, 0xed, 0x92, 0xaf, 0xc3, 0x46, 0x6e,
+	0x8c, 0x2a, 0xa7, 0xc7, 0xb0, 0xb1, 0x4f, 0xc3, 0x3d, 0x3a, 0xb1, 0x1d, 0x3a, 0x3e, 0xa6, 0xd2,
+	0xbc, 0x79, 0x5b, 0x18, 0x07, 0xdc, 0xb4, 0xd9, 0x8c, 0xd9, 0x09, 0x52, 0x1c, 0x2c, 0x6e, 0x09,
+	0xc8, 0x73, 0x68, 0x31, 0x3e, 0x87, 0xea, 0x1d, 0xd8, 0x4c, 0xb1, 0x95, 0x4a, 0xa3, 0x14, 0xd0,
+	0x30, 0xea, 0xa0, 0x8d, 0x0c, 0x5f, 0xa4, 0x65, 0x14, 0xfa, 0xcf, 0x60, 0xa3, 0x33, 0x1e, 0x67,
+	0x25, 0xfb, 0x11, 0x2c, 0xa1, 0x22, 0xe7, 0xed, 0xcc, 0x67, 0x80, 0x04, 0x38, 0x57, 0x53, 0xe5,
+	0x45, 0x93, 0x8f, 0x61, 0x8b, 0xf7, 0xc3, 0xb7, 0xe6, 0x8d, 0xf3, 0xda, 0x9a, 0x4e, 0x85, 0x39,
+	0x80, 0x3f, 0xd1, 0x52, 0xcf, 0x32, 0x15, 0x15, 0x7e, 0x0e, 0x2d, 0x83, 0x7a, 0x53, 0x6b, 0xf4,
+	0xed, 0x6b, 0xc4, 0x13, 0x48, 0x0e, 0x0f, 0x51, 0xc1, 0x26, 0x73, 0x4e, 0x30, 0xcd, 0x3e, 0xa3,
+	0x8e, 0x34, 0x66, 0xbf, 0x80, 0x8d, 0x24, 0x58, 0x8c, 0xc1, 0x07, 0x00, 0x41, 0x04, 0x8c, 0x46,
+	0x42, 0xd9, 0x25, 0xe2, 0x02, 0x0a, 0x99, 0xfe, 0x88, 0x1d, 0x4f, 0xd3, 0x75, 0x90, 0xfb, 0x50,
+	0x95, 0x44, 0xa2, 0x15, 0xb9, 0xac, 0x62, 0x2a, 0xfd, 0x2a, 0x1b, 0xd8, 0x8c, 0x58, 0xfa, 0xaf,
+	0xa2, 0xc3, 0xea, 0x77, 0x50, 0x49, 0xce, 0x08, 0x5d, 0x8b, 0x86, 0x3d, 0x5b, 0xf3, 0x01, 0x6c,
+	0x89, 0xce, 0xfd, 0x2e, 0xda, 0xd7, 0x96, 0xc3, 0x9d, 0xad, 0x89, 0x80, 0xb6, 0x4f, 0x43, 0x61,
+	0x48, 0x8b, 0x61, 0xea, 0xc0, 0x9a, 0x02, 0x13, 0x63, 0xf4, 0x0e, 0x54, 0x3c, 0x84, 0xd8, 0x34,
+	0x1a, 0x21, 0x4d, 0x39, 0x1a, 0x70, 0x5a, 0x49, 0xa1, 0x3f, 0x07, 0x0d, 0xfd, 0x2b, 0x2a, 0x5b,
+	0x72, 0x07, 0xca, 0x0c, 0xff, 0x42, 0x88, 0x9d, 0x2d, 0x2f, 0xf0, 0xe4, 0x13, 0xb8, 0xe6, 0xd3,
+	0x09, 0xaa, 0xd3, 0xe7, 0x76, 0x10, 0xda, 0xce, 0x89, 0xa9, 0x4c, 0x0f, 0xde, 0x83, 0x5b, 0x8c,
+	0xa0, 0x2b, 0xf0, 0xc7, 0xf1, 0xb4, 0x58, 0x87, 0x35, 0xa5, 0x66, 0xd1, 0xca, 0xdf, 0x14, 0x60,
+	0x5d, 0xf8, 0x46, 0xbe, 0xa5, 0x48, 0xef, 0xc1, 0xba, 0xe7, 0x53, 0x66, 0x3f, 0x64, 0x85, 0x21,
+	0x11, 0x2a, 0x96, 0x23, 0x1a, 0xef, 0xa5, 0x78, 0xbc, 0xaf, 0xc2, 0x46, 0x52, 0x06, 0x21, 0xdc,
+	0x3f, 0x2d, 0xc0, 0x86, 0x18, 0x9f, 0xff, 0x07, 0x1d, 0xb6, 0xa8, 0x65, 0x4b, 0x8b, 0x5a, 0xc6,
+	0x3d, 0x2a, 0x09, 0x71, 0xe5, 0x99, 0xbd, 0x2d, 0xe7, 0x4d, 0x27, 0x08, 0xec, 0x13, 0x47, 0x9d,
+	0xb8, 0x9f, 0x00, 0x58, 0x12, 0x28, 0x5a, 0xd4, 0x4e, 0xb7, 0x48, 0x29, 0xa6, 0x50, 0xeb, 0x5f,
+	0xc1, 0x76, 0x2e, 0x67, 0x31, 0x37, 0xff, 0x22, 0xac, 0x9f, 0x42, 0x5b, 0xce, 0x97, 0xef, 0x56,
+	0xe8, 0xeb, 0xb0, 0x9d, 0xcb, 0x59, 0xf4, 0xd6, 0x0c, 0xae, 0xab, 0xd3, 0xe1, 0x3b, 0xad, 0x3b,
+	0x47, 0xdb, 0xdc, 0x82, 0x1b, 0x8b, 0xaa, 0x13, 0x02, 0xfd, 0x3e, 0xdc, 0x48, 0x8c, 0xeb, 0x77,
+	0xdb, 0x1b, 0x6f, 0xc2, 0xcd, 0x85, 0xdc, 0x13, 0xba, 0xe8, 0x98, 0xd9, 0xe8, 0x91, 0x2e, 0xfa,
+	0x0c, 0xd6, 0x14, 0x98, 0xdc, 0xb3, 0xcb, 0x27, 0x53, 0xf7, 0x99, 0x35, 0xcd, 0x2e, 0x8c, 0x7d,
+	0x06, 0x37, 0x04, 0x5e, 0xff, 0x19, 0x90, 0xe3, 0xd0, 0xf2, 0x93, 0x4c, 0x5f, 0xa3, 0xfc, 0x26,
+	0xac, 0x27, 0xca, 0xc7, 0xae, 0x9a, 0xe3, 0xd0, 0xf5, 0x92, 0xa2, 0x6e, 0x00, 0x51, 0x81, 0x82,
+	0xf4, 0x8f, 0x97, 0x60, 0x15, 0x8f, 0x39, 0x4f, 0xac, 0xa9, 0x3d, 0x66, 0x1e, 0x28, 0xf2, 0x61,
+	0x74, 0x1e, 0xe2, 0xb6, 0xcc, 0x8d, 0xe4, 0x79, 0x28, 0x26, 0xbc, 0xa7, 0x1e, 0x8d, 0xc8, 0x47,
+	0x50, 0xf6, 0xa9, 0x15, 0x48, 0xaf, 0xe3, 0xcd, 0x85, 0xc5, 0x0c, 0x46, 0x66, 0x08, 0x72, 0x72,
+	0x1b, 0x56, 0x66, 0x56, 0x38, 0x3a, 0xa5, 0x63, 0xe1, 0xd3, 0x51, 0x6c, 0x31, 0xc3, 0xb5, 0x8c,
+	0x08, 0x4b, 0xde, 0x87, 0xfa, 0xdc, 0x11, 0x1f, 0xa6, 0x15, 0xb4, 0x4a, 0x79, 0xd4, 0x35, 0x49,
+	0xd2, 0x09, 0xc8, 0xc7, 0xa0, 0xc5, 0x25, 0xa6, 0xd4, 0x39, 0x09, 0x4f, 0x5b, 0xcb, 0x79, 0xa5,
+	0x9a, 0x92, 0xec, 0x80, 0x51, 0xe9, 0x03, 0x58, 0x66, 0xad, 0x23, 0xab, 0x00, 0xc7, 0xc3, 0xce,
+	0xb0, 0x6b, 0x1e, 0xf5, 0x8f, 0xd0, 0x53, 0xb3, 0x0e, 0xcd, 0xe8, 0x7b, 0x68, 0x3e, 0xec, 0x3f,
+	0x3e, 0xda, 0xd3, 0x0a, 0xa4, 0x09, 0x35, 0x0e, 0x7c, 0xd2, 0x39, 0xe8, 0xed, 0x69, 0x45, 0xb2,
+	0x06, 0x0d, 0x0e, 0xe8, 0x1d, 0x71, 0xd0, 0x92, 0xfe, 0x29, 0x94, 0x79, 0xc3, 0x91, 0xda, 0xe8,
+	0x76, 0x8e, 0xfb, 0xc3, 0x88, 0x67, 0x03, 0xaa, 0x0c, 0x70, 0x64, 0x76, 0x8e, 0xb5, 0x02, 0x16,
+	0x16, 0x9f, 0x07, 0xdd, 0xa3, 0x7d, 0xe6, 0x4f, 0xfd, 0x6f, 0x25, 0x28, 0x0d, 0x84, 0x63, 0xdd,
+	0x99, 0xfa, 0x76, 0x74, 0x0b, 0x80, 0xbf, 0xf1, 0x08, 0xea, 0x59, 0x61, 0xe8, 0xf3, 0xd3, 0x41,
+	0xdd, 0x10, 0x5f, 0x6c, 0x91, 0x9d, 0x44, 0x07, 0x40, 0xfc, 0x89, 0xa5, 0x9f, 0xd1, 0x20, 0xba,
+	0xea, 0x60, 0xbf, 0xf1, 0x80, 0x61, 0x07, 0xe6, 0x37, 0x76, 0x78, 0x3a, 0xf6, 0xad, 0x6f, 0x98,
+	0x95, 0x5f, 0x31, 0xc0, 0x0e, 0x7e, 0x21, 0x20, 0xe4, 0x06, 0xc0, 0x99, 0x1c, 0x3c, 0xe6, 0xd8,
+	0x58, 0x36, 0x14, 0x08, 0xe9, 0xc2, 0x5a, 0xfc, 0x65, 0x8e, 0x69, 0x68, 0xd9, 0x53, 0xe6, 0xde,
+	0xa8, 0x3d, 0x68, 0x2d, 0x9a, 0x03, 0x86, 0x16, 0x17, 0xd9, 0x63, 0x25, 0xc8, 0xfb, 0xb0, 0xe1,
+	0xb8, 0xa6, 0x3d, 0xf3, 0x70, 0x8b, 0x0e, 0x63, 0x81, 0x2a, 0x5c, 0xd1, 0x3b, 0x6e, 0x4f, 0xa0,
+	0xa4, 0x60, 0xf1, 0xd1, 0xbb, 0x9a, 0xb8, 0xa0, 0xb8, 0x0e, 0xc0, 0x7d, 0x88, 0xa6, 0x15, 0x38,
+	0xcc, 0x11, 0xd2, 0x30, 0xaa, 0x1c, 0xd2, 0x09, 0x1c, 0xf4, 0x98, 0x0a, 0xb4, 0x3d, 0x66, 0x1e,
+	0x90, 0xaa, 0x51, 0xe1, 0x80, 0xde, 0x58, 0x78, 0x4c, 0x43, 0xea, 0xd3, 0x31, 0x73, 0x7d, 0x54,
+	0x0c, 0xf9, 0x8d, 0x1e, 0x8b, 0x20, 0xb4, 0xa6, 0xdc, 0xdf, 0x51, 0x31, 0xf8, 0x07, 0xb9, 0x03,
+	0x9a, 0x1d, 0x98, 0x13, 0xdf, 0x9d, 0x99, 0xf4, 0x79, 0x48, 0x7d, 0xc7, 0x9a, 0x32, 0x67, 0x47,
+	0xc5, 0x58, 0xb5, 0x83, 0x87, 0xbe, 0x3b, 0xeb, 0x0a, 0x28, 0xf6, 0xb4, 0x23, 0x5c, 0xba, 0xa6,
+	0xed, 0x31, 0xbf, 0x47, 0xd5, 0x80, 0x08, 0xd4, 0xf3, 0xe4, 0xad, 0x89, 0x16, 0xdf, 0x9a, 0x90,
+	0x77, 0x80, 0xd8, 0x81, 0x19, 0x9d, 0xc8, 0x6c, 0x87, 0xf5, 0x1b, 0x73, 0x7a, 0x54, 0x0c, 0xcd,
+	0x0e, 0x8e, 0x38, 0xa2, 0xc7, 0xe1, 0x38, 0x56, 0xf6, 0x98, 0x3a, 0xa1, 0x3d, 0xb1, 0xa9, 0xdf,
+	0x22, 0xac, 0xe9, 0x0a, 0x84, 0xbc, 0x0d, 0xda, 0xd4, 0x1d, 0x59, 0x53, 0x53, 0xa1, 0x5a, 0x67,
+	0x54, 0x4d, 0x06, 0xef, 0x49, 0xb0, 0xfe, 0x4f, 0x0a, 0x50, 0xdb, 0xa3, 0xb8, 0x1b, 0xf3, 0x61,
+	0xc6, 0x59, 0xc6, 0x9c, 0x55, 0xe2, 0x74, 0x2a, 0xbe, 0x62, 0x87, 0x6c, 0xf1, 0x1c, 0x87, 0x2c,
+	0xb9, 0x0d, 0xcd, 0xa9, 0xeb, 0xe0, 0x61, 0x92, 0x17, 0xa3, 0xd1, 0x0e, 0xbe, 0xca, 0xc1, 0x03,
+	0x01, 0x45, 0x09, 0x83, 0x53, 0xd7, 0x0f, 0x55, 0x4a, 0x3e, 0x5d, 0x9b, 0x02, 0x1e, 0x91, 0xea,
+	0xff, 0xa2, 0x00, 0xcb, 0xcc, 0xf1, 0x88, 0x9e, 0x1e, 0xe5, 0xf0, 0x95, 0xe7, 0x57, 0x5e, 0x78,
+	0xf2, 0x5a, 0x78, 0xcd, 0xf5, 0x3b, 0x50, 0x1f, 0xc7, 0xcd, 0x8f, 0xb4, 0x4d, 0xe2, 0x60, 0x27,
+	0xb1, 0x46, 0x82, 0x94, 0xb9, 0xfa, 0xdc, 0x20, 0x34, 0x85, 0x75, 0x24, 0x96, 0x14, 0x82, 0xf8,
+	0xde, 0xa2, 0xef, 0xb0, 0x83, 0xf1, 0x6b, 0x7b, 0x56, 0xf5, 0x8f, 0x60, 0x35, 0x2a, 0x27, 0xb6,
+	0x9a, 0x4b, 0x16, 0x9c, 0xc1, 0x1a, 0xfb, 0x3e, 0x70, 0xdd, 0xaf, 0xe7, 0x1e, 0xef, 0xc1, 0x85,
+	0x23, 0xfa, 0x73, 0x68, 0x4c, 0x19, 0x9d, 0xe9, 0x7a, 0xca, 0x35, 0xd2, 0x76, 0x8a, 0x37, 0xe7,
+	0xd5, 0xf7, 0x78, 0x07, 0x4c, 0x95, 0x2f, 0xfd, 0x1f, 0x17, 0x98, 0xa0, 0xea, 0xa5, 0xe4, 0xf7,
+	0x31, 0x44, 0x1f, 0x41, 0x45, 0x99, 0x23, 0x38, 0x3c, 0xf9, 0x32, 0xf2, 0xf6, 0x1a, 0x92, 0x58,
+	0x9f, 0x02, 0x11, 0xba, 0x88, 0x2a, 0x83, 0x70, 0x59, 0x11, 0x17, 0xdd, 0x2f, 0xc7, 0xfd, 0xb9,
+	0xa4, 0xf6, 0x27, 0x6e, 0xd2, 0x89, 0xda, 0xc4, 0xce, 0xfb, 0x6f, 0xf1, 0x92, 0xfb, 0x61, 0xef,
+	0xa1, 0x2c, 0x9c, 0xe7, 0x47, 0x24, 0xf7, 0x60, 0x7d, 0xe6, 0x99, 0x27, 0xbe, 0x35, 0xa2, 0x93,
+	0xf9, 0x14, 0x7d, 0xae, 0xb8, 0xdf, 0x0b, 0xcb, 0x69, 0x6d, 0xe6, 0xed, 0x0b, 0x8c, 0xc1, 0x11,
+	0xe4, 0xa7, 0xd0, 0xc6, 0x15, 0x35, 0x65, 0x9e, 0xe0, 0x4c, 0x31, 0xbe, 0xe6, 0x5a, 0x92, 0x22,
+	0x5d, 0xfa, 0x43, 0xb8, 0x1a, 0x97, 0x16, 0x85, 0x4c, 0xe6, 0x46, 0xe4, 0x2e, 0xf1, 0x0d, 0x89,
+	0x15, 0x25, 0x86, 0xe8, 0x52, 0xfc, 0xe3, 0x65, 0x28, 0x0d, 0x28, 0xf5, 0x99, 0xf6, 0x44, 0xb1,
+	0xa3, 0x43, 0x58, 0xc3, 0x90, 0xdf, 0xe4, 0x63, 0xa8, 0x5b, 0x9e, 0x37, 0x7d, 0x11, 0xad, 0x0a,
+	0xee, 0x6c, 0x55, 0xd6, 0x53, 0x07, 0xb1, 0xc2, 0x64, 0xaf, 0x59, 0xf1, 0x87, 0xf4, 0xe3, 0x2e,
+	0xa5, 0xfd, 0xb8, 0x58, 0xa7, 0xe2, 0xc7, 0xfd, 0x14, 0x1a, 0xf4, 0xd9, 0x89, 0x67, 0xce, 0xe6,
+	0xd3, 0xd0, 0x3e, 0x75, 0x3d, 0x71, 0x43, 0x7d, 0x35, 0x2e, 0xd0, 0x7d, 0x76, 0xe2, 0x1d, 0x0a,
+	0xac, 0x51, 0xa7, 0xca, 0x17, 0xe9, 0x40, 0x93, 0xfb, 0xd9, 0x7c, 0x3a, 0x99, 0xd2, 0x51, 0xe8,
+	0xfa, 0xad, 0xe5, 0xcc, 0x1e, 0x86, 0x04, 0x46, 0x84, 0x37, 0x56, 0xfd, 0xc4, 0x37, 0xb9, 0x0d,
+	0x25, 0xdb, 0x99, 0xb8, 0xad, 0x72, 0xfa, 0xd4, 0x8b, 0x72, 0x72, 0x5b, 0x89, 0x11, 0xa0, 0x7d,
+	0x87, 0x7d, 0xea, 0x07, 0xad, 0x95, 0xb4, 0x7d, 0x37, 0x64, 0x70, 0x43, 0xe0, 0xf1, 0x34, 0x1d,
+	0xfa, 0x96, 0x13, 0x30, 0x7f, 0x6b, 0x25, 0xcd, 0x77, 0x18, 0xa1, 0x8c, 0x98, 0x0a, 0xfb, 0x99,
+	0x37, 0x84, 0x3b, 0x93, 0x5b, 0xd5, 0x74, 0x3f, 0xb3, 0x56, 0x08, 0x2b, 0xb0, 0xe6, 0xc7, 0x1f,
+	0x64, 0x07, 0xea, 0xd6, 0xc4, 0x36, 0xe5, 0x08, 0x42, 0xda, 0xd1, 0x21, 0x67, 0xab, 0x51, 0xb3,
+	0x26, 0xf6, 0xc3, 0x68, 0x64, 0xf7, 0x40, 0xcb, 0x4c, 0xb4, 0x1a, 0xab, 0xf5, 0x9a, 0x62, 0xb8,
+	0x26, 0x67, 0x9a, 0xd1, 0x3c, 0x49, 0x02, 0xc8, 0x3d, 0xa8, 0x62, 0xed, 0x81, 0x35, 0xb1, 0x83,
+	0x56, 0x9d, 0x55, 0xbd, 0xa6, 0x54, 0x3d, 0xb1, 0x8f, 0xad, 0x89, 0x6d, 0x54, 0x2c, 0xfe, 0x03,
+	0xcf, 0x85, 0x55, 0x6b, 0x3c, 0x36, 0xf9, 0xde, 0xd3, 0x48, 0x4f, 0x0d, 0x11, 0x3d, 0x11, 0x18,
+	0x15, 0x4b, 0xfc, 0xd2, 0xff, 0x5d, 0x01, 0x6a, 0xca, 0x1c, 0x23, 0x1f, 0x41, 0xd5, 0x76, 0xcc,
+	0xc4, 0x09, 0xf6, 0xbc, 0xc3, 0x42, 0xc5, 0x76, 0x44, 0xc1, 0xdf, 0x85, 0x06, 0x7d, 0x8e, 0x7d,
+	0x9d, 0x9c, 0xca, 0xe7, 0x15, 0xae, 0xf3, 0x02, 0x31, 0x03, 0x7b, 0xa6, 0x32, 0x58, 0xba, 0x98,
+	0x01, 0x2f, 0x20, 0xf6, 0x8f, 0xbf, 0x05, 0x35, 0xae, 0xd3, 0x0e, 0xec, 0x99, 0xbd, 0xf0, 0x0e,
+	0x02, 0x2f, 0x53, 0x66, 0xd6, 0xf3, 0x78, 0x1f, 0xe5, 0xea, 0xaa, 0x36, 0xb3, 0x9e, 0xcb, 0xed,
+	0xf6, 0x43, 0xb8, 0x1a, 0x88, 0x0b, 0x73, 0x33, 0x3c, 0xf5, 0x69, 0x70, 0xea, 0x4e, 0xc7, 0xa6,
+	0x37, 0x0a, 0x85, 0xaa, 0xdd, 0x88, 0xb0, 0xc3, 0x08, 0x39, 0x18, 0x85, 0xfa, 0x7f, 0x2d, 0x43,
+	0x25, 0x5a, 0x7c, 0x78, 0xab, 0x64, 0xcd, 0xc3, 0x53, 0xd3, 0xb3, 0x82, 0xe0, 0x1b, 0xd7, 0x1f,
+	0x8b, 0xdd, 0xa4, 0x8e, 0xc0, 0x81, 0x80, 0x91, 0x5b, 0x50, 0x1b, 0xd3, 0x60, 0xe4, 0xdb, 0x9e,
+	0x72, 0xf3, 0xad, 0x82, 0xc8, 0x35, 0xa8, 0x70, 0xd3, 0xc4, 0x0a, 0x22, 0x47, 0x36, 0xfb, 0xee,
+	0x30, 0x9b, 0x40, 0x1a, 0x4e, 0x91, 0xa3, 0xbd, 0xc4, 0x38, 0x34, 0x23, 0x78, 0x87, 0x83, 0xc9,
+	0x16, 0xac, 0x78, 0x94, 0xfa, 0xc8, 0x84, 0xfb, 0xab, 0xcb, 0xf8, 0xd9, 0x09, 0xd0, 0x28, 0x64,
+	0x88, 0x13, 0xdf, 0x9d, 0x7b, 0x6c, 0x89, 0x56, 0x8d, 0x2a, 0x42, 0xf6, 0x11, 0x80, 0x46, 0x21,
+	0x43, 0xb3, 0x0d, 0x80, 0xdf, 0xcd, 0x55, 0x10, 0xc0, 0xae, 0xd1, 0x8f, 0x60, 0x0d, 0x6f, 0x1f,
+	0xcf, 0xa8, 0xe9, 0xf9, 0xf6, 0x99, 0x15, 0xa2, 0x61, 0xc9, 0x56, 0xe3, 0xea, 0x03, 0x3d, 0xab,
+	0x8d, 0xee, 0x19, 0x8c, 0x76, 0xc0, 0x49, 0x3b, 0x81, 0xd1, 0xf4, 0x93, 0x00, 0xb4, 0xe9, 0xf8,
+	0x12, 0x9d, 0x4c, 0x2d, 0xcf, 0x1c, 0x5b, 0x33, 0xcf, 0x76, 0x4e, 0xd8, 0x42, 0xad, 0x18, 0x1a,
+	0xc3, 0x3c, 0x9c, 0x5a, 0xde, 0x1e, 0x87, 0xe3, 0x7d, 0x5b, 0x80, 0x37, 0x69, 0x22, 0x4c, 0x20,
+	0x7c, 0x21, 0x4c, 0xda, 0x06, 0x42, 0x77, 0x23, 0x20, 0x36, 0x50, 0xdc, 0x9a, 0x8e, 0x2c, 0xaf,
+	0x55, 0x63, 0x27, 0x81, 0x2a, 0x87, 0xec, 0x5a, 0xac, 0x81, 0xbc, 0x7b, 0x11, 0x5b, 0x67, 0x58,
+	0xde, 0xdf, 0x88, 0x5c, 0x85, 0xa2, 0x3d, 0x66, 0x8b, 0xa8, 0x6a, 0x14, 0xed, 0x31, 0xf9, 0x04,
+	0x1a, 0xe2, 0xae, 0x72, 0x8a, 0x13, 0x2c, 0x68, 0xad, 0xa6, 0x8d, 0x1f, 0x65, 0xfa, 0x19, 0x75,
+	0x2f, 0xfe, 0x08, 0x70, 0x3a, 0x88, 0x71, 0x14, 0x23, 0xc5, 0xed, 0xdc, 0x3a, 0x1f, 0x4c, 0x31,
+	0x4c, 0xef, 0x02, 0x89, 0x4d, 0x61, 0x27, 0xa4, 0xfe, 0xc4, 0x1a, 0x51, 0x66, 0xf7, 0x56, 0x8d,
+	0x35, 0x69, 0x11, 0x47, 0x08, 0xa2, 0x71, 0x57, 0xfd, 0x1a, 0xbf, 0x16, 0x39, 0xf3, 0xf1, 0xce,
+	0x2e, 0xab, 0x73, 0x08, 0x37, 0x13, 0x4f, 0x5e, 0x6b, 0x47, 0x5c, 0xbf, 0x60, 0x47, 0xbc, 0x05,
+	0x75, 0x6b, 0x3a, 0x75, 0xbf, 0x31, 0x71, 0x85, 0x58, 0x41, 0x6b, 0x83, 0xdb, 0xd4, 0x0c, 0xd6,
+	0xff, 0xc6, 0xe9, 0x04, 0xe4, 0x47, 0xd0, 0xf4, 0xb9, 0xe7, 0xc0, 0x8c, 0xa6, 0xde, 0x26, 0x63,
+	0xda, 0x10, 0xe0, 0x01, 0x9b, 0x81, 0xfa, 0x7d, 0x68, 0xa6, 0x66, 0x06, 0x5e, 0xf6, 0x8b, 0x23,
+	0xa0, 0xb8, 0xe1, 0x2f, 0x90, 0x1a, 0xac, 0x18, 0xdd, 0xc1, 0x41, 0x67, 0xb7, 0xab, 0x15, 0xf5,
+	0x2f, 0xa0, 0xae, 0x6e, 0x59, 0x78, 0xd7, 0xc3, 0x6f, 0x70, 0xa2, 0x88, 0xbc, 0xe8, 0x93, 0x2d,
+	0x75, 0x41, 0x65, 0x86, 0xe1, 0x54, 0x2e, 0x75, 0x01, 0x1b, 0x86, 0x53, 0xfd, 0xef, 0x14, 0x60,
+	0x35, 0xb9, 0x83, 0xe1, 0xea, 0x4f, 0x6d, 0x7a, 0xe6, 0x68, 0x6a, 0x47, 0xce, 0x93, 0x8a, 0xb1,
+	0x91, 0xdc, 0xe1, 0x76, 0x19, 0x8e, 0x7c, 0x0a, 0xed, 0x6c, 0xa9, 0x79, 0x80, 0x26, 0xbb, 0x8c,
+	0xd6, 0xd8, 0x4a, 0x97, 0x64, 0xf8, 0xde, 0x58, 0xff, 0x67, 0x65, 0xa8, 0xca, 0xfd, 0xf0, 0xff,
+	0x82, 0xee, 0xb8, 0x07, 0x95, 0x19, 0x0d, 0x02, 0xeb, 0x44, 0x9c, 0x23, 0x12, 0xbb, 0xc4, 0xa1,
+	0xc0, 0x18, 0x92, 0x26, 0x57, 0xd7, 0x2c, 0x5f, 0xa8, 0x6b, 0xca, 0xe7, 0xe8, 0x9a, 0x95, 0x73,
+	0x75, 0x4d, 0x25, 0xa5, 0x6b, 0xee, 0x40, 0xf9, 0xd7, 0x73, 0x3a, 0xa7, 0x41, 0xab, 0x9a, 0xb6,
+	0x0d, 0xbe, 0x64, 0x70, 0x43, 0xe0, 0xc9, 0xdd, 0x3c, 0xad, 0xc4, 0x55, 0xc3, 0x25, 0x35, 0x4e,
+	0xed, 0xd2, 0x1a, 0xa7, 0x9e, 0xa7, 0x71, 0x58, 0x38, 0x41, 0x80, 0x57, 0x8d, 0xdc, 0x31, 0xcb,
+	0x14, 0x48, 0xc3, 0xa8, 0x0b, 0x20, 0x1f, 0xe1, 0x9f, 0xc0, 0xd5, 0x60, 0xee, 0xe1, 0xde, 0x45,
+	0xc7, 0xa8, 0x7b, 0xac, 0x67, 0xf6, 0xd4, 0x0e, 0x6d, 0xca, 0x75, 0x4a, 0xd5, 0xd8, 0x94, 0xd8,
+	0x5d, 0x05, 0x89, 0x7d, 0x84, 0xa6, 0x1c, 0xe7, 0xcb, 0x35, 0x48, 0xe5, 0xd9, 0x89, 0xc7, 0x79,
+	0xfe, 0x2e, 0xd4, 0xac, 0xf1, 0xcc, 0x8e, 0xaa, 0xd5, 0xd2, 0x6e, 0x2a, 0x39, 0xbf, 0xee, 0x75,
+	0x90, 0x8c, 0xfd, 0x34, 0xc0, 0x92, 0xbf, 0xd1, 0x4e, 0x8d, 0x02, 0x23, 0x98, 0x52, 0x69, 0x18,
+	0xf2, 0x1b, 0x71, 0xd6, 0x68, 0x44, 0xbd, 0x90, 0x8e, 0xc5, 0x01, 0x5a, 0x7e, 0xe3, 0xf1, 0xda,
+	0x8a, 0x83, 0x62, 0xd7, 0x85, 0x2a, 0x90, 0x10, 0xb2, 0x0e, 0xcb, 0xee, 0x3c, 0x34, 0x7f, 0x2d,
+	0xb4, 0x44, 0xc9, 0x9d, 0x87, 0x5f, 0xa2, 0xdb, 0x60, 0x32, 0x75, 0x3d, 0xae, 0x15, 0x1a, 0x06,
+	0xff, 0xd0, 0xef, 0x02, 0xc4, 0xc2, 0x61, 0x28, 0xdd, 0xe3, 0x01, 0x8f, 0x03, 0xda, 0xeb, 0xff,
+	0xe2, 0x48, 0x2b, 0x10, 0x80, 0xf2, 0xe0, 0xe1, 0x53, 0x73, 0x77, 0xa8, 0x15, 0xf5, 0xbf, 0x06,
+	0x95, 0x68, 0xa6, 0x92, 0x77, 0x15, 0xd1, 0xb9, 0xd1, 0xb2, 0x96, 0x99, 0xcf, 0x4a, 0x6b, 0xde,
+	0xc2, 0xeb, 0x43, 0x11, 0x88, 0x93, 0x4b, 0xca, 0xd0, 0xfa, 0x9f, 0x16, 0x60, 0x45, 0x40, 0x88,
+	0x0e, 0xf5, 0xa3, 0xfe, 0xb0, 0xf7, 0xb0, 0xb7, 0xdb, 0x19, 0xf6, 0xfa, 0x47, 0xac, 0x96, 0x92,
+	0x91, 0x80, 0xa1, 0xc5, 0xf1, 0x78, 0xb0, 0xd7, 0x19, 0x76, 0x19, 0xe3, 0x92, 0x21, 0xbe, 0xf0,
+	0xf4, 0xd6, 0x1f, 0x74, 0x8f, 0x44, 0x40, 0x19, 0xfb, 0x4d, 0xde, 0x80, 0xea, 0x17, 0xdd, 0xee,
+	0xa0, 0x73, 0xd0, 0x7b, 0xd2, 0x65, 0x4b, 0xb0, 0x64, 0xc4, 0x00, 0x54, 0x69, 0x46, 0xf7, 0xa1,
+	0xd1, 0x3d, 0x7e, 0xc4, 0x96, 0x59, 0xc9, 0x88, 0x3e, 0xb1, 0xdc, 0x5e, 0xef, 0x78, 0xb7, 0x63,
+	0xec, 0x75, 0xf7, 0xd8, 0x02, 0x2b, 0x19, 0x31, 0x00, 0x7b, 0x75, 0xd8, 0x1f, 0x76, 0x0e, 0xd8,
+	0xf2, 0x2a, 0x19, 0xfc, 0x43, 0xdf, 0x81, 0x32, 0x5f, 0x25, 0x88, 0xb7, 0x1d, 0x6f, 0x1e, 0x0a,
+	0x93, 0x88, 0x7f, 0xa0, 0xdc, 0xee, 0x3c, 0x44, 0xb0, 0x38, 0xba, 0xf1, 0x2f, 0x9d, 0x42, 0x99,
+	0x5b, 0xde, 0xe4, 0x1e, 0x94, 0xf1, 0x30, 0x61, 0x9f, 0xb4, 0x0a, 0xe9, 0xd3, 0x03, 0xa7, 0xd8,
+	0x65, 0x58, 0x43, 0x50, 0x91, 0x1f, 0x27, 0x83, 0x47, 0x36, 0xd3, 0xe4, 0x89, 0xf0, 0x91, 0x3f,
+	0x2d, 0x40, 0x5d, 0xe5, 0x82, 0x4b, 0x68, 0xe4, 0x3a, 0x0e, 0x1d, 0x85, 0xa6, 0x4f, 0x43, 0xff,
+	0x45, 0xd4, 0xd9, 0x02, 0x68, 0x20, 0x0c, 0xd7, 0x02, 0xb3, 0xca, 0x64, 0x24, 0x53, 0xc9, 0xa8,
+	0x20, 0x00, 0x39, 0xe1, 0x4e, 0xfa, 0x35, 0xa5, 0x9e, 0x85, 0x9b, 0x97, 0x99, 0x0a, 0xe8, 0x5b,
+	0x93, 0x98, 0x9e, 0x40, 0x90, 0x3d, 0xb8, 0x31, 0xb3, 0x1d, 0x7b, 0x36, 0x9f, 0x99, 0x72, 0xde,
+	0xa2, 0x81, 0x19, 0x17, 0xe5, 0x23, 0xf4, 0x86, 0xa0, 0xea, 0xa8, 0x44, 0x11, 0x17, 0xfd, 0x4f,
+	0x8a, 0x50, 0x53, 0x9a, 0xf7, 0xff, 0x69, 0x33, 0x98, 0xcb, 0x91, 0x9e, 0xb8, 0xa1, 0x6d, 0xa1,
+	0x72, 0x8a, 0x85, 0xe3, 0x13, 0x91, 0xc4, 0xb8, 0x47, 0x91, 0x98, 0x71, 0xac, 0x19, 0x9f, 0x90,
+	0x79, 0xb1, 0x66, 0x7c, 0x42, 0xca, 0x6f, 0xfd, 0x7f, 0x16, 0xa0, 0x2a, 0x4f, 0x6a, 0x59, 0xf3,
+	0xa8, 0x90, 0x63, 0x1e, 0x5d, 0x07, 0xe0, 0x44, 0x4a, 0x9c, 0x0d, 0x37, 0xdf, 0x06, 0x82, 0xc7,
+	0x2c, 0x9c, 0x9b, 0x63, 0x3b, 0x18, 0xb9, 0x67, 0x18, 0x03, 0xc5, 0x8f, 0xf5, 0xf5, 0x59, 0x38,
+	0xdf, 0x8b, 0x60, 0x68, 0x11, 0xe0, 0xae, 0x8a, 0xfd, 0x39, 0x73, 0xc7, 0x51, 0xcc, 0x47, 0x4d,
+	0xc0, 0x0e, 0xdd, 0x31, 0x3a, 0x8f, 0x56, 0x85, 0xc9, 0x98, 0xdc, 0xe9, 0x1a, 0x1c, 0xda, 0xc9,
+	0x8f, 0xc7, 0x2b, 0x47, 0xb1, 0x6f, 0x51, 0x3c, 0x1e, 0x6e, 0x84, 0xe1, 0xc8, 0x33, 0x67, 0x41,
+	0x20, 0x4c, 0xe7, 0x72, 0x38, 0xf2, 0x0e, 0x83, 0x40, 0xff, 0x0c, 0x6a, 0xca, 0x69, 0x13, 0x7d,
+	0x19, 0xea, 0xd1, 0x34, 0x69, 0x6b, 0xac, 0x29, 0x47, 0x51, 0x6e, 0x68, 0xe8, 0xff, 0xa3, 0x00,
+	0xcd, 0xb4, 0x3d, 0x76, 0xae, 0x09, 0x94, 0xf0, 0x58, 0x08, 0x13, 0xc8, 0x8f, 0x1d, 0x15, 0xd8,
+	0x92, 0x53, 0x3a, 0xf5, 0xa8, 0x6f, 0xba, 0xce, 0x34, 0xea, 0x36, 0xe0, 0xa0, 0xbe, 0x33, 0x65,
+	0x5b, 0xda, 0x98, 0x4e, 0xa8, 0xef, 0x5b, 0x53, 0xd5, 0xed, 0x51, 0x8f, 0x80, 0x8c, 0xcb, 0x7d,
+	0xf4, 0x54, 0x87, 0xf6, 0x44, 0x44, 0xec, 0x9a, 0x91, 0x3c, 0xdc, 0xcf, 0xb7, 0xae, 0xe2, 0xba,
+	0x42, 0xb6, 0x1f, 0xc3, 0x5a, 0x6c, 0x83, 0x46, 0xf4, 0x65, 0xbe, 0xfd, 0x4a, 0x84, 0x20, 0xd6,
+	0x3f, 0x80, 0xad, 0xc3, 0xb4, 0x5f, 0x47, 0xe8, 0x8b, 0x85, 0xad, 0xd7, 0xff, 0x4d, 0x01, 0xae,
+	0x66, 0x4a, 0xf1, 0xd5, 0xb9, 0xb8, 0xcb, 0xd4, 0x7d, 0x90, 0x7b, 0x94, 0xe4, 0x77, 0x6a, 0xaf,
+	0x13, 0x5d, 0x15, 0x43, 0xc8, 0xbb, 0xb0, 0x2e, 0xe2, 0xfb, 0x7c, 0xfb, 0x99, 0x29, 0xd9, 0x94,
+	0xa2, 0x97, 0x22, 0xe3, 0xfe, 0x84, 0x79, 0xbb, 0xe4, 0x46, 0xd4, 0x54, 0xc8, 0xd9, 0x9e, 0xc4,
+	0xfb, 0xab, 0x1e, 0x91, 0x1e, 0xe3, 0x90, 0xff, 0x51, 0x01, 0xd6, 0x32, 0xcd, 0x20, 0xbf, 0x93,
+	0x52, 0xca, 0x6f, 0x2a, 0xfb, 0x58, 0x7e, 0x4f, 0x49, 0xfd, 0xbc, 0x93, 0xd4, 0xcf, 0xb7, 0xce,
+	0x29, 0x99, 0x50, 0xd5, 0x1d, 0x68, 0x08, 0x9f, 0x83, 0xe8, 0xfa, 0x45, 0x87, 0x6c, 0xa5, 0x77,
+	0x8b, 0xc9, 0x21, 0xf9, 0x7b, 0x05, 0xa8, 0x0b, 0x1e, 0x32, 0x52, 0xf5, 0xf5, 0x58, 0xe0, 0x84,
+	0x0d, 0xdd, 0x10, 0x15, 0x81, 0x88, 0x79, 0x66, 0x4b, 0x8f, 0x81, 0x98, 0x53, 0x03, 0x97, 0xb0,
+	0x20, 0x50, 0x9d, 0xe5, 0x0d, 0xa3, 0xc1, 0x69, 0x04, 0x50, 0xff, 0x4f, 0x45, 0xd8, 0x16, 0x2b,
+	0x71, 0xca, 0xa3, 0xf7, 0xb9, 0xa7, 0x36, 0xda, 0x87, 0xde, 0x01, 0x62, 0x4d, 0xbf, 0xb1, 0x5e,
+	0x04, 0x68, 0xf3, 0x79, 0x96, 0x4f, 0xcd, 0x59, 0xfc, 0xea, 0x87, 0x63, 0x76, 0x39, 0xe2, 0x90,
+	0x8e, 0xc9, 0x7d, 0xd8, 0xb4, 0x4f, 0x1c, 0xd7, 0x47, 0x8b, 0x93, 0x49, 0x16, 0xdd, 0xad, 0x71,
+	0xe9, 0x09, 0x47, 0x76, 0x02, 0x14, 0x91, 0xdf, 0xa7, 0xe1, 0x99, 0x21, 0xba, 0x1d, 0x91, 0x55,
+	0xb0, 0x05, 0xcf, 0xce, 0x0c, 0x7c, 0x76, 0x6d, 0x45, 0x14, 0xa2, 0x2a, 0x26, 0xb0, 0xdf, 0xc3,
+	0xe3, 0xe8, 0x35, 0x39, 0xf1, 0x4c, 0xdb, 0xb1, 0x46, 0xa1, 0x7d, 0x26, 0x8a, 0x47, 0x97, 0x03,
+	0x5b, 0x92, 0xa0, 0x27, 0xf0, 0xac, 0x34, 0x53, 0x5e, 0xbc, 0x33, 0x4d, 0xcb, 0x3e, 0xf1, 0x22,
+	0x5f, 0x3c, 0x07, 0x75, 0xec, 0x13, 0x8f, 0x7c, 0x02, 0x6d, 0xd1, 0x18, 0xbc, 0x64, 0x31, 0xd9,
+	0x2d, 0x0b, 0x3a, 0x11, 0x69, 0xe8, 0xdb, 0x23, 0xb1, 0x46, 0xaf, 0x72, 0x0a, 0xbc, 0x6c, 0x79,
+	0xe4, 0x7a, 0xbd, 0x13, 0xef, 0x90, 0x61, 0xf5, 0xff, 0x58, 0x84, 0x76, 0x6e, 0xb7, 0xf2, 0xf1,
+	0xfe, 0xcb, 0x5e, 0xfd, 0x56, 0xbd, 0xfa, 0x0f, 0x0a, 0xb0, 0x99, 0xdb, 0xab, 0xe4, 0xb3, 0x94,
+	0x1e, 0x78, 0x2b, 0xe3, 0xd5, 0xcc, 0x9b, 0xdd, 0x52, 0x17, 0x7c, 0x92, 0xd4, 0x05, 0x3f, 0xbc,
+	0xa0, 0x74, 0x42, 0x1f, 0x3c, 0x80, 0xab, 0x8f, 0x03, 0xca, 0x4e, 0xe2, 0xde, 0x94, 0x3d, 0x61,
+	0x0a, 0x2e, 0xd4, 0xc9, 0xf7, 0x61, 0x33, 0x5d, 0xe6, 0x02, 0x8d, 0xac, 0xff, 0x0a, 0x00, 0x4f,
+	0xfc, 0x82, 0xf5, 0x5d, 0x58, 0xe3, 0xce, 0x87, 0x99, 0xe0, 0x81, 0x47, 0x3c, 0x5e, 0xa2, 0xc9,
+	0x10, 0x11, 0xef, 0x0e, 0xf3, 0xbb, 0xcc, 0xac, 0xe7, 0xcc, 0x24, 0x8a, 0xee, 0xe3, 0xd8, 0xd6,
+	0x25, 0x80, 0xdc, 0x07, 0xfa, 0xfb, 0x50, 0xed, 0xca, 0x63, 0xd4, 0x77, 0xce, 0xdd, 0x84, 0x12,
+	0x72, 0x27, 0xef, 0xa4, 0x86, 0x69, 0x23, 0xe9, 0x81, 0x4f, 0x8d, 0xca, 0xe2, 0xf0, 0x6b, 0x29,
+	0x6a, 0x34, 0x08, 0xf7, 0x01, 0x7a, 0x71, 0xef, 0x64, 0x64, 0x2a, 0xe4, 0xc8, 0xf4, 0x3e, 0x54,
+	0x7b, 0xb2, 0xc5, 0x97, 0x2a, 0x61, 0x42, 0xa9, 0x77, 0x41, 0x2b, 0x7a, 0xaf, 0xd3, 0x8a, 0x5e,
+	0xba, 0x15, 0x7f, 0x5e, 0x00, 0x2d, 0x3d, 0x2f, 0xc8, 0xc7, 0xa9, 0xda, 0x94, 0x8d, 0x2a, 0x7f,
+	0xde, 0xc9, 0x9a, 0x7f, 0x92, 0xac, 0xf9, 0xe6, 0xe2, 0x82, 0xaa, 0x14, 0xf8, 0x1c, 0x0e, 0x2f,
+	0x40, 0xb2, 0xcf, 0x10, 0xb1, 0xd7, 0x0d, 0x86, 0x43, 0x1a, 0x1b, 0x69, 0x32, 0x4f, 0xfd, 0x7a,
+	0x8c, 0x06, 0x71, 0xfa, 0xe7, 0x62, 0x67, 0x19, 0x5a, 0xfe, 0x09, 0x0d, 0x0f, 0xe9, 0xec, 0x19,
+	0xf5, 0x83, 0x53, 0x5b, 0x19, 0xa4, 0xa4, 0x45, 0x55, 0xc8, 0x5a, 0x54, 0x7a, 0x07, 0xda, 0xb9,
+	0x3c, 0xe4, 0xa8, 0x5d, 0xcc, 0x42, 0x2a, 0x8d, 0x34, 0x8f, 0x0b, 0x95, 0x46, 0xbe, 0xe0, 0x97,
+	0x55, 0x1a, 0xb9, 0x22, 0x47, 0x23, 0xfd, 0x2b, 0xb8, 0x71, 0xe0, 0x3a, 0x27, 0x07, 0x39, 0x8e,
+	0xc5, 0x8b, 0x94, 0xc7, 0x25, 0xcc, 0x59, 0xfd, 0x3f, 0x17, 0xe0, 0xfa, 0x22, 0xfe, 0xdf, 0xa7,
+	0xe9, 0x77, 0x17, 0xd6, 0x98, 0x03, 0x2b, 0xe7, 0x82, 0xb0, 0x89, 0x08, 0xe5, 0x6e, 0x10, 0xb7,
+	0xa8, 0x0c, 0x2d, 0xc6, 0x01, 0x7a, 0xb6, 0x2f, 0x4d, 0xe6, 0xad, 0x54, 0x21, 0xbf, 0xcb, 0xd1,
+	0xfa, 0x3f, 0x2c, 0x40, 0x6b, 0x51, 0x03, 0xc9, 0xcf, 0x53, 0xe3, 0xaa, 0x3c, 0xc8, 0x3a, 0xbf,
+	0xd3, 0xe5, 0xd0, 0x7e, 0x96, 0x1c, 0xda, 0xdb, 0x17, 0x33, 0x48, 0x8c, 0xee, 0x6f, 0x97, 0x61,
+	0x45, 0xd8, 0x77, 0xe4, 0x8b, 0xfc, 0x6b, 0x5a, 0x2e, 0xd9, 0xf6, 0x39, 0x46, 0x67, 0xde, 0x1d,
+	0xee, 0x7b, 0xb2, 0x65, 0x5c, 0xb0, 0xad, 0xcc, 0x3d, 0x58, 0xaa, 0x21, 0xe9, 0xbb, 0xd5, 0xa5,
+	0x4b, 0xdf, 0xad, 0xfe, 0x02, 0xb6, 0xa2, 0x23, 0x99, 0xd8, 0xfc, 0xc4, 0xa5, 0x7f, 0xe4, 0x2d,
+	0xbd, 0x79, 0xc1, 0x26, 0x69, 0x6c, 0xfa, 0x79, 0x60, 0xf2, 0x08, 0xc8, 0x3c, 0xa0, 0xf1, 0xd6,
+	0xc2, 0xf5, 0xed, 0x72, 0xfa, 0xa2, 0x2b, 0xad, 0xa2, 0x0c, 0x6d, 0x9e, 0x82, 0x64, 0x2f, 0x23,
+	0xca, 0xe9, 0xd6, 0x2d, 0xbe, 0x8c, 0x90, 0xcd, 0x0b, 0xd9, 0x32, 0x35, 0x67, 0x72, 0x9d, 0xb6,
+	0x56, 0x72, 0x9b, 0x97, 0x5e, 0xce, 0xa2, 0x79, 0x69, 0x30, 0xb1, 0x60, 0x1b, 0xcf, 0x6d, 0xe6,
+	0x82, 0x5b, 0x05, 0x7e, 0x55, 0xab, 0x5f, 0x3c, 0xa1, 0xf8, 0xcd, 0x43, 0x1e, 0x26, 0x79, 0xc1,
+	0x59, 0xbd, 0xc4, 0x05, 0x67, 0x57, 0xbe, 0x58, 0x57, 0x4c, 0x13, 0xb1, 0xa8, 0xa3, 0xe5, 0x2f,
+	0x3e, 0xd1, 0x63, 0xce, 0x5c, 0xbc, 0x33, 0xeb, 0xb9, 0xd0, 0x2c, 0x2b, 0xf8, 0x7d, 0x68, 0x3d,
+	0xd7, 0xf7, 0xa0, 0x11, 0xb1, 0x91, 0x4a, 0xe4, 0xf5, 0xb9, 0x7c, 0x0d, 0x95, 0x88, 0x0b, 0x79,
+	0x3f, 0xb5, 0x52, 0x5b, 0xd9, 0x66, 0xa4, 0x26, 0xf4, 0xbb, 0xc9, 0x95, 0xb9, 0x95, 0x2d, 0x90,
+	0x58, 0x89, 0x73, 0x28, 0x8b, 0x98, 0x96, 0x6d, 0xa8, 0xda, 0x9e, 0x99, 0x08, 0x6b, 0xa9, 0xd8,
+	0x51, 0xc0, 0xcb, 0x8f, 0xa0, 0x39, 0xb3, 0x82, 0xaf, 0x85, 0x5d, 0x6d, 0xce, 0x6c, 0x47, 0x48,
+	0xdd, 0x40, 0x30, 0xb7, 0xa9, 0x0f, 0x6d, 0x27, 0x43, 0x67, 0x3d, 0x6f, 0x2d, 0x65, 0xe8, 0xac,
+	0xe7, 0xfa, 0x6f, 0x0b, 0x00, 0xf1, 0xeb, 0x82, 0xbf, 0xe0, 0x13, 0x10, 0x84, 0x4d, 0xed, 0x20,
+	0x64, 0x41, 0x90, 0x55, 0x83, 0xfd, 0x66, 0x51, 0xed, 0xc9, 0x08, 0x17, 0x2d, 0x3d, 0xed, 0x95,
+	0xb0, 0x96, 0x7d, 0xa8, 0x1c, 0x62, 0x14, 0x23, 0x0a, 0x73, 0x3b, 0x21, 0x8c, 0x62, 0x8e, 0x30,
+	0x8a, 0x0b, 0x5e, 0xa3, 0x3c, 0x81, 0x7a, 0xe2, 0x9c, 0x71, 0x2f, 0xc1, 0x4c, 0x59, 0xbe, 0x2a,
+	0x95, 0xc2, 0xf3, 0x2a, 0x94, 0x95, 0xb3, 0x4b, 0xc3, 0x10, 0x5f, 0xfa, 0xbf, 0x5f, 0x06, 0xd8,
+	0x75, 0x9d, 0xb1, 0xcd, 0x75, 0xc4, 0x7d, 0x10, 0xef, 0x1f, 0xcd, 0xf8, 0x49, 0x07, 0x49, 0x49,
+	0x7a, 0x4c, 0x43, 0xa3, 0xca, 0xa9, 0xb0, 0x59, 0x3f, 0x81, 0xba, 0xbc, 0x9e, 0xc1, 0x42, 0xc5,
+	0x85, 0x85, 0x64, 0xac, 0x1d, 0x16, 0xfb, 0x29, 0xac, 0xa6, 0x0e, 0x55, 0x4b, 0x69, 0xef, 0xae,
+	0xda, 0x14, 0xa3, 0x6e, 0xa9, 0xcd, 0x7f, 0x00, 0xb5, 0xa8, 0x34, 0xd6, 0x59, 0x5a, 0x2c, 0x28,
+	0x2f, 0x86, 0x35, 0x7e, 0x24, 0x1f, 0x7b, 0x87, 0x2f, 0x58, 0xa9, 0xe5, 0x85, 0xa5, 0xea, 0x92,
+	0x10, 0x0b, 0xfe, 0x0c, 0xd6, 0xf0, 0xc4, 0x94, 0x2c, 0x5c, 0x5e, 0x58, 0xb8, 0x49, 0x9f, 0x87,
+	0xbb, 0x6a, 0x79, 0xf4, 0xd6, 0x79, 0x5f, 0xdb, 0xa8, 0x8a, 0xe6, 0xd3, 0x90, 0xa9, 0xb9, 0x65,
+	0x03, 0x7c, 0xfe, 0xf8, 0x6c, 0x3e, 0x0d, 0xc9, 0x67, 0x00, 0xf1, 0x8b, 0xb2, 0x56, 0x25, 0x7d,
+	0x79, 0x12, 0x8f, 0x8f, 0xd0, 0x88, 0x38, 0xac, 0x55, 0xf9, 0xe0, 0x8c, 0x7c, 0x0e, 0xeb, 0x53,
+	0xd4, 0x86, 0x29, 0x09, 0xab, 0x0b, 0x25, 0x5c, 0x63, 0xe4, 0x09, 0x19, 0x6f, 0x83, 0x16, 0x1f,
+	0x0b, 0x1d, 0x93, 0x4d, 0x7b, 0x60, 0xd3, 0xbe, 0xe1, 0x88, 0xd3, 0xa0, 0x73, 0x80, 0xf3, 0xff,
+	0x7d, 0xa8, 0x45, 0x41, 0x21, 0xa6, 0xed, 0xb0, 0x5b, 0xed, 0x55, 0x75, 0x09, 0x88, 0x70, 0x94,
+	0xaa, 0x88, 0x0a, 0xe9, 0x39, 0xfa, 0x29, 0x54, 0xa5, 0xd8, 0x18, 0xac, 0x6b, 0xf4, 0x1f, 0x0f,
+	0xbb, 0xe6, 0xf0, 0xab, 0x81, 0x8c, 0xe0, 0xdd, 0x82, 0x75, 0x05, 0xd8, 0x3b, 0x1a, 0x76, 0x8d,
+	0xa3, 0x0e, 0x5e, 0xbd, 0x26, 0x11, 0xdd, 0xa7, 0x02, 0x51, 0x24, 0x1b, 0xa0, 0x29, 0x08, 0xf1,
+	0x28, 0x5b, 0x9f, 0x40, 0x53, 0x36, 0xaa, 0xc3, 0x33, 0x22, 0xdc, 0x4f, 0xac, 0x93, 0xeb, 0x6a,
+	0xa7, 0x26, 0x08, 0x95, 0xa5, 0x72, 0x0b, 0x6a, 0x51, 0x47, 0xda, 0xf2, 0x7d, 0x9f, 0x0a, 0xd2,
+	0x8f, 0xa0, 0x7a, 0x48, 0xc7, 0xa2, 0x86, 0x1f, 0x27, 0x6a, 0x50, 0xf4, 0xa2, 0x24, 0x51, 0x78,
+	0x6f, 0xc0, 0xf2, 0x99, 0x35, 0x9d, 0x47, 0xcf, 0x9f, 0xf9, 0x87, 0x6e, 0x42, 0xb3, 0x13, 0x0c,
+	0x7c, 0xea, 0x51, 0x27, 0xe2, 0x8a, 0x91, 0xc2, 0x81, 0x23, 0xec, 0x6a, 0xfc, 0x89, 0x2b, 0x18,
+	0x29, 0x2c, 0x79, 0x51, 0xc2, 0xbf, 0x88, 0x0e, 0x0d, 0xdc, 0xd6, 0xa7, 0x74, 0x12, 0x9a, 0x33,
+	0x37, 0x88, 0x22, 0xca, 0x6a, 0xf3, 0x80, 0x1e, 0xd0, 0x49, 0x78, 0xe8, 0xb2, 0x98, 0xf8, 0x86,
+	0x08, 0x4b, 0x15, 0xec, 0xcf, 0x7d, 0x4a, 0x1a, 0xd0, 0xe9, 0x44, 0x58, 0xa0, 0xec, 0xb7, 0x7e,
+	0x1b, 0x9a, 0x07, 0xcc, 0xd5, 0xed, 0xd3, 0x89, 0x60, 0x20, 0x1b, 0x22, 0x2e, 0x73, 0x78, 0x43,
+	0xfe, 0xc3, 0x12, 0xac, 0x70, 0x82, 0x20, 0x8e, 0x7a, 0xb2, 0x18, 0x20, 0xab, 0x83, 0xd9, 0xa4,
+	0xe0, 0xd4, 0x22, 0xea, 0x49, 0xf0, 0xfe, 0x08, 0xaa, 0xf1, 0x3d, 0x67, 0x31, 0x1d, 0xb6, 0x94,
+	0x1a, 0x38, 0x23, 0xa6, 0x25, 0x6f, 0xc1, 0xd2, 0x4c, 0x98, 0xc7, 0x89, 0xf3, 0x9e, 0x1c, 0x09,
+	0x03, 0xf1, 0xe4, 0x63, 0x7c, 0x94, 0x60, 0x7a, 0xbc, 0xbf, 0x5b, 0xa5, 0x74, 0x05, 0xa9, 0xa1,
+	0x60, 0x2a, 0x84, 0x03, 0xc8, 0xcf, 0xa0, 0x91, 0xd0, 0x04, 0xad, 0xe5, 0x74, 0xe1, 0xb4, 0x74,
+	0x75, 0x55, 0x19, 0x90, 0xfb, 0xb0, 0x22, 0xe2, 0x86, 0x85, 0xfe, 0x50, 0xa6, 0x4b, 0x62, 0x80,
+	0x8c, 0x88, 0x0e, 0x85, 0x15, 0x17, 0x0f, 0x3e, 0x9d, 0xb4, 0x56, 0xd2, 0xf5, 0xa5, 0xc6, 0x25,
+	0xba, 0x93, 0xf0, 0xe9, 0x84, 0x7c, 0x0e, 0xcd, 0x94, 0x5a, 0x68, 0x55, 0xd2, 0xc5, 0xd3, 0xe2,
+	0xae, 0x26, 0x35, 0x03, 0x3e, 0x83, 0xaa, 0xca, 0x87, 0x3c, 0x72, 0x63, 0x2a, 0x28, 0x7b, 0xe4,
+	0x87, 0x00, 0x23, 0xa9, 0x9f, 0x5a, 0xc5, 0xf4, 0xb9, 0x3c, 0xd6, 0x5d, 0x86, 0x42, 0x47, 0x7e,
+	0x0c, 0x2b, 0x7c, 0x5a, 0x04, 0xad, 0xa5, 0xf4, 0x3d, 0xa8, 0x98, 0x40, 0x46, 0x44, 0xa1, 0x7f,
+	0x09, 0x65, 0x61, 0x1b, 0xe7, 0x09, 0x90, 0x7c, 0x0a, 0x58, 0xbc, 0xdc, 0x53, 0xc0, 0xff, 0x52,
+	0x00, 0x2d, 0x1d, 0xd1, 0x85, 0x0f, 0x3b, 0x95, 0x95, 0xbc, 0x91, 0x8e, 0xfd, 0x52, 0x96, 0xb1,
+	0x9a, 0x39, 0xa3, 0x78, 0x89, 0xcc, 0x19, 0x79, 0x79, 0x8e, 0xd4, 0xe7, 0x71, 0xa5, 0x8b, 0x9e,
+	0xc7, 0x91, 0xf7, 0x60, 0x65, 0x4c, 0x27, 0x16, 0xee, 0x1f, 0xcb, 0xe7, 0x2d, 0xa4, 0x88, 0x4a,
+	0xff, 0xfb, 0x05, 0x58, 0x32, 0x5c, 0x0b, 0x03, 0x89, 0xac, 0xc8, 0xab, 0x52, 0xb4, 0x02, 0xbc,
+	0xc3, 0xe5, 0x7b, 0xf7, 0x94, 0x46, 0xb6, 0x56, 0x0c, 0x40, 0x25, 0x33, 0xb3, 0x18, 0x4a, 0xc4,
+	0xf5, 0xce, 0xac, 0x08, 0xce, 0x89, 0x44, 0x94, 0x97, 0xf8, 0x92, 0x81, 0xa0, 0xcb, 0xe7, 0x3f,
+	0xe8, 0xd7, 0x6f, 0xf3, 0xf0, 0x6a, 0xd7, 0xba, 0xe8, 0x91, 0x3e, 0x7f, 0x8f, 0xcc, 0x08, 0xe3,
+	0xf7, 0xc8, 0xbe, 0x6b, 0xe5, 0xbc, 0x47, 0x46, 0x22, 0x86, 0xd2, 0x03, 0x58, 0x7a, 0xe2, 0x4f,
+	0x72, 0x67, 0xc7, 0x2a, 0x14, 0x7d, 0x7e, 0x9c, 0xae, 0x1b, 0x45, 0x7f, 0xcc, 0xac, 0x51, 0x1e,
+	0xe8, 0xe7, 0x73, 0xbb, 0xae, 0x6e, 0x54, 0x38, 0xc0, 0x60, 0x99, 0x5b, 0x44, 0x18, 0xa1, 0x1f,
+	0xb2, 0x31, 0xa9, 0x1b, 0x15, 0x0e, 0x30, 0x42, 0x11, 0x91, 0xc5, 0x43, 0xd8, 0x8a, 0xf6, 0x18,
+	0x7d, 0x46, 0x65, 0xfe, 0xf6, 0x27, 0xd3, 0xc7, 0xdb, 0x50, 0x8d, 0x7d, 0xbe, 0x22, 0xcb, 0x8b,
+	0x1f, 0x39, 0x79, 0x6f, 0x42, 0x0d, 0x77, 0x57, 0xea, 0xf0, 0xbb, 0xbb, 0x25, 0x6e, 0x0d, 0x70,
+	0x10, 0xbb, 0xbb, 0xc3, 0x17, 0x01, 0x9c, 0x40, 0xe8, 0x64, 0x31, 0x41, 0xaa, 0x46, 0x93, 0xc3,
+	0x3b, 0x11, 0x38, 0x11, 0xdd, 0xbb, 0x9c, 0x8a, 0xee, 0x7d, 0x27, 0xf7, 0xb8, 0x27, 0x6e, 0xb8,
+	0xd2, 0x47, 0x3a, 0xfd, 0xcf, 0xf0, 0x4a, 0x14, 0xfd, 0x10, 0x3d, 0x0c, 0x87, 0xfd, 0x3e, 0x42,
+	0xc3, 0x6f, 0x43, 0xd3, 0x99, 0xcf, 0x4c, 0x25, 0x2c, 0x5f, 0xdc, 0x08, 0xaf, 0x3a, 0xf3, 0x99,
+	0xfa, 0xac, 0xe1, 0x1a, 0x54, 0x1c, 0xe1, 0x0e, 0x8c, 0x02, 0x10, 0x1c, 0xee, 0x09, 0x44, 0x0f,
+	0x0c, 0xa2, 0x64, 0x34, 0x08, 0xbf, 0xf2, 0xad, 0x39, 0xf3, 0x59, 0x47, 0x80, 0xf4, 0x9f, 0xb2,
+	0xe7, 0x5f, 0x86, 0xfd, 0x0c, 0x1b, 0x12, 0xcd, 0xb6, 0x28, 0x0e, 0x38, 0xf3, 0xfa, 0x55, 0x36,
+	0x99, 0xc7, 0x01, 0xeb, 0x9f, 0x01, 0x51, 0x4b, 0x8b, 0x29, 0x78, 0xe9, 0xe2, 0xd7, 0x60, 0x6b,
+	0x9f, 0x86, 0xec, 0xc9, 0xbd, 0x50, 0xe7, 0x41, 0xf4, 0xd6, 0xeb, 0xb7, 0x05, 0xa8, 0xab, 0x88,
+	0x73, 0xb6, 0xe0, 0x64, 0x34, 0x7b, 0x55, 0xcd, 0x96, 0xf6, 0x37, 0x5c, 0x47, 0x6a, 0x11, 0xfc,
+	0x8d, 0x17, 0xbd, 0x3c, 0x27, 0x0f, 0x9f, 0x23, 0x0d, 0xa3, 0xcc, 0x92, 0xf2, 0x04, 0xe8, 0x31,
+	0xf2, 0xe9, 0x09, 0xce, 0x97, 0xd8, 0xab, 0xa3, 0x40, 0xf4, 0x23, 0x68, 0x65, 0x45, 0x15, 0xed,
+	0x7d, 0x00, 0x15, 0xb1, 0xfd, 0x44, 0xcb, 0x4e, 0xb1, 0xca, 0xd5, 0x22, 0x86, 0xa4, 0xbb, 0xfb,
+	0xbf, 0x8b, 0x50, 0x16, 0x81, 0xf3, 0xcb, 0x50, 0x30, 0xb5, 0x2b, 0x04, 0xa0, 0xd4, 0x1b, 0x9c,
+	0x7d, 0xa8, 0xbd, 0x7a, 0x59, 0x12, 0xbf, 0x77, 0xb4, 0x57, 0x2f, 0x2b, 0xa4, 0x01, 0x2b, 0x08,
+	0x37, 0x0f, 0x77, 0xb5, 0xdf, 0xbc, 0x2c, 0x89, 0xcf, 0x1d, 0xfe, 0x59, 0x21, 0x4d, 0xa8, 0x72,
+	0xec, 0xe0, 0xe0, 0x58, 0xfb, 0x83, 0x97, 0x25, 0x01, 0xd8, 0x89, 0x00, 0x15, 0xb2, 0x0a, 0x15,
+	0x46, 0xf1, 0x64, 0x70, 0xa4, 0xbd, 0x7c, 0x55, 0x12, 0xdf, 0x3b, 0xe2, 0xbb, 0x42, 0xd6, 0xa0,
+	0x16, 0xe1, 0x91, 0xe9, 0xab, 0x57, 0x25, 0x01, 0xda, 0x89, 0x41, 0x15, 0x94, 0xe8, 0x09, 0x72,
+	0xfc, 0xd7, 0x2f, 0xc7, 0xf8, 0xbb, 0x8b, 0xa5, 0xff, 0xec, 0xe5, 0x98, 0x54, 0x61, 0xc9, 0x18,
+	0xee, 0x6a, 0x7f, 0xf0, 0xaa, 0x44, 0x34, 0x00, 0xc6, 0xa8, 0x7b, 0xb4, 0xdb, 0x19, 0x68, 0x7f,
+	0xf7, 0x65, 0x04, 0xd9, 0x91, 0x90, 0x0a, 0xd9, 0x80, 0xd5, 0x87, 0x07, 0xfd, 0x5f, 0x98, 0xc7,
+	0x83, 0xee, 0xae, 0xc9, 0x9a, 0xfb, 0x87, 0xaf, 0x4a, 0x19, 0xe8, 0x8e, 0xf6, 0x87, 0xaf, 0x2a,
+	0xa4, 0x05, 0x24, 0x49, 0xcb, 0x44, 0xfe, 0xa3, 0x57, 0xa5, 0x0c, 0x66, 0x47, 0x60, 0x2a, 0xe4,
+	0x2a, 0x68, 0x31, 0xe6, 0xe0, 0x81, 0x80, 0x8f, 0xc9, 0x2a, 0x94, 0xfb, 0x83, 0xce, 0x97, 0x8f,
+	0xbb, 0xda, 0x7f, 0x7f, 0xf5, 0xcf, 0x5f, 0x96, 0xee, 0xee, 0x42, 0x25, 0x5a, 0x9b, 0x18, 0xa8,
+	0xb4, 0x7f, 0xd0, 0xff, 0xbc, 0x73, 0xa0, 0x5d, 0x89, 0x53, 0x16, 0xb1, 0xf8, 0xa5, 0xce, 0xde,
+	0xef, 0x99, 0xbd, 0x23, 0xad, 0x88, 0x31, 0x8d, 0xf8, 0x1b, 0x93, 0x84, 0xb1, 0x5c, 0x46, 0x4f,
+	0x8c, 0x87, 0x5a, 0xe9, 0xee, 0x41, 0xe2, 0x4d, 0x0a, 0xf7, 0x1b, 0x11, 0x0d, 0xea, 0x07, 0xfd,
+	0xfe, 0x17, 0x8f, 0x07, 0x66, 0xf7, 0x69, 0x67, 0x77, 0xa8, 0x5d, 0xc1, 0xd7, 0x70, 0x02, 0x72,
+	0xd0, 0x3f, 0xda, 0xef, 0x1a, 0x5a, 0x81, 0x10, 0x58, 0x15, 0xa0, 0xe3, 0x47, 0x7d, 0x63, 0xd8,
+	0x35, 0xb4, 0xe2, 0xdd, 0xdf, 0xb0, 0xe7, 0x4a, 0xf2, 0x9c, 0xcd, 0xe2, 0xa7, 0x8c, 0xee, 0xc3,
+	0xde, 0x53, 0xed, 0x0a, 0xa9, 0x43, 0xe5, 0xa8, 0xdb, 0xdb, 0x7f, 0xf4, 0x79, 0x1f, 0x4b, 0xaf,
+	0xc0, 0xd2, 0xb0, 0xb3, 0x2f, 0xc4, 0x3a, 0x36, 0x07, 0x9d, 0xe1, 0x23, 0x6d, 0x09, 0xdf, 0xe0,
+	0xed, 0xf6, 0x0f, 0x0f, 0x1f, 0x1f, 0xf5, 0x86, 0x5f, 0x69, 0x38, 0x86, 0x8d, 0xee, 0xd3, 0xa1,
+	0x19, 0x83, 0x96, 0xf1, 0x30, 0x71, 0xd0, 0x31, 0xf6, 0xbb, 0x0a, 0xb0, 0xcc, 0x59, 0x3f, 0x1d,
+	0x9a, 0x8f, 0xfa, 0x03, 0x6d, 0xe5, 0xee, 0xdb, 0x50, 0x95, 0xc7, 0x6b, 0xac, 0xa7, 0x73, 0xf4,
+	0x95, 0x1a, 0xdb, 0x09, 0x50, 0xee, 0x1d, 0x3d, 0xe9, 0x1a, 0x43, 0xad, 0x78, 0xf7, 0x2e, 0x68,
+	0xe9, 0xc3, 0x33, 0x46, 0x81, 0x75, 0xbf, 0xd4, 0xae, 0xe0, 0xdf, 0xfd, 0xae, 0x56, 0xc0, 0xbf,
+	0x07, 0x5d, 0xad, 0x78, 0xf7, 0x3d, 0xa8, 0x29, 0xbb, 0xae, 0x12, 0x35, 0x8a, 0x9d, 0xbc, 0xbb,
+	0xdb, 0x1d, 0x0c, 0x39, 0x73, 0xa3, 0xfb, 0x7b, 0x5d, 0x0c, 0x18, 0xbb, 0xfb, 0x18, 0xd6, 0x73,
+	0x4e, 0x1c, 0xd8, 0x28, 0x29, 0xbb, 0xd9, 0xd9, 0xdb, 0xd3, 0xae, 0xe0, 0xd1, 0x26, 0x06, 0x19,
+	0xdd, 0xc3, 0xfe, 0x13, 0xac, 0x78, 0x13, 0xd6, 0x54, 0xa8, 0x08, 0x47, 0xbd, 0xfb, 0x2e, 0x34,
+	0x12, 0xc7, 0x0c, 0xec, 0xc1, 0xc3, 0xee, 0x9e, 0x79, 0xd8, 0x47, 0x56, 0x4d, 0xa8, 0xe1, 0x47,
+	0x44, 0x5e, 0xb8, 0xfb, 0x0e, 0x40, 0x6c, 0xcb, 0xc8, 0x6c, 0x71, 0xd8, 0x09, 0x87, 0x83, 0xbe,
+	0x21, 0x64, 0xee, 0x3e, 0x65, 0xbf, 0x8b, 0x0f, 0xfe, 0xd7, 0x2d, 0xa8, 0xec, 0xe3, 0xb2, 0xef,
+	0x78, 0x36, 0x39, 0x80, 0x9a, 0xf2, 0xae, 0x95, 0xbc, 0x91, 0xb0, 0xb0, 0x52, 0xcf, 0x65, 0xdb,
+	0xd7, 0x17, 0x60, 0xc5, 0x3b, 0x9b, 0x2b, 0xa4, 0x07, 0x10, 0xbf, 0x7c, 0x25, 0xdb, 0x2a, 0x79,
+	0xea, 0x91, 0x6c, 0xfb, 0x8d, 0x7c, 0xa4, 0x64, 0xf5, 0x10, 0xaa, 0xf2, 0xbd, 0x2f, 0x51, 0x1c,
+	0x21, 0xe9, 0x87, 0xc1, 0xed, 0xed, 0x5c, 0x9c, 0xe4, 0x73, 0x00, 0x35, 0x25, 0x79, 0xa1, 0xda,
+	0xc0, 0x6c, 0x36, 0xc4, 0xf6, 0xf5, 0x05, 0x58, 0xc9, 0xed, 0x31, 0xac, 0x26, 0xd3, 0x16, 0x92,
+	0x9b, 0xaa, 0xf7, 0x29, 0x27, 0x1b, 0x62, 0xfb, 0xd6, 0x62, 0x02, 0x55, 0x48, 0x25, 0x87, 0xa7,
+	0x2a, 0x64, 0x36, 0x6d, 0x68, 0xfb, 0xfa, 0x02, 0xac, 0xe4, 0x66, 0x40, 0x23, 0x91, 0x0f, 0x90,
+	0xdc, 0x48, 0xec, 0xf4, 0x59, 0x8e, 0x37, 0x17, 0xe2, 0x25, 0xcf, 0xbf, 0x0a, 0x6b, 0x99, 0x3c,
+	0x83, 0x44, 0xbf, 0x38, 0xdf, 0x61, 0xfb, 0x07, 0xe7, 0xd2, 0x48, 0xfe, 0x7f, 0x05, 0xb4, 0x74,
+	0x3e, 0x41, 0xa2, 0x04, 0x9f, 0x2c, 0x48, 0x63, 0xd8, 0xd6, 0xcf, 0x23, 0x51, 0x47, 0x2d, 0x99,
+	0x5d, 0x50, 0x1d, 0xb5, 0xdc, 0x54, 0x85, 0xed, 0x5b, 0x8b, 0x09, 0x24, 0xdb, 0xa7, 0xd0, 0x4c,
+	0x25, 0x10, 0x24, 0xea, 0x60, 0xe7, 0x66, 0x2d, 0x6c, 0xbf, 0x79, 0x0e, 0x85, 0xe4, 0xfc, 0x19,
+	0x94, 0xb9, 0xbd, 0x42, 0xb6, 0x12, 0x83, 0x1d, 0xbf, 0xa1, 0x6b, 0xb7, 0xb2, 0x08, 0x59, 0xfc,
+	0x23, 0x58, 0x11, 0x8f, 0x02, 0x49, 0x92, 0x4c, 0x79, 0x27, 0xd8, 0x4e, 0xbd, 0x1f, 0xd5, 0xaf,
+	0xbc, 0x5f, 0xc0, 0x79, 0xa8, 0x3c, 0xa0, 0x53, 0xe7, 0x61, 0xf6, 0x15, 0x5f, 0xfb, 0xfa, 0x02,
+	0xac, 0x14, 0xe3, 0xe7, 0xb0, 0x22, 0x9c, 0xc8, 0x24, 0xeb, 0x88, 0x8e, 0xb8, 0x5c, 0xcb, 0xc1,
+	0xa8, 0xfa, 0x24, 0xce, 0x64, 0xaa, 0xea, 0x93, 0x4c, 0x2e, 0xd6, 0xf6, 0x1b, 0xf9, 0x48, 0xc9,
+	0x6a, 0x0f, 0x20, 0xce, 0xb3, 0xa7, 0xb2, 0xca, 0x64, 0xdf, 0x6b, 0xe7, 0x3f, 0x3d, 0x65, 0x1d,
+	0xf4, 0xa9, 0xcc, 0x2d, 0x18, 0x87, 0xd6, 0xab, 0xef, 0xb6, 0xa2, 0x54, 0xba, 0xed, 0x54, 0xd6,
+	0x53, 0x56, 0xf8, 0x21, 0x54, 0x65, 0xb2, 0x47, 0x55, 0xa5, 0xa5, 0x53, 0x4d, 0xb6, 0xb7, 0x73,
+	0x71, 0x89, 0x5e, 0x91, 0xa9, 0x20, 0x13, 0xbd, 0x92, 0xce, 0x1a, 0xd9, 0x7e, 0x23, 0x1f, 0x29,
+	0x59, 0x3d, 0x82, 0xaa, 0x4c, 0xdf, 0xa8, 0x8a, 0x94, 0x4e, 0x2a, 0xd9, 0xde, 0xce, 0xc5, 0x45,
+	0x7c, 0xee, 0x14, 0x70, 0xca, 0xf2, 0x84, 0x89, 0x64, 0x6b, 0x41, 0xbe, 0xc6, 0x76, 0x2b, 0x8b,
+	0x50, 0xd5, 0xbd, 0xcc, 0x8d, 0xa8, 0x0a, 0x92, 0x4e, 0xb9, 0xd8, 0xde, 0xce, 0xc5, 0xa9, 0x73,
+	0x4e, 0x64, 0x83, 0x4b, 0x4d, 0x7d, 0x25, 0x8d, 0x58, 0xfb, 0x5a, 0x0e, 0x26, 0x35, 0x6b, 0xd3,
+	0x1c, 0x92, 0x59, 0xe2, 0xda, 0xd7, 0x72, 0x30, 0xd9, 0x59, 0xcb, 0x98, 0x64, 0x04, 0x56, 0xf9,
+	0xbc, 0x91, 0x8f, 0x54, 0x59, 0xc5, 0x89, 0xda, 0x48, 0x66, 0x5e, 0x2c, 0x60, 0x95, 0x93, 0xdb,
+	0x8d, 0xed, 0x31, 0x4a, 0xb6, 0x36, 0x92, 0x9d, 0x19, 0x2a, 0xb3, 0xeb, 0x0b, 0xb0, 0xea, 0x78,
+	0xc9, 0x5c, 0x6b, 0xea, 0x78, 0xa5, 0x53, 0xb6, 0xb5, 0xb7, 0x73, 0x71, 0xea, 0x5e, 0x95, 0xc8,
+	0xdb, 0xa6, 0xee, 0x55, 0x79, 0x29, 0xe0, 0xda, 0x37, 0x17, 0xe2, 0xd3, 0xda, 0xd3, 0xb5, 0xd2,
+	0xda, 0xd3, 0xb5, 0x72, 0xa6, 0x62, 0xd2, 0x2f, 0xc1, 0x3b, 0x4a, 0xc9, 0xb1, 0x46, 0x32, 0xfd,
+	0xaa, 0xe6, 0x91, 0x6b, 0x5f, 0x5f, 0x80, 0x55, 0x37, 0xb6, 0xf4, 0x81, 0x4c, 0xdd, 0xd8, 0x16,
+	0x9c, 0x2b, 0xdb, 0xfa, 0x79, 0x24, 0x6a, 0x4b, 0x79, 0xfe, 0xb5, 0xd4, 0xa2, 0x8b, 0x93, 0xaf,
+	0xb5, 0x5b, 0x59, 0x44, 0x76, 0xd1, 0x21, 0x87, 0xcc, 0xa2, 0x53, 0x98, 0x6c, 0xe7, 0xe2, 0x52,
+	0x1d, 0x9e, 0x12, 0x23, 0x91, 0x90, 0xae, 0xdd, 0xca, 0x22, 0xd4, 0x39, 0x90, 0x48, 0xc9, 0xa6,
+	0xce, 0x81, 0xbc, 0x14, 0x70, 0xed, 0x9b, 0x0b, 0xf1, 0x2a, 0xcf, 0x44, 0x8e, 0x35, 0x95, 0x67,
+	0x5e, 0xf2, 0xb6, 0xf6, 0xcd, 0x85, 0x78, 0x75, 0x28, 0xd3, 0x99, 0xd4, 0xd4, 0xa1, 0x5c, 0x90,
+	0xba, 0xad, 0xad, 0x9f, 0x47, 0xa2, 0x1a, 0x58, 0x99, 0x34, 0x6a, 0xaa, 0x81, 0xb5, 0x28, 0x4f,
+	0x5b, 0xfb, 0x07, 0xe7, 0xd2, 0x48, 0xfe, 0x7d, 0xa8, 0xab, 0x29, 0xd7, 0x48, 0xd2, 0x8a, 0x4c,
+	0x67, 0x17, 0x6b, 0xdf, 0x58, 0x84, 0x56, 0x19, 0xaa, 0xc9, 0xd2, 0x48, 0xd2, 0x76, 0x3e, 0x8f,
+	0x61, 0x6e, 0x8e, 0x35, 0x6e, 0x4e, 0x25, 0xd3, 0xa0, 0x91, 0x8c, 0xed, 0x9c, 0x61, 0xfb, 0xe6,
+	0x39, 0x14, 0xea, 0xc0, 0xa5, 0xf3, 0x9e, 0xa9, 0x03, 0xb7, 0x20, 0xc3, 0x5a, 0x5b, 0x3f, 0x8f,
+	0x24, 0x75, 0x50, 0x11, 0x9e, 0xec, 0xe4, 0x41, 0x25, 0x91, 0xc5, 0xab, 0xbd, 0x9d, 0x8b, 0x53,
+	0xf9, 0xc8, 0x2c, 0x51, 0x2a, 0x9f, 0x74, 0xfa, 0xb4, 0xf6, 0x76, 0x2e, 0x4e, 0x1d, 0x17, 0x35,
+	0xbf, 0x93, 0x3a, 0x2e, 0x39, 0x99, 0xcf, 0xda, 0x37, 0x16, 0xa1, 0x93, 0xc7, 0x09, 0x25, 0x61,
+	0x53, 0xf2, 0x38, 0x91, 0x4d, 0x57, 0xd6, 0xbe, 0xb9, 0x10, 0x2f, 0x79, 0x8e, 0x59, 0x5e, 0xc0,
+	0x8c, 0xab, 0xfe, 0x87, 0x39, 0x5d, 0x94, 0xc9, 0x3e, 0xd5, 0x7e, 0xeb, 0x02, 0x2a, 0xb5, 0x96,
+	0x9c, 0xc4, 0x5b, 0x6a, 0x2d, 0x8b, 0x33, 0x7e, 0xb5, 0xdf, 0xba, 0x80, 0x4a, 0xd6, 0x32, 0x8b,
+	0xb2, 0x03, 0x66, 0x2a, 0xba, 0x9d, 0xdf, 0xb7, 0xd9, 0xba, 0xee, 0x5c, 0x4c, 0x28, 0xab, 0xf3,
+	0x64, 0x4a, 0xc0, 0x4c, 0x7d, 0x77, 0x16, 0x74, 0x7c, 0xb6, 0xc2, 0xb7, 0x2f, 0x41, 0xa9, 0x1a,
+	0x21, 0xb1, 0xf7, 0x94, 0x6c, 0xa7, 0x0f, 0x1e, 0x8a, 0x47, 0xb6, 0xfd, 0x46, 0x3e, 0x32, 0x62,
+	0xf5, 0xac, 0xcc, 0xfe, 0x33, 0xc6, 0x07, 0xff, 0x67, 0x00, 0x63, 0xf3, 0x92, 0xd2, 0x28, 0x63,
 	0x00, 0x00,
 }
@@ -1019,7 +1019,8 @@ func (s *Server) EnableZebra(ctx context.Context, arg *EnableZebraRequest) (*Ena
 			l = append(l, config.InstallProtocolType(p))
 		}
 	}
-	return &EnableZebraResponse{}, s.bgpServer.StartZebraClient(&config.ZebraConfig{
+	// Not the context of the request, which is cancelled once it returns.
+	return &EnableZebraResponse{}, s.bgpServer.StartZebraClient(context.Background(), &config.ZebraConfig{
 		Url: arg.Url,
 		RedistributeRouteTypeList: l,
 		Version:                   uint8(arg.Version),
@@ -1028,6 +1029,24 @@ func (s *Server) EnableZebra(ctx context.Context, arg *EnableZebraRequest) (*Ena
 	})
 }
 
+func (s *Server) GetZebraNexthops(ctx context.Context, arg *GetZebraNexthopsRequest) (*GetZebraNexthopsResponse, error) {
+	nexthops, err := s.bgpServer.GetZebraNexthops()
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*ZebraNexthop, 0, len(nexthops))
+	for _, n := range nexthops {
+		l = append(l, &ZebraNexthop{
+			Address:    n.Address.String(),
+			Family:     n.Family,
+			Zone:       n.Zone,
+			VrfIds:     n.VrfIds,
+			Registered: n.Registered,
+		})
+	}
+	return &GetZebraNexthopsResponse{Nexthops: l}, nil
+}
+
 func (s *Server) GetVrf(ctx context.Context, arg *GetVrfRequest) (*GetVrfResponse, error) {
 	toApi := func(v *table.Vrf) *Vrf {
 		f := func(rts []bgp.ExtendedCommunityInterface) [][]byte {
@@ -129,6 +129,14 @@ func (cli *Client) EnableZebra(c *config.Zebra) error {
 	return err
 }
 
+func (cli *Client) GetZebraNexthops() ([]*api.ZebraNexthop, error) {
+	rsp, err := cli.cli.GetZebraNexthops(context.Background(), &api.GetZebraNexthopsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Nexthops, nil
+}
+
 func (cli *Client) getNeighbor(name string, afi int, vrf string, enableAdvertised bool) ([]*config.Neighbor, error) {
 	ret, err := cli.cli.GetNeighbor(context.Background(), &api.GetNeighborRequest{EnableAdvertised: enableAdvertised, Address: name})
 	if err != nil {
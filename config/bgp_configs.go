@@ -962,6 +962,509 @@ func (v MrtType) Validate() error {
 	return nil
 }
 
+// typedef for identity gobgp:zebra-aspath-error-action.
+// Action taken when the AS_PATH of a route to be installed to zebra
+// cannot be serialized.
+type ZebraAspathErrorAction string
+
+const (
+	ZEBRA_ASPATH_ERROR_ACTION_IGNORE ZebraAspathErrorAction = "ignore"
+	ZEBRA_ASPATH_ERROR_ACTION_LOG    ZebraAspathErrorAction = "log"
+	ZEBRA_ASPATH_ERROR_ACTION_SKIP   ZebraAspathErrorAction = "skip"
+)
+
+var ZebraAspathErrorActionToIntMap = map[ZebraAspathErrorAction]int{
+	ZEBRA_ASPATH_ERROR_ACTION_IGNORE: 0,
+	ZEBRA_ASPATH_ERROR_ACTION_LOG:    1,
+	ZEBRA_ASPATH_ERROR_ACTION_SKIP:   2,
+}
+
+func (v ZebraAspathErrorAction) ToInt() int {
+	i, ok := ZebraAspathErrorActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraAspathErrorActionMap = map[int]ZebraAspathErrorAction{
+	0: ZEBRA_ASPATH_ERROR_ACTION_IGNORE,
+	1: ZEBRA_ASPATH_ERROR_ACTION_LOG,
+	2: ZEBRA_ASPATH_ERROR_ACTION_SKIP,
+}
+
+func (v ZebraAspathErrorAction) Validate() error {
+	if _, ok := ZebraAspathErrorActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraAspathErrorAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-vrf-resolve-mode.
+// Determines how the VRF of a VPN route passed to zebra is resolved.
+type ZebraVrfResolveMode string
+
+const (
+	ZEBRA_VRF_RESOLVE_MODE_RT_THEN_RD ZebraVrfResolveMode = "rt-then-rd"
+	ZEBRA_VRF_RESOLVE_MODE_RT_ONLY    ZebraVrfResolveMode = "rt-only"
+)
+
+var ZebraVrfResolveModeToIntMap = map[ZebraVrfResolveMode]int{
+	ZEBRA_VRF_RESOLVE_MODE_RT_THEN_RD: 0,
+	ZEBRA_VRF_RESOLVE_MODE_RT_ONLY:    1,
+}
+
+func (v ZebraVrfResolveMode) ToInt() int {
+	i, ok := ZebraVrfResolveModeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraVrfResolveModeMap = map[int]ZebraVrfResolveMode{
+	0: ZEBRA_VRF_RESOLVE_MODE_RT_THEN_RD,
+	1: ZEBRA_VRF_RESOLVE_MODE_RT_ONLY,
+}
+
+func (v ZebraVrfResolveMode) Validate() error {
+	if _, ok := ZebraVrfResolveModeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraVrfResolveMode: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-nil-nexthop-action.
+// Determines how routes without a nexthop are passed to zebra.
+type ZebraNilNexthopAction string
+
+const (
+	ZEBRA_NIL_NEXTHOP_ACTION_INSTALL ZebraNilNexthopAction = "install"
+	ZEBRA_NIL_NEXTHOP_ACTION_SKIP    ZebraNilNexthopAction = "skip"
+)
+
+var ZebraNilNexthopActionToIntMap = map[ZebraNilNexthopAction]int{
+	ZEBRA_NIL_NEXTHOP_ACTION_INSTALL: 0,
+	ZEBRA_NIL_NEXTHOP_ACTION_SKIP:    1,
+}
+
+func (v ZebraNilNexthopAction) ToInt() int {
+	i, ok := ZebraNilNexthopActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraNilNexthopActionMap = map[int]ZebraNilNexthopAction{
+	0: ZEBRA_NIL_NEXTHOP_ACTION_INSTALL,
+	1: ZEBRA_NIL_NEXTHOP_ACTION_SKIP,
+}
+
+func (v ZebraNilNexthopAction) Validate() error {
+	if _, ok := ZebraNilNexthopActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraNilNexthopAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-panic-action.
+// Determines what the zebra client does after recovering from a panic
+// while handling a message.
+type ZebraPanicAction string
+
+const (
+	ZEBRA_PANIC_ACTION_CONTINUE  ZebraPanicAction = "continue"
+	ZEBRA_PANIC_ACTION_RECONNECT ZebraPanicAction = "reconnect"
+)
+
+var ZebraPanicActionToIntMap = map[ZebraPanicAction]int{
+	ZEBRA_PANIC_ACTION_CONTINUE:  0,
+	ZEBRA_PANIC_ACTION_RECONNECT: 1,
+}
+
+func (v ZebraPanicAction) ToInt() int {
+	i, ok := ZebraPanicActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraPanicActionMap = map[int]ZebraPanicAction{
+	0: ZEBRA_PANIC_ACTION_CONTINUE,
+	1: ZEBRA_PANIC_ACTION_RECONNECT,
+}
+
+func (v ZebraPanicAction) Validate() error {
+	if _, ok := ZebraPanicActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraPanicAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-aux-encoding.
+// Determines how the Aux field of the routes passed to zebra is encoded.
+type ZebraAuxEncoding string
+
+const (
+	ZEBRA_AUX_ENCODING_RAW ZebraAuxEncoding = "raw"
+	ZEBRA_AUX_ENCODING_TLV ZebraAuxEncoding = "tlv"
+)
+
+var ZebraAuxEncodingToIntMap = map[ZebraAuxEncoding]int{
+	ZEBRA_AUX_ENCODING_RAW: 0,
+	ZEBRA_AUX_ENCODING_TLV: 1,
+}
+
+func (v ZebraAuxEncoding) ToInt() int {
+	i, ok := ZebraAuxEncodingToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraAuxEncodingMap = map[int]ZebraAuxEncoding{
+	0: ZEBRA_AUX_ENCODING_RAW,
+	1: ZEBRA_AUX_ENCODING_TLV,
+}
+
+func (v ZebraAuxEncoding) Validate() error {
+	if _, ok := ZebraAuxEncodingToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraAuxEncoding: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-label-rewrite-action.
+// Determines how the label stack of labeled routes is rewritten before
+// they are passed to zebra.
+type ZebraLabelRewriteAction string
+
+const (
+	ZEBRA_LABEL_REWRITE_ACTION_NONE ZebraLabelRewriteAction = "none"
+	ZEBRA_LABEL_REWRITE_ACTION_POP  ZebraLabelRewriteAction = "pop"
+	ZEBRA_LABEL_REWRITE_ACTION_SWAP ZebraLabelRewriteAction = "swap"
+)
+
+var ZebraLabelRewriteActionToIntMap = map[ZebraLabelRewriteAction]int{
+	ZEBRA_LABEL_REWRITE_ACTION_NONE: 0,
+	ZEBRA_LABEL_REWRITE_ACTION_POP:  1,
+	ZEBRA_LABEL_REWRITE_ACTION_SWAP: 2,
+}
+
+func (v ZebraLabelRewriteAction) ToInt() int {
+	i, ok := ZebraLabelRewriteActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraLabelRewriteActionMap = map[int]ZebraLabelRewriteAction{
+	0: ZEBRA_LABEL_REWRITE_ACTION_NONE,
+	1: ZEBRA_LABEL_REWRITE_ACTION_POP,
+	2: ZEBRA_LABEL_REWRITE_ACTION_SWAP,
+}
+
+func (v ZebraLabelRewriteAction) Validate() error {
+	if _, ok := ZebraLabelRewriteActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraLabelRewriteAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-nexthop-metric-precedence.
+// Determines which MED wins when both a nexthop update and an import
+// policy set it.
+type ZebraNexthopMetricPrecedence string
+
+const (
+	ZEBRA_NEXTHOP_METRIC_PRECEDENCE_POLICY  ZebraNexthopMetricPrecedence = "policy"
+	ZEBRA_NEXTHOP_METRIC_PRECEDENCE_NEXTHOP ZebraNexthopMetricPrecedence = "nexthop"
+)
+
+var ZebraNexthopMetricPrecedenceToIntMap = map[ZebraNexthopMetricPrecedence]int{
+	ZEBRA_NEXTHOP_METRIC_PRECEDENCE_POLICY:  0,
+	ZEBRA_NEXTHOP_METRIC_PRECEDENCE_NEXTHOP: 1,
+}
+
+func (v ZebraNexthopMetricPrecedence) ToInt() int {
+	i, ok := ZebraNexthopMetricPrecedenceToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraNexthopMetricPrecedenceMap = map[int]ZebraNexthopMetricPrecedence{
+	0: ZEBRA_NEXTHOP_METRIC_PRECEDENCE_POLICY,
+	1: ZEBRA_NEXTHOP_METRIC_PRECEDENCE_NEXTHOP,
+}
+
+func (v ZebraNexthopMetricPrecedence) Validate() error {
+	if _, ok := ZebraNexthopMetricPrecedenceToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraNexthopMetricPrecedence: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-import-nil-nexthop-action.
+// Determines how IPv6 routes redistributed from zebra without a nexthop
+// are imported.
+type ZebraImportNilNexthopAction string
+
+const (
+	ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_SYNTHESIZE ZebraImportNilNexthopAction = "synthesize"
+	ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_WITHDRAW   ZebraImportNilNexthopAction = "withdraw"
+)
+
+var ZebraImportNilNexthopActionToIntMap = map[ZebraImportNilNexthopAction]int{
+	ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_SYNTHESIZE: 0,
+	ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_WITHDRAW:   1,
+}
+
+func (v ZebraImportNilNexthopAction) ToInt() int {
+	i, ok := ZebraImportNilNexthopActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraImportNilNexthopActionMap = map[int]ZebraImportNilNexthopAction{
+	0: ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_SYNTHESIZE,
+	1: ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_WITHDRAW,
+}
+
+func (v ZebraImportNilNexthopAction) Validate() error {
+	if _, ok := ZebraImportNilNexthopActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraImportNilNexthopAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-aspath-as-set-action.
+// Action taken on the AS_SET segments of the AS_PATH of a route to be
+// installed to zebra.
+type ZebraAspathAsSetAction string
+
+const (
+	ZEBRA_ASPATH_AS_SET_ACTION_KEEP    ZebraAspathAsSetAction = "keep"
+	ZEBRA_ASPATH_AS_SET_ACTION_FLATTEN ZebraAspathAsSetAction = "flatten"
+	ZEBRA_ASPATH_AS_SET_ACTION_OMIT    ZebraAspathAsSetAction = "omit"
+)
+
+var ZebraAspathAsSetActionToIntMap = map[ZebraAspathAsSetAction]int{
+	ZEBRA_ASPATH_AS_SET_ACTION_KEEP:    0,
+	ZEBRA_ASPATH_AS_SET_ACTION_FLATTEN: 1,
+	ZEBRA_ASPATH_AS_SET_ACTION_OMIT:    2,
+}
+
+func (v ZebraAspathAsSetAction) ToInt() int {
+	i, ok := ZebraAspathAsSetActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraAspathAsSetActionMap = map[int]ZebraAspathAsSetAction{
+	0: ZEBRA_ASPATH_AS_SET_ACTION_KEEP,
+	1: ZEBRA_ASPATH_AS_SET_ACTION_FLATTEN,
+	2: ZEBRA_ASPATH_AS_SET_ACTION_OMIT,
+}
+
+func (v ZebraAspathAsSetAction) Validate() error {
+	if _, ok := ZebraAspathAsSetActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraAspathAsSetAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-invalid-nexthop-action.
+// Determines how routes none of whose nexthops can be passed to zebra
+// are handled, such as IPv4 routes with IPv6 nexthops only.
+type ZebraInvalidNexthopAction string
+
+const (
+	ZEBRA_INVALID_NEXTHOP_ACTION_SKIP      ZebraInvalidNexthopAction = "skip"
+	ZEBRA_INVALID_NEXTHOP_ACTION_BLACKHOLE ZebraInvalidNexthopAction = "blackhole"
+)
+
+var ZebraInvalidNexthopActionToIntMap = map[ZebraInvalidNexthopAction]int{
+	ZEBRA_INVALID_NEXTHOP_ACTION_SKIP:      0,
+	ZEBRA_INVALID_NEXTHOP_ACTION_BLACKHOLE: 1,
+}
+
+func (v ZebraInvalidNexthopAction) ToInt() int {
+	i, ok := ZebraInvalidNexthopActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraInvalidNexthopActionMap = map[int]ZebraInvalidNexthopAction{
+	0: ZEBRA_INVALID_NEXTHOP_ACTION_SKIP,
+	1: ZEBRA_INVALID_NEXTHOP_ACTION_BLACKHOLE,
+}
+
+func (v ZebraInvalidNexthopAction) Validate() error {
+	if _, ok := ZebraInvalidNexthopActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraInvalidNexthopAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-unsupported-family-action.
+// Determines how paths of address families which zebra has no table for,
+// such as flowspec or route target constraint, are handled.
+type ZebraUnsupportedFamilyAction string
+
+const (
+	ZEBRA_UNSUPPORTED_FAMILY_ACTION_IGNORE ZebraUnsupportedFamilyAction = "ignore"
+	ZEBRA_UNSUPPORTED_FAMILY_ACTION_LOG    ZebraUnsupportedFamilyAction = "log"
+)
+
+var ZebraUnsupportedFamilyActionToIntMap = map[ZebraUnsupportedFamilyAction]int{
+	ZEBRA_UNSUPPORTED_FAMILY_ACTION_IGNORE: 0,
+	ZEBRA_UNSUPPORTED_FAMILY_ACTION_LOG:    1,
+}
+
+func (v ZebraUnsupportedFamilyAction) ToInt() int {
+	i, ok := ZebraUnsupportedFamilyActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraUnsupportedFamilyActionMap = map[int]ZebraUnsupportedFamilyAction{
+	0: ZEBRA_UNSUPPORTED_FAMILY_ACTION_IGNORE,
+	1: ZEBRA_UNSUPPORTED_FAMILY_ACTION_LOG,
+}
+
+func (v ZebraUnsupportedFamilyAction) Validate() error {
+	if _, ok := ZebraUnsupportedFamilyActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraUnsupportedFamilyAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-community-flag-precedence.
+// Determines the flags of routes carrying both a community asking for
+// them to be rejected, such as the backup community or the ones of
+// reject-community, and one of install-community.
+type ZebraCommunityFlagPrecedence string
+
+const (
+	ZEBRA_COMMUNITY_FLAG_PRECEDENCE_REJECT  ZebraCommunityFlagPrecedence = "reject"
+	ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL ZebraCommunityFlagPrecedence = "install"
+)
+
+var ZebraCommunityFlagPrecedenceToIntMap = map[ZebraCommunityFlagPrecedence]int{
+	ZEBRA_COMMUNITY_FLAG_PRECEDENCE_REJECT:  0,
+	ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL: 1,
+}
+
+func (v ZebraCommunityFlagPrecedence) ToInt() int {
+	i, ok := ZebraCommunityFlagPrecedenceToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraCommunityFlagPrecedenceMap = map[int]ZebraCommunityFlagPrecedence{
+	0: ZEBRA_COMMUNITY_FLAG_PRECEDENCE_REJECT,
+	1: ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL,
+}
+
+func (v ZebraCommunityFlagPrecedence) Validate() error {
+	if _, ok := ZebraCommunityFlagPrecedenceToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraCommunityFlagPrecedence: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-nil-source-action.
+// Action taken on the paths passed to the zebra client without source,
+// which come neither from a peer nor from zebra.
+type ZebraNilSourceAction string
+
+const (
+	ZEBRA_NIL_SOURCE_ACTION_LOCAL   ZebraNilSourceAction = "local"
+	ZEBRA_NIL_SOURCE_ACTION_INSTALL ZebraNilSourceAction = "install"
+	ZEBRA_NIL_SOURCE_ACTION_IGNORE  ZebraNilSourceAction = "ignore"
+)
+
+var ZebraNilSourceActionToIntMap = map[ZebraNilSourceAction]int{
+	ZEBRA_NIL_SOURCE_ACTION_LOCAL:   0,
+	ZEBRA_NIL_SOURCE_ACTION_INSTALL: 1,
+	ZEBRA_NIL_SOURCE_ACTION_IGNORE:  2,
+}
+
+func (v ZebraNilSourceAction) ToInt() int {
+	i, ok := ZebraNilSourceActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraNilSourceActionMap = map[int]ZebraNilSourceAction{
+	0: ZEBRA_NIL_SOURCE_ACTION_LOCAL,
+	1: ZEBRA_NIL_SOURCE_ACTION_INSTALL,
+	2: ZEBRA_NIL_SOURCE_ACTION_IGNORE,
+}
+
+func (v ZebraNilSourceAction) Validate() error {
+	if _, ok := ZebraNilSourceActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraNilSourceAction: %s", v)
+	}
+	return nil
+}
+
+// typedef for identity gobgp:zebra-cross-vrf-nexthop-action.
+// Action taken on the routes installed into a VRF whose nexthop belongs
+// to the prefix of an interface of another VRF.
+type ZebraCrossVrfNexthopAction string
+
+const (
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_NONE    ZebraCrossVrfNexthopAction = "none"
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE ZebraCrossVrfNexthopAction = "resolve"
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT  ZebraCrossVrfNexthopAction = "reject"
+)
+
+var ZebraCrossVrfNexthopActionToIntMap = map[ZebraCrossVrfNexthopAction]int{
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_NONE:    0,
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE: 1,
+	ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT:  2,
+}
+
+func (v ZebraCrossVrfNexthopAction) ToInt() int {
+	i, ok := ZebraCrossVrfNexthopActionToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+var IntToZebraCrossVrfNexthopActionMap = map[int]ZebraCrossVrfNexthopAction{
+	0: ZEBRA_CROSS_VRF_NEXTHOP_ACTION_NONE,
+	1: ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE,
+	2: ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT,
+}
+
+func (v ZebraCrossVrfNexthopAction) Validate() error {
+	if _, ok := ZebraCrossVrfNexthopActionToIntMap[v]; !ok {
+		return fmt.Errorf("invalid ZebraCrossVrfNexthopAction: %s", v)
+	}
+	return nil
+}
+
 // typedef for identity gobgp:rpki-validation-result-type.
 // indicate the validation result of RPKI based on ROA.
 type RpkiValidationResultType string
@@ -1104,6 +1607,85 @@ func (lhs *Collector) Equal(rhs *Collector) bool {
 	return true
 }
 
+// struct for container gobgp:neighbor-distance.
+// Administrative distance of the routes learned from the
+// given neighbor.
+type NeighborDistance struct {
+	// original -> gobgp:address
+	// gobgp:address's original type is inet:ip-address.
+	// Address of the neighbor.
+	Address string `mapstructure:"address" json:"address,omitempty"`
+	// original -> gobgp:distance
+	// Administrative distance installed with the routes learned
+	// from the neighbor.
+	Distance uint8 `mapstructure:"distance" json:"distance,omitempty"`
+}
+
+func (lhs *NeighborDistance) Equal(rhs *NeighborDistance) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Address != rhs.Address {
+		return false
+	}
+	if lhs.Distance != rhs.Distance {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:as-translation.
+// Translation of the AS of the neighbors the routes are learned
+// from, e.g. from a private AS number to the effective one.
+type AsTranslation struct {
+	// original -> gobgp:source-as
+	// gobgp:source-as's original type is inet:as-number.
+	// AS number of the neighbor.
+	SourceAs uint32 `mapstructure:"source-as" json:"source-as,omitempty"`
+	// original -> gobgp:translated-as
+	// gobgp:translated-as's original type is inet:as-number.
+	// AS number the neighbor's AS number is translated to.
+	TranslatedAs uint32 `mapstructure:"translated-as" json:"translated-as,omitempty"`
+}
+
+func (lhs *AsTranslation) Equal(rhs *AsTranslation) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.SourceAs != rhs.SourceAs {
+		return false
+	}
+	if lhs.TranslatedAs != rhs.TranslatedAs {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:community-tag.
+// Route tag of the routes installed into zebra which carry the
+// given community, taking precedence over tag.
+type CommunityTag struct {
+	// original -> gobgp:community
+	// Community, e.g. 65000:100 or no-export.
+	Community string `mapstructure:"community" json:"community,omitempty"`
+	// original -> gobgp:tag
+	// Route tag of the routes carrying the community.
+	Tag uint32 `mapstructure:"tag" json:"tag,omitempty"`
+}
+
+func (lhs *CommunityTag) Equal(rhs *CommunityTag) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Community != rhs.Community {
+		return false
+	}
+	if lhs.Tag != rhs.Tag {
+		return false
+	}
+	return true
+}
+
 // struct for container gobgp:state.
 type ZebraState struct {
 	// original -> gobgp:enabled
@@ -1116,13 +1698,338 @@ type ZebraState struct {
 	// original -> gobgp:redistribute-route-type
 	RedistributeRouteTypeList []InstallProtocolType `mapstructure:"redistribute-route-type-list" json:"redistribute-route-type-list,omitempty"`
 	// original -> gobgp:version
-	// Configure version of zebra protocol.  Default is 2. Supported up to 3.
+	// Configure version of zebra protocol.  Default is 2. Supported up to 6.
+	// From version 4 on, the older versions down to 3 are tried in turn
+	// if zebra does not speak the configured one.
 	Version uint8 `mapstructure:"version" json:"version,omitempty"`
 	// original -> gobgp:nexthop-trigger-enable
 	// gobgp:nexthop-trigger-enable's original type is boolean.
 	NexthopTriggerEnable bool `mapstructure:"nexthop-trigger-enable" json:"nexthop-trigger-enable,omitempty"`
 	// original -> gobgp:nexthop-trigger-delay
 	NexthopTriggerDelay uint8 `mapstructure:"nexthop-trigger-delay" json:"nexthop-trigger-delay,omitempty"`
+	// original -> gobgp:install-table-id
+	// Configure additional kernel table ids into which routes of the default
+	// VRF are installed alongside the main table.
+	InstallTableIdList []uint16 `mapstructure:"install-table-id-list" json:"install-table-id-list,omitempty"`
+	// original -> gobgp:aspath-error-action
+	// Configure the action taken when the AS_PATH of a route cannot be
+	// serialized. Default is ignore.
+	AspathErrorAction ZebraAspathErrorAction `mapstructure:"aspath-error-action" json:"aspath-error-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-max-delay
+	// Configure the upper bound in seconds of the delay applied to nexthop
+	// tracking updates while nexthops are flapping. Zero means no upper
+	// bound.
+	NexthopTriggerMaxDelay uint16 `mapstructure:"nexthop-trigger-max-delay" json:"nexthop-trigger-max-delay,omitempty"`
+	// original -> gobgp:write-buffer-size
+	// Configure the size in bytes of the buffer used to coalesce messages
+	// written to zebra. Zero disables buffering.
+	WriteBufferSize uint32 `mapstructure:"write-buffer-size" json:"write-buffer-size,omitempty"`
+	// original -> gobgp:write-flush-interval
+	// Configure the interval in milliseconds at which buffered messages are
+	// flushed to zebra. If zero, the buffer is flushed as soon as no more
+	// messages are queued.
+	WriteFlushInterval uint32 `mapstructure:"write-flush-interval" json:"write-flush-interval,omitempty"`
+	// original -> gobgp:nexthop-trigger-max-coalesce-age
+	// Configure the maximum time in seconds a nexthop tracking update may be
+	// held back to be coalesced with subsequent ones. Zero means no limit.
+	NexthopTriggerMaxCoalesceAge uint16 `mapstructure:"nexthop-trigger-max-coalesce-age" json:"nexthop-trigger-max-coalesce-age,omitempty"`
+	// original -> gobgp:vrf-resolve-mode
+	// Configure how the VRF of a VPN route is resolved. Defaults to
+	// rt-then-rd.
+	VrfResolveMode ZebraVrfResolveMode `mapstructure:"vrf-resolve-mode" json:"vrf-resolve-mode,omitempty"`
+	// original -> gobgp:nil-nexthop-action
+	// Configure how routes whose paths have no nexthop or an unspecified one
+	// (0.0.0.0 or ::) are handled. Defaults to install.
+	NilNexthopAction ZebraNilNexthopAction `mapstructure:"nil-nexthop-action" json:"nil-nexthop-action,omitempty"`
+	// original -> gobgp:neighbor-distance
+	// Administrative distance of the routes learned from the
+	// given neighbor.
+	NeighborDistanceList []NeighborDistance `mapstructure:"neighbor-distance-list" json:"neighbor-distance-list,omitempty"`
+	// original -> gobgp:watch-channel-size
+	// Configure the capacity of the channel through which best path and
+	// update events are passed to the zebra client. Defaults to 8.
+	WatchChannelSize uint32 `mapstructure:"watch-channel-size" json:"watch-channel-size,omitempty"`
+	// original -> gobgp:max-metric
+	// Configure the maximum metric installed into zebra. MEDs above it are
+	// clamped. Zero means no limit, every zebra message version carrying
+	// 32 bits metrics.
+	MaxMetric uint32 `mapstructure:"max-metric" json:"max-metric,omitempty"`
+	// original -> gobgp:send-empty-aspath
+	// gobgp:send-empty-aspath's original type is boolean.
+	// Configure sending an empty AS_PATH to zebra for iBGP routes whose
+	// AS_PATH is empty, so that route-maps can match on it.
+	SendEmptyAspath bool `mapstructure:"send-empty-aspath" json:"send-empty-aspath,omitempty"`
+	// original -> gobgp:panic-action
+	// Configure the action taken after recovering from a panic while
+	// handling a message. Defaults to continue.
+	PanicAction ZebraPanicAction `mapstructure:"panic-action" json:"panic-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-skip-default-route
+	// gobgp:nexthop-trigger-skip-default-route's original type is boolean.
+	// Do not register the nexthop of default routes for nexthop tracking.
+	NexthopTriggerSkipDefaultRoute bool `mapstructure:"nexthop-trigger-skip-default-route" json:"nexthop-trigger-skip-default-route,omitempty"`
+	// original -> gobgp:default-route-normal-afi-safi
+	// List of address families whose default route is handled like any other
+	// prefix instead of being installed from post-policy updates.
+	DefaultRouteNormalAfiSafiList []AfiSafiType `mapstructure:"default-route-normal-afi-safi-list" json:"default-route-normal-afi-safi-list,omitempty"`
+	// original -> gobgp:reconnect-interval
+	// Configure the interval in seconds before the first attempt to
+	// reconnect to zebra, which is doubled after each failed attempt.
+	// Defaults to 1.
+	ReconnectInterval uint16 `mapstructure:"reconnect-interval" json:"reconnect-interval,omitempty"`
+	// original -> gobgp:aux-encoding
+	// Configure how the Aux field carrying the AS_PATH is encoded. Defaults
+	// to raw.
+	AuxEncoding ZebraAuxEncoding `mapstructure:"aux-encoding" json:"aux-encoding,omitempty"`
+	// original -> gobgp:as-translation
+	// Translation of the AS of the neighbors the routes are learned
+	// from, e.g. from a private AS number to the effective one.
+	AsTranslationList []AsTranslation `mapstructure:"as-translation-list" json:"as-translation-list,omitempty"`
+	// original -> gobgp:nexthop-trigger-min-delay
+	// Configure the lower bound in seconds of the delay applied to nexthop
+	// tracking updates. Without it, a nexthop-trigger-delay of zero flushes
+	// the updates as soon as they arrive, coalescing only those queued
+	// meanwhile.
+	NexthopTriggerMinDelay uint8 `mapstructure:"nexthop-trigger-min-delay" json:"nexthop-trigger-min-delay,omitempty"`
+	// original -> gobgp:nexthop-trigger-bypass-community
+	// Configure communities marking the routes whose nexthop is not
+	// registered for nexthop tracking, e.g. those with a directly connected
+	// nexthop. The routes are still installed.
+	NexthopTriggerBypassCommunityList []string `mapstructure:"nexthop-trigger-bypass-community-list" json:"nexthop-trigger-bypass-community-list,omitempty"`
+	// original -> gobgp:install-state-file
+	// Path of the file the routes installed into zebra are saved to, so that
+	// the ones which are no longer to be installed are withdrawn after a
+	// restart. The state is not saved if empty.
+	InstallStateFile string `mapstructure:"install-state-file" json:"install-state-file,omitempty"`
+	// original -> gobgp:graceful-restart-reconcile
+	// gobgp:graceful-restart-reconcile's original type is boolean.
+	// Configure whether the routes saved in install-state-file are kept in
+	// zebra while GoBGP restarts gracefully. The withdraws of the saved
+	// routes which are not installed again wait until every neighbor has
+	// sent its End-of-RIB or its deferral timer has expired, and the saved
+	// routes installed again unchanged are not sent to zebra again.
+	GracefulRestartReconcile bool `mapstructure:"graceful-restart-reconcile" json:"graceful-restart-reconcile,omitempty"`
+	// original -> gobgp:graceful-restart-reconcile-timeout
+	// Configure the maximum time in seconds the withdraws of the saved
+	// routes wait for the graceful restart of GoBGP to finish when
+	// graceful-restart-reconcile is enabled. Defaults to 360.
+	GracefulRestartReconcileTimeout uint16 `mapstructure:"graceful-restart-reconcile-timeout" json:"graceful-restart-reconcile-timeout,omitempty"`
+	// original -> gobgp:label-rewrite-action
+	// Configure how the label stack of labeled routes is rewritten before
+	// they are passed to zebra. The label stack is only passed with the tlv
+	// aux-encoding. Defaults to none.
+	LabelRewriteAction ZebraLabelRewriteAction `mapstructure:"label-rewrite-action" json:"label-rewrite-action,omitempty"`
+	// original -> gobgp:label-rewrite-label
+	// Configure the label the top label is swapped for with the swap
+	// label-rewrite-action.
+	LabelRewriteLabel uint32 `mapstructure:"label-rewrite-label" json:"label-rewrite-label,omitempty"`
+	// original -> gobgp:interface-sync-timeout
+	// Configure the time in seconds to wait after connecting for zebra to
+	// send the interfaces before routes are exchanged. Zero means not
+	// waiting.
+	InterfaceSyncTimeout uint16 `mapstructure:"interface-sync-timeout" json:"interface-sync-timeout,omitempty"`
+	// original -> gobgp:nexthop-metric-precedence
+	// Configure which MED wins when both a nexthop update and an import
+	// policy set it. Defaults to policy.
+	NexthopMetricPrecedence ZebraNexthopMetricPrecedence `mapstructure:"nexthop-metric-precedence" json:"nexthop-metric-precedence,omitempty"`
+	// original -> gobgp:nexthop-trigger-penalty-charge
+	// Configure the penalty charged for each nexthop tracking update. Zero
+	// means the default of 500.
+	NexthopTriggerPenaltyCharge uint16 `mapstructure:"nexthop-trigger-penalty-charge" json:"nexthop-trigger-penalty-charge,omitempty"`
+	// original -> gobgp:nexthop-trigger-suppress-threshold
+	// Configure the penalty above which nexthop tracking updates are
+	// dampened beyond nexthop-trigger-delay. Zero means the default of 950.
+	NexthopTriggerSuppressThreshold uint16 `mapstructure:"nexthop-trigger-suppress-threshold" json:"nexthop-trigger-suppress-threshold,omitempty"`
+	// original -> gobgp:nexthop-trigger-damping-delay
+	// Configure the delay in seconds added for each halving of the penalty
+	// needed to bring it under the suppress threshold. Zero means the
+	// default of 8.
+	NexthopTriggerDampingDelay uint8 `mapstructure:"nexthop-trigger-damping-delay" json:"nexthop-trigger-damping-delay,omitempty"`
+	// original -> gobgp:nexthop-trigger-decay-interval
+	// Configure the interval in seconds at which the penalty of nexthop
+	// tracking updates is halved. Zero means the default of 8.
+	NexthopTriggerDecayInterval uint16 `mapstructure:"nexthop-trigger-decay-interval" json:"nexthop-trigger-decay-interval,omitempty"`
+	// original -> gobgp:import-nil-nexthop-action
+	// Configure how IPv6 routes redistributed from zebra without a nexthop
+	// are imported. Defaults to synthesize.
+	ImportNilNexthopAction ZebraImportNilNexthopAction `mapstructure:"import-nil-nexthop-action" json:"import-nil-nexthop-action,omitempty"`
+	// original -> gobgp:source-neighbor-address
+	// gobgp:source-neighbor-address's original type is inet:ip-address.
+	// Configure the address which neighbor sets match against the routes
+	// redistributed from zebra, which otherwise have none. The routes are
+	// still handled as locally originated.
+	SourceNeighborAddress string `mapstructure:"source-neighbor-address" json:"source-neighbor-address,omitempty"`
+	// original -> gobgp:reconnect-max-interval
+	// Configure the upper bound in seconds of the interval between the
+	// attempts to reconnect to zebra. Defaults to 30.
+	ReconnectMaxInterval uint16 `mapstructure:"reconnect-max-interval" json:"reconnect-max-interval,omitempty"`
+	// original -> gobgp:import-dedup
+	// gobgp:import-dedup's original type is boolean.
+	// Configure whether routes which zebra sends again with the same nexthop
+	// and metric are ignored instead of being imported again.
+	ImportDedup bool `mapstructure:"import-dedup" json:"import-dedup,omitempty"`
+	// original -> gobgp:ebgp-distance
+	// Configure the administrative distance of the routes learned over eBGP,
+	// unless set for their neighbor in neighbor-distance. Zero leaves it to
+	// zebra.
+	EbgpDistance uint8 `mapstructure:"ebgp-distance" json:"ebgp-distance,omitempty"`
+	// original -> gobgp:ibgp-distance
+	// Configure the administrative distance of the routes learned over iBGP,
+	// unless set for their neighbor in neighbor-distance. Zero leaves it to
+	// zebra.
+	IbgpDistance uint8 `mapstructure:"ibgp-distance" json:"ibgp-distance,omitempty"`
+	// original -> gobgp:invalidate-on-interface-down
+	// gobgp:invalidate-on-interface-down's original type is boolean.
+	// Configure whether the paths whose nexthop is on a connected prefix of
+	// an interface are invalidated as soon as zebra reports the interface
+	// down, and validated again once it is up, rather than waiting for
+	// nexthop updates.
+	InvalidateOnInterfaceDown bool `mapstructure:"invalidate-on-interface-down" json:"invalidate-on-interface-down,omitempty"`
+	// original -> gobgp:tag
+	// Configure the route tag of the routes installed into zebra, unless one
+	// of their communities is mapped to another one in community-tag. Zero
+	// means no tag.
+	Tag uint32 `mapstructure:"tag" json:"tag,omitempty"`
+	// original -> gobgp:community-tag
+	// Route tag of the routes installed into zebra which carry the
+	// given community, taking precedence over tag.
+	CommunityTagList []CommunityTag `mapstructure:"community-tag-list" json:"community-tag-list,omitempty"`
+	// original -> gobgp:nexthop-register-retry-interval
+	// Configure the interval in seconds between the attempts to send again
+	// the nexthop registrations zebra has failed to receive. Defaults to 5.
+	NexthopRegisterRetryInterval uint16 `mapstructure:"nexthop-register-retry-interval" json:"nexthop-register-retry-interval,omitempty"`
+	// original -> gobgp:blackhole-community
+	// Configure communities marking the routes installed as blackhole
+	// routes, which silently drop the packets. It takes precedence over
+	// reject-community.
+	BlackholeCommunityList []string `mapstructure:"blackhole-community-list" json:"blackhole-community-list,omitempty"`
+	// original -> gobgp:reject-community
+	// Configure communities marking the routes installed as reject routes,
+	// which drop the packets with an ICMP unreachable error.
+	RejectCommunityList []string `mapstructure:"reject-community-list" json:"reject-community-list,omitempty"`
+	// original -> gobgp:aspath-as-set-action
+	// Configure the action taken on the AS_SET segments of the AS_PATH sent
+	// to zebra. Default is keep.
+	AspathAsSetAction ZebraAspathAsSetAction `mapstructure:"aspath-as-set-action" json:"aspath-as-set-action,omitempty"`
+	// original -> gobgp:withdraw-on-shutdown
+	// gobgp:withdraw-on-shutdown's original type is boolean.
+	// Configure to withdraw the routes installed into zebra when the client
+	// is stopped.
+	WithdrawOnShutdown bool `mapstructure:"withdraw-on-shutdown" json:"withdraw-on-shutdown,omitempty"`
+	// original -> gobgp:shutdown-drain-timeout
+	// Configure the maximum time in seconds to wait for the nexthop
+	// unregisters and the withdraws sent on shutdown to be written to
+	// zebra. The connection is closed regardless once it expires.
+	// Defaults to 5.
+	ShutdownDrainTimeout uint16 `mapstructure:"shutdown-drain-timeout" json:"shutdown-drain-timeout,omitempty"`
+	// original -> gobgp:import-family
+	// Configure the address families of the routes imported from zebra. All
+	// of them are imported if empty.
+	ImportFamilyList []AfiSafiType `mapstructure:"import-family-list" json:"import-family-list,omitempty"`
+	// original -> gobgp:mtu
+	// Configure the MTU of the routes installed into zebra. No MTU is sent
+	// if zero.
+	Mtu uint32 `mapstructure:"mtu" json:"mtu,omitempty"`
+	// original -> gobgp:max-metric-unreachable
+	// gobgp:max-metric-unreachable's original type is boolean.
+	// Treat the routes with the largest metric Zebra can carry as
+	// unreachable, both when importing them from Zebra and when installing
+	// them, in which case they are withdrawn.
+	MaxMetricUnreachable bool `mapstructure:"max-metric-unreachable" json:"max-metric-unreachable,omitempty"`
+	// original -> gobgp:nexthop-label-resolution
+	// gobgp:nexthop-label-resolution's original type is boolean.
+	// Push the labels Zebra resolves the nexthop of the VPN routes installed
+	// with on top of their own labels, for the route to carry the label
+	// stack of the underlay. Needs version 5 or later, the former ones
+	// carrying a single label.
+	NexthopLabelResolution bool `mapstructure:"nexthop-label-resolution" json:"nexthop-label-resolution,omitempty"`
+	// original -> gobgp:self-route-withdraw-nexthop
+	// Nexthop addresses of the routes withdrawn from zebra when a local path
+	// is selected, at most one per address family. The loopback address of
+	// the family is used by default.
+	SelfRouteWithdrawNexthopList []string `mapstructure:"self-route-withdraw-nexthop-list" json:"self-route-withdraw-nexthop-list,omitempty"`
+	// original -> gobgp:self-route-withdraw-blackhole
+	// gobgp:self-route-withdraw-blackhole's original type is boolean.
+	// Withdraw the routes from zebra as blackhole routes rather than with a
+	// loopback nexthop when a local path is selected.
+	SelfRouteWithdrawBlackhole bool `mapstructure:"self-route-withdraw-blackhole" json:"self-route-withdraw-blackhole,omitempty"`
+	// original -> gobgp:invalid-nexthop-action
+	// Configure how routes none of whose nexthops is valid for their address
+	// family are passed to zebra. They are skipped by default.
+	InvalidNexthopAction ZebraInvalidNexthopAction `mapstructure:"invalid-nexthop-action" json:"invalid-nexthop-action,omitempty"`
+	// original -> gobgp:unsupported-family-action
+	// Configure how paths of address families which cannot be installed into
+	// zebra are handled. They are logged and counted by default.
+	UnsupportedFamilyAction ZebraUnsupportedFamilyAction `mapstructure:"unsupported-family-action" json:"unsupported-family-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-withdraw-penalty-charge
+	// Configure the penalty charged for each nexthop tracking update
+	// invalidating the nexthop of the paths, which withdraws them. Zero
+	// means the same as nexthop-trigger-penalty-charge.
+	NexthopTriggerWithdrawPenaltyCharge uint16 `mapstructure:"nexthop-trigger-withdraw-penalty-charge" json:"nexthop-trigger-withdraw-penalty-charge,omitempty"`
+	// original -> gobgp:additional-url
+	// Configure the URLs of other zebra instances to install the same routes
+	// into for redundancy. Each of them is connected to, tracks the routes
+	// installed into it and reconnects independently of the others.
+	AdditionalUrlList []string `mapstructure:"additional-url-list" json:"additional-url-list,omitempty"`
+	// original -> gobgp:install-community
+	// Configure the communities marking the routes to be installed normally.
+	// Along with the backup community or one of reject-community, see
+	// community-flag-precedence.
+	InstallCommunityList []string `mapstructure:"install-community-list" json:"install-community-list,omitempty"`
+	// original -> gobgp:community-flag-precedence
+	// Configure which of the communities asking for a route to be rejected
+	// and install-community takes precedence when a route carries both. The
+	// reject ones do by default.
+	CommunityFlagPrecedence ZebraCommunityFlagPrecedence `mapstructure:"community-flag-precedence" json:"community-flag-precedence,omitempty"`
+	// original -> gobgp:metrics-enabled
+	// gobgp:metrics-enabled's original type is boolean.
+	// Configure exporting the metrics of the zebra client, such as the
+	// connection state and the number of messages exchanged, in the
+	// Prometheus text format. gobgpd serves them at /metrics on the host
+	// given by --metrics-host.
+	MetricsEnabled bool `mapstructure:"metrics-enabled" json:"metrics-enabled,omitempty"`
+	// original -> gobgp:dump-batch-size
+	// Configure the maximum number of paths sent to zebra at once when the
+	// paths of the VRF tables are dumped after connecting. The next batch is
+	// fetched from the RIB once the client has processed the previous one,
+	// which bounds the memory used to dump large RIBs. Defaults to 1000.
+	// Zero means dumping every table at once.
+	DumpBatchSize uint32 `mapstructure:"dump-batch-size" json:"dump-batch-size,omitempty"`
+	// original -> gobgp:dump-batch-interval
+	// Configure the time in milliseconds to wait between two batches of
+	// paths dumped to zebra, see dump-batch-size. Defaults to 10.
+	DumpBatchInterval uint32 `mapstructure:"dump-batch-interval" json:"dump-batch-interval,omitempty"`
+	// original -> gobgp:nil-source-action
+	// Configure the action taken on the paths without source, which come
+	// neither from a peer nor from zebra. Defaults to LOCAL.
+	NilSourceAction ZebraNilSourceAction `mapstructure:"nil-source-action" json:"nil-source-action,omitempty"`
+	// original -> gobgp:nexthop-self
+	// gobgp:nexthop-self's original type is boolean.
+	// Configure whether the nexthops of the routes installed into zebra are
+	// rewritten to the local address of the BGP session over which their
+	// path has been received, or to the router id for IPv4 routes if the
+	// session address is of another family, so that the kernel resolves them
+	// recursively. The routes of multiple paths then have a single nexthop.
+	// The routes without such an address keep their nexthops.
+	NexthopSelf bool `mapstructure:"nexthop-self" json:"nexthop-self,omitempty"`
+	// original -> gobgp:nexthop-trigger-ignore-zone
+	// gobgp:nexthop-trigger-ignore-zone's original type is boolean.
+	// Configure whether link-local nexthops are tracked by their address
+	// only. By default, the same link-local nexthop reached through
+	// different interfaces is tracked once per interface.
+	NexthopTriggerIgnoreZone bool `mapstructure:"nexthop-trigger-ignore-zone" json:"nexthop-trigger-ignore-zone,omitempty"`
+	// original -> gobgp:cross-vrf-nexthop-action
+	// Configure the action taken on the routes whose nexthop belongs to the
+	// prefix of an interface, known from zebra, of a VRF other than the one
+	// they are installed into, e.g. when leaking routes between VRFs.
+	// Defaults to NONE.
+	CrossVrfNexthopAction ZebraCrossVrfNexthopAction `mapstructure:"cross-vrf-nexthop-action" json:"cross-vrf-nexthop-action,omitempty"`
+	// original -> gobgp:redistribute-all-vrfs
+	// gobgp:redistribute-all-vrfs's original type is boolean.
+	// Configure whether the routes of the protocols of
+	// redistribute-route-type-list are redistributed to GoBGP from every VRF
+	// it knows, including the VRFs added later, rather than only from the
+	// default VRF.
+	RedistributeAllVrfs bool `mapstructure:"redistribute-all-vrfs" json:"redistribute-all-vrfs,omitempty"`
 }
 
 // struct for container gobgp:config.
@@ -1137,13 +2044,338 @@ type ZebraConfig struct {
 	// original -> gobgp:redistribute-route-type
 	RedistributeRouteTypeList []InstallProtocolType `mapstructure:"redistribute-route-type-list" json:"redistribute-route-type-list,omitempty"`
 	// original -> gobgp:version
-	// Configure version of zebra protocol.  Default is 2. Supported up to 3.
+	// Configure version of zebra protocol.  Default is 2. Supported up to 6.
+	// From version 4 on, the older versions down to 3 are tried in turn
+	// if zebra does not speak the configured one.
 	Version uint8 `mapstructure:"version" json:"version,omitempty"`
 	// original -> gobgp:nexthop-trigger-enable
 	// gobgp:nexthop-trigger-enable's original type is boolean.
 	NexthopTriggerEnable bool `mapstructure:"nexthop-trigger-enable" json:"nexthop-trigger-enable,omitempty"`
 	// original -> gobgp:nexthop-trigger-delay
 	NexthopTriggerDelay uint8 `mapstructure:"nexthop-trigger-delay" json:"nexthop-trigger-delay,omitempty"`
+	// original -> gobgp:install-table-id
+	// Configure additional kernel table ids into which routes of the default
+	// VRF are installed alongside the main table.
+	InstallTableIdList []uint16 `mapstructure:"install-table-id-list" json:"install-table-id-list,omitempty"`
+	// original -> gobgp:aspath-error-action
+	// Configure the action taken when the AS_PATH of a route cannot be
+	// serialized. Default is ignore.
+	AspathErrorAction ZebraAspathErrorAction `mapstructure:"aspath-error-action" json:"aspath-error-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-max-delay
+	// Configure the upper bound in seconds of the delay applied to nexthop
+	// tracking updates while nexthops are flapping. Zero means no upper
+	// bound.
+	NexthopTriggerMaxDelay uint16 `mapstructure:"nexthop-trigger-max-delay" json:"nexthop-trigger-max-delay,omitempty"`
+	// original -> gobgp:write-buffer-size
+	// Configure the size in bytes of the buffer used to coalesce messages
+	// written to zebra. Zero disables buffering.
+	WriteBufferSize uint32 `mapstructure:"write-buffer-size" json:"write-buffer-size,omitempty"`
+	// original -> gobgp:write-flush-interval
+	// Configure the interval in milliseconds at which buffered messages are
+	// flushed to zebra. If zero, the buffer is flushed as soon as no more
+	// messages are queued.
+	WriteFlushInterval uint32 `mapstructure:"write-flush-interval" json:"write-flush-interval,omitempty"`
+	// original -> gobgp:nexthop-trigger-max-coalesce-age
+	// Configure the maximum time in seconds a nexthop tracking update may be
+	// held back to be coalesced with subsequent ones. Zero means no limit.
+	NexthopTriggerMaxCoalesceAge uint16 `mapstructure:"nexthop-trigger-max-coalesce-age" json:"nexthop-trigger-max-coalesce-age,omitempty"`
+	// original -> gobgp:vrf-resolve-mode
+	// Configure how the VRF of a VPN route is resolved. Defaults to
+	// rt-then-rd.
+	VrfResolveMode ZebraVrfResolveMode `mapstructure:"vrf-resolve-mode" json:"vrf-resolve-mode,omitempty"`
+	// original -> gobgp:nil-nexthop-action
+	// Configure how routes whose paths have no nexthop or an unspecified one
+	// (0.0.0.0 or ::) are handled. Defaults to install.
+	NilNexthopAction ZebraNilNexthopAction `mapstructure:"nil-nexthop-action" json:"nil-nexthop-action,omitempty"`
+	// original -> gobgp:neighbor-distance
+	// Administrative distance of the routes learned from the
+	// given neighbor.
+	NeighborDistanceList []NeighborDistance `mapstructure:"neighbor-distance-list" json:"neighbor-distance-list,omitempty"`
+	// original -> gobgp:watch-channel-size
+	// Configure the capacity of the channel through which best path and
+	// update events are passed to the zebra client. Defaults to 8.
+	WatchChannelSize uint32 `mapstructure:"watch-channel-size" json:"watch-channel-size,omitempty"`
+	// original -> gobgp:max-metric
+	// Configure the maximum metric installed into zebra. MEDs above it are
+	// clamped. Zero means no limit, every zebra message version carrying
+	// 32 bits metrics.
+	MaxMetric uint32 `mapstructure:"max-metric" json:"max-metric,omitempty"`
+	// original -> gobgp:send-empty-aspath
+	// gobgp:send-empty-aspath's original type is boolean.
+	// Configure sending an empty AS_PATH to zebra for iBGP routes whose
+	// AS_PATH is empty, so that route-maps can match on it.
+	SendEmptyAspath bool `mapstructure:"send-empty-aspath" json:"send-empty-aspath,omitempty"`
+	// original -> gobgp:panic-action
+	// Configure the action taken after recovering from a panic while
+	// handling a message. Defaults to continue.
+	PanicAction ZebraPanicAction `mapstructure:"panic-action" json:"panic-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-skip-default-route
+	// gobgp:nexthop-trigger-skip-default-route's original type is boolean.
+	// Do not register the nexthop of default routes for nexthop tracking.
+	NexthopTriggerSkipDefaultRoute bool `mapstructure:"nexthop-trigger-skip-default-route" json:"nexthop-trigger-skip-default-route,omitempty"`
+	// original -> gobgp:default-route-normal-afi-safi
+	// List of address families whose default route is handled like any other
+	// prefix instead of being installed from post-policy updates.
+	DefaultRouteNormalAfiSafiList []AfiSafiType `mapstructure:"default-route-normal-afi-safi-list" json:"default-route-normal-afi-safi-list,omitempty"`
+	// original -> gobgp:reconnect-interval
+	// Configure the interval in seconds before the first attempt to
+	// reconnect to zebra, which is doubled after each failed attempt.
+	// Defaults to 1.
+	ReconnectInterval uint16 `mapstructure:"reconnect-interval" json:"reconnect-interval,omitempty"`
+	// original -> gobgp:aux-encoding
+	// Configure how the Aux field carrying the AS_PATH is encoded. Defaults
+	// to raw.
+	AuxEncoding ZebraAuxEncoding `mapstructure:"aux-encoding" json:"aux-encoding,omitempty"`
+	// original -> gobgp:as-translation
+	// Translation of the AS of the neighbors the routes are learned
+	// from, e.g. from a private AS number to the effective one.
+	AsTranslationList []AsTranslation `mapstructure:"as-translation-list" json:"as-translation-list,omitempty"`
+	// original -> gobgp:nexthop-trigger-min-delay
+	// Configure the lower bound in seconds of the delay applied to nexthop
+	// tracking updates. Without it, a nexthop-trigger-delay of zero flushes
+	// the updates as soon as they arrive, coalescing only those queued
+	// meanwhile.
+	NexthopTriggerMinDelay uint8 `mapstructure:"nexthop-trigger-min-delay" json:"nexthop-trigger-min-delay,omitempty"`
+	// original -> gobgp:nexthop-trigger-bypass-community
+	// Configure communities marking the routes whose nexthop is not
+	// registered for nexthop tracking, e.g. those with a directly connected
+	// nexthop. The routes are still installed.
+	NexthopTriggerBypassCommunityList []string `mapstructure:"nexthop-trigger-bypass-community-list" json:"nexthop-trigger-bypass-community-list,omitempty"`
+	// original -> gobgp:install-state-file
+	// Path of the file the routes installed into zebra are saved to, so that
+	// the ones which are no longer to be installed are withdrawn after a
+	// restart. The state is not saved if empty.
+	InstallStateFile string `mapstructure:"install-state-file" json:"install-state-file,omitempty"`
+	// original -> gobgp:graceful-restart-reconcile
+	// gobgp:graceful-restart-reconcile's original type is boolean.
+	// Configure whether the routes saved in install-state-file are kept in
+	// zebra while GoBGP restarts gracefully. The withdraws of the saved
+	// routes which are not installed again wait until every neighbor has
+	// sent its End-of-RIB or its deferral timer has expired, and the saved
+	// routes installed again unchanged are not sent to zebra again.
+	GracefulRestartReconcile bool `mapstructure:"graceful-restart-reconcile" json:"graceful-restart-reconcile,omitempty"`
+	// original -> gobgp:graceful-restart-reconcile-timeout
+	// Configure the maximum time in seconds the withdraws of the saved
+	// routes wait for the graceful restart of GoBGP to finish when
+	// graceful-restart-reconcile is enabled. Defaults to 360.
+	GracefulRestartReconcileTimeout uint16 `mapstructure:"graceful-restart-reconcile-timeout" json:"graceful-restart-reconcile-timeout,omitempty"`
+	// original -> gobgp:label-rewrite-action
+	// Configure how the label stack of labeled routes is rewritten before
+	// they are passed to zebra. The label stack is only passed with the tlv
+	// aux-encoding. Defaults to none.
+	LabelRewriteAction ZebraLabelRewriteAction `mapstructure:"label-rewrite-action" json:"label-rewrite-action,omitempty"`
+	// original -> gobgp:label-rewrite-label
+	// Configure the label the top label is swapped for with the swap
+	// label-rewrite-action.
+	LabelRewriteLabel uint32 `mapstructure:"label-rewrite-label" json:"label-rewrite-label,omitempty"`
+	// original -> gobgp:interface-sync-timeout
+	// Configure the time in seconds to wait after connecting for zebra to
+	// send the interfaces before routes are exchanged. Zero means not
+	// waiting.
+	InterfaceSyncTimeout uint16 `mapstructure:"interface-sync-timeout" json:"interface-sync-timeout,omitempty"`
+	// original -> gobgp:nexthop-metric-precedence
+	// Configure which MED wins when both a nexthop update and an import
+	// policy set it. Defaults to policy.
+	NexthopMetricPrecedence ZebraNexthopMetricPrecedence `mapstructure:"nexthop-metric-precedence" json:"nexthop-metric-precedence,omitempty"`
+	// original -> gobgp:nexthop-trigger-penalty-charge
+	// Configure the penalty charged for each nexthop tracking update. Zero
+	// means the default of 500.
+	NexthopTriggerPenaltyCharge uint16 `mapstructure:"nexthop-trigger-penalty-charge" json:"nexthop-trigger-penalty-charge,omitempty"`
+	// original -> gobgp:nexthop-trigger-suppress-threshold
+	// Configure the penalty above which nexthop tracking updates are
+	// dampened beyond nexthop-trigger-delay. Zero means the default of 950.
+	NexthopTriggerSuppressThreshold uint16 `mapstructure:"nexthop-trigger-suppress-threshold" json:"nexthop-trigger-suppress-threshold,omitempty"`
+	// original -> gobgp:nexthop-trigger-damping-delay
+	// Configure the delay in seconds added for each halving of the penalty
+	// needed to bring it under the suppress threshold. Zero means the
+	// default of 8.
+	NexthopTriggerDampingDelay uint8 `mapstructure:"nexthop-trigger-damping-delay" json:"nexthop-trigger-damping-delay,omitempty"`
+	// original -> gobgp:nexthop-trigger-decay-interval
+	// Configure the interval in seconds at which the penalty of nexthop
+	// tracking updates is halved. Zero means the default of 8.
+	NexthopTriggerDecayInterval uint16 `mapstructure:"nexthop-trigger-decay-interval" json:"nexthop-trigger-decay-interval,omitempty"`
+	// original -> gobgp:import-nil-nexthop-action
+	// Configure how IPv6 routes redistributed from zebra without a nexthop
+	// are imported. Defaults to synthesize.
+	ImportNilNexthopAction ZebraImportNilNexthopAction `mapstructure:"import-nil-nexthop-action" json:"import-nil-nexthop-action,omitempty"`
+	// original -> gobgp:source-neighbor-address
+	// gobgp:source-neighbor-address's original type is inet:ip-address.
+	// Configure the address which neighbor sets match against the routes
+	// redistributed from zebra, which otherwise have none. The routes are
+	// still handled as locally originated.
+	SourceNeighborAddress string `mapstructure:"source-neighbor-address" json:"source-neighbor-address,omitempty"`
+	// original -> gobgp:reconnect-max-interval
+	// Configure the upper bound in seconds of the interval between the
+	// attempts to reconnect to zebra. Defaults to 30.
+	ReconnectMaxInterval uint16 `mapstructure:"reconnect-max-interval" json:"reconnect-max-interval,omitempty"`
+	// original -> gobgp:import-dedup
+	// gobgp:import-dedup's original type is boolean.
+	// Configure whether routes which zebra sends again with the same nexthop
+	// and metric are ignored instead of being imported again.
+	ImportDedup bool `mapstructure:"import-dedup" json:"import-dedup,omitempty"`
+	// original -> gobgp:ebgp-distance
+	// Configure the administrative distance of the routes learned over eBGP,
+	// unless set for their neighbor in neighbor-distance. Zero leaves it to
+	// zebra.
+	EbgpDistance uint8 `mapstructure:"ebgp-distance" json:"ebgp-distance,omitempty"`
+	// original -> gobgp:ibgp-distance
+	// Configure the administrative distance of the routes learned over iBGP,
+	// unless set for their neighbor in neighbor-distance. Zero leaves it to
+	// zebra.
+	IbgpDistance uint8 `mapstructure:"ibgp-distance" json:"ibgp-distance,omitempty"`
+	// original -> gobgp:invalidate-on-interface-down
+	// gobgp:invalidate-on-interface-down's original type is boolean.
+	// Configure whether the paths whose nexthop is on a connected prefix of
+	// an interface are invalidated as soon as zebra reports the interface
+	// down, and validated again once it is up, rather than waiting for
+	// nexthop updates.
+	InvalidateOnInterfaceDown bool `mapstructure:"invalidate-on-interface-down" json:"invalidate-on-interface-down,omitempty"`
+	// original -> gobgp:tag
+	// Configure the route tag of the routes installed into zebra, unless one
+	// of their communities is mapped to another one in community-tag. Zero
+	// means no tag.
+	Tag uint32 `mapstructure:"tag" json:"tag,omitempty"`
+	// original -> gobgp:community-tag
+	// Route tag of the routes installed into zebra which carry the
+	// given community, taking precedence over tag.
+	CommunityTagList []CommunityTag `mapstructure:"community-tag-list" json:"community-tag-list,omitempty"`
+	// original -> gobgp:nexthop-register-retry-interval
+	// Configure the interval in seconds between the attempts to send again
+	// the nexthop registrations zebra has failed to receive. Defaults to 5.
+	NexthopRegisterRetryInterval uint16 `mapstructure:"nexthop-register-retry-interval" json:"nexthop-register-retry-interval,omitempty"`
+	// original -> gobgp:blackhole-community
+	// Configure communities marking the routes installed as blackhole
+	// routes, which silently drop the packets. It takes precedence over
+	// reject-community.
+	BlackholeCommunityList []string `mapstructure:"blackhole-community-list" json:"blackhole-community-list,omitempty"`
+	// original -> gobgp:reject-community
+	// Configure communities marking the routes installed as reject routes,
+	// which drop the packets with an ICMP unreachable error.
+	RejectCommunityList []string `mapstructure:"reject-community-list" json:"reject-community-list,omitempty"`
+	// original -> gobgp:aspath-as-set-action
+	// Configure the action taken on the AS_SET segments of the AS_PATH sent
+	// to zebra. Default is keep.
+	AspathAsSetAction ZebraAspathAsSetAction `mapstructure:"aspath-as-set-action" json:"aspath-as-set-action,omitempty"`
+	// original -> gobgp:withdraw-on-shutdown
+	// gobgp:withdraw-on-shutdown's original type is boolean.
+	// Configure to withdraw the routes installed into zebra when the client
+	// is stopped.
+	WithdrawOnShutdown bool `mapstructure:"withdraw-on-shutdown" json:"withdraw-on-shutdown,omitempty"`
+	// original -> gobgp:shutdown-drain-timeout
+	// Configure the maximum time in seconds to wait for the nexthop
+	// unregisters and the withdraws sent on shutdown to be written to
+	// zebra. The connection is closed regardless once it expires.
+	// Defaults to 5.
+	ShutdownDrainTimeout uint16 `mapstructure:"shutdown-drain-timeout" json:"shutdown-drain-timeout,omitempty"`
+	// original -> gobgp:import-family
+	// Configure the address families of the routes imported from zebra. All
+	// of them are imported if empty.
+	ImportFamilyList []AfiSafiType `mapstructure:"import-family-list" json:"import-family-list,omitempty"`
+	// original -> gobgp:mtu
+	// Configure the MTU of the routes installed into zebra. No MTU is sent
+	// if zero.
+	Mtu uint32 `mapstructure:"mtu" json:"mtu,omitempty"`
+	// original -> gobgp:max-metric-unreachable
+	// gobgp:max-metric-unreachable's original type is boolean.
+	// Treat the routes with the largest metric Zebra can carry as
+	// unreachable, both when importing them from Zebra and when installing
+	// them, in which case they are withdrawn.
+	MaxMetricUnreachable bool `mapstructure:"max-metric-unreachable" json:"max-metric-unreachable,omitempty"`
+	// original -> gobgp:nexthop-label-resolution
+	// gobgp:nexthop-label-resolution's original type is boolean.
+	// Push the labels Zebra resolves the nexthop of the VPN routes installed
+	// with on top of their own labels, for the route to carry the label
+	// stack of the underlay. Needs version 5 or later, the former ones
+	// carrying a single label.
+	NexthopLabelResolution bool `mapstructure:"nexthop-label-resolution" json:"nexthop-label-resolution,omitempty"`
+	// original -> gobgp:self-route-withdraw-nexthop
+	// Nexthop addresses of the routes withdrawn from zebra when a local path
+	// is selected, at most one per address family. The loopback address of
+	// the family is used by default.
+	SelfRouteWithdrawNexthopList []string `mapstructure:"self-route-withdraw-nexthop-list" json:"self-route-withdraw-nexthop-list,omitempty"`
+	// original -> gobgp:self-route-withdraw-blackhole
+	// gobgp:self-route-withdraw-blackhole's original type is boolean.
+	// Withdraw the routes from zebra as blackhole routes rather than with a
+	// loopback nexthop when a local path is selected.
+	SelfRouteWithdrawBlackhole bool `mapstructure:"self-route-withdraw-blackhole" json:"self-route-withdraw-blackhole,omitempty"`
+	// original -> gobgp:invalid-nexthop-action
+	// Configure how routes none of whose nexthops is valid for their address
+	// family are passed to zebra. They are skipped by default.
+	InvalidNexthopAction ZebraInvalidNexthopAction `mapstructure:"invalid-nexthop-action" json:"invalid-nexthop-action,omitempty"`
+	// original -> gobgp:unsupported-family-action
+	// Configure how paths of address families which cannot be installed into
+	// zebra are handled. They are logged and counted by default.
+	UnsupportedFamilyAction ZebraUnsupportedFamilyAction `mapstructure:"unsupported-family-action" json:"unsupported-family-action,omitempty"`
+	// original -> gobgp:nexthop-trigger-withdraw-penalty-charge
+	// Configure the penalty charged for each nexthop tracking update
+	// invalidating the nexthop of the paths, which withdraws them. Zero
+	// means the same as nexthop-trigger-penalty-charge.
+	NexthopTriggerWithdrawPenaltyCharge uint16 `mapstructure:"nexthop-trigger-withdraw-penalty-charge" json:"nexthop-trigger-withdraw-penalty-charge,omitempty"`
+	// original -> gobgp:additional-url
+	// Configure the URLs of other zebra instances to install the same routes
+	// into for redundancy. Each of them is connected to, tracks the routes
+	// installed into it and reconnects independently of the others.
+	AdditionalUrlList []string `mapstructure:"additional-url-list" json:"additional-url-list,omitempty"`
+	// original -> gobgp:install-community
+	// Configure the communities marking the routes to be installed normally.
+	// Along with the backup community or one of reject-community, see
+	// community-flag-precedence.
+	InstallCommunityList []string `mapstructure:"install-community-list" json:"install-community-list,omitempty"`
+	// original -> gobgp:community-flag-precedence
+	// Configure which of the communities asking for a route to be rejected
+	// and install-community takes precedence when a route carries both. The
+	// reject ones do by default.
+	CommunityFlagPrecedence ZebraCommunityFlagPrecedence `mapstructure:"community-flag-precedence" json:"community-flag-precedence,omitempty"`
+	// original -> gobgp:metrics-enabled
+	// gobgp:metrics-enabled's original type is boolean.
+	// Configure exporting the metrics of the zebra client, such as the
+	// connection state and the number of messages exchanged, in the
+	// Prometheus text format. gobgpd serves them at /metrics on the host
+	// given by --metrics-host.
+	MetricsEnabled bool `mapstructure:"metrics-enabled" json:"metrics-enabled,omitempty"`
+	// original -> gobgp:dump-batch-size
+	// Configure the maximum number of paths sent to zebra at once when the
+	// paths of the VRF tables are dumped after connecting. The next batch is
+	// fetched from the RIB once the client has processed the previous one,
+	// which bounds the memory used to dump large RIBs. Defaults to 1000.
+	// Zero means dumping every table at once.
+	DumpBatchSize uint32 `mapstructure:"dump-batch-size" json:"dump-batch-size,omitempty"`
+	// original -> gobgp:dump-batch-interval
+	// Configure the time in milliseconds to wait between two batches of
+	// paths dumped to zebra, see dump-batch-size. Defaults to 10.
+	DumpBatchInterval uint32 `mapstructure:"dump-batch-interval" json:"dump-batch-interval,omitempty"`
+	// original -> gobgp:nil-source-action
+	// Configure the action taken on the paths without source, which come
+	// neither from a peer nor from zebra. Defaults to LOCAL.
+	NilSourceAction ZebraNilSourceAction `mapstructure:"nil-source-action" json:"nil-source-action,omitempty"`
+	// original -> gobgp:nexthop-self
+	// gobgp:nexthop-self's original type is boolean.
+	// Configure whether the nexthops of the routes installed into zebra are
+	// rewritten to the local address of the BGP session over which their
+	// path has been received, or to the router id for IPv4 routes if the
+	// session address is of another family, so that the kernel resolves them
+	// recursively. The routes of multiple paths then have a single nexthop.
+	// The routes without such an address keep their nexthops.
+	NexthopSelf bool `mapstructure:"nexthop-self" json:"nexthop-self,omitempty"`
+	// original -> gobgp:nexthop-trigger-ignore-zone
+	// gobgp:nexthop-trigger-ignore-zone's original type is boolean.
+	// Configure whether link-local nexthops are tracked by their address
+	// only. By default, the same link-local nexthop reached through
+	// different interfaces is tracked once per interface.
+	NexthopTriggerIgnoreZone bool `mapstructure:"nexthop-trigger-ignore-zone" json:"nexthop-trigger-ignore-zone,omitempty"`
+	// original -> gobgp:cross-vrf-nexthop-action
+	// Configure the action taken on the routes whose nexthop belongs to the
+	// prefix of an interface, known from zebra, of a VRF other than the one
+	// they are installed into, e.g. when leaking routes between VRFs.
+	// Defaults to NONE.
+	CrossVrfNexthopAction ZebraCrossVrfNexthopAction `mapstructure:"cross-vrf-nexthop-action" json:"cross-vrf-nexthop-action,omitempty"`
+	// original -> gobgp:redistribute-all-vrfs
+	// gobgp:redistribute-all-vrfs's original type is boolean.
+	// Configure whether the routes of the protocols of
+	// redistribute-route-type-list are redistributed to GoBGP from every VRF
+	// it knows, including the VRFs added later, rather than only from the
+	// default VRF.
+	RedistributeAllVrfs bool `mapstructure:"redistribute-all-vrfs" json:"redistribute-all-vrfs,omitempty"`
 }
 
 func (lhs *ZebraConfig) Equal(rhs *ZebraConfig) bool {
@@ -1173,6 +2405,288 @@ func (lhs *ZebraConfig) Equal(rhs *ZebraConfig) bool {
 	if lhs.NexthopTriggerDelay != rhs.NexthopTriggerDelay {
 		return false
 	}
+	if len(lhs.InstallTableIdList) != len(rhs.InstallTableIdList) {
+		return false
+	}
+	for idx, l := range lhs.InstallTableIdList {
+		if l != rhs.InstallTableIdList[idx] {
+			return false
+		}
+	}
+	if lhs.AspathErrorAction != rhs.AspathErrorAction {
+		return false
+	}
+	if lhs.NexthopTriggerMaxDelay != rhs.NexthopTriggerMaxDelay {
+		return false
+	}
+	if lhs.WriteBufferSize != rhs.WriteBufferSize {
+		return false
+	}
+	if lhs.WriteFlushInterval != rhs.WriteFlushInterval {
+		return false
+	}
+	if lhs.NexthopTriggerMaxCoalesceAge != rhs.NexthopTriggerMaxCoalesceAge {
+		return false
+	}
+	if lhs.VrfResolveMode != rhs.VrfResolveMode {
+		return false
+	}
+	if lhs.NilNexthopAction != rhs.NilNexthopAction {
+		return false
+	}
+	if len(lhs.NeighborDistanceList) != len(rhs.NeighborDistanceList) {
+		return false
+	}
+	{
+		lmap := make(map[string]*NeighborDistance)
+		for i, l := range lhs.NeighborDistanceList {
+			lmap[mapkey(i, string(l.Address))] = &lhs.NeighborDistanceList[i]
+		}
+		for i, r := range rhs.NeighborDistanceList {
+			if l, y := lmap[mapkey(i, string(r.Address))]; !y {
+				return false
+			} else if !r.Equal(l) {
+				return false
+			}
+		}
+	}
+	if lhs.WatchChannelSize != rhs.WatchChannelSize {
+		return false
+	}
+	if lhs.MaxMetric != rhs.MaxMetric {
+		return false
+	}
+	if lhs.SendEmptyAspath != rhs.SendEmptyAspath {
+		return false
+	}
+	if lhs.PanicAction != rhs.PanicAction {
+		return false
+	}
+	if lhs.NexthopTriggerSkipDefaultRoute != rhs.NexthopTriggerSkipDefaultRoute {
+		return false
+	}
+	if len(lhs.DefaultRouteNormalAfiSafiList) != len(rhs.DefaultRouteNormalAfiSafiList) {
+		return false
+	}
+	for idx, l := range lhs.DefaultRouteNormalAfiSafiList {
+		if l != rhs.DefaultRouteNormalAfiSafiList[idx] {
+			return false
+		}
+	}
+	if lhs.ReconnectInterval != rhs.ReconnectInterval {
+		return false
+	}
+	if lhs.AuxEncoding != rhs.AuxEncoding {
+		return false
+	}
+	if len(lhs.AsTranslationList) != len(rhs.AsTranslationList) {
+		return false
+	}
+	{
+		lmap := make(map[string]*AsTranslation)
+		for i, l := range lhs.AsTranslationList {
+			lmap[mapkey(i, fmt.Sprintf("%d", l.SourceAs))] = &lhs.AsTranslationList[i]
+		}
+		for i, r := range rhs.AsTranslationList {
+			if l, y := lmap[mapkey(i, fmt.Sprintf("%d", r.SourceAs))]; !y {
+				return false
+			} else if !r.Equal(l) {
+				return false
+			}
+		}
+	}
+	if lhs.NexthopTriggerMinDelay != rhs.NexthopTriggerMinDelay {
+		return false
+	}
+	if len(lhs.NexthopTriggerBypassCommunityList) != len(rhs.NexthopTriggerBypassCommunityList) {
+		return false
+	}
+	for idx, l := range lhs.NexthopTriggerBypassCommunityList {
+		if l != rhs.NexthopTriggerBypassCommunityList[idx] {
+			return false
+		}
+	}
+	if lhs.InstallStateFile != rhs.InstallStateFile {
+		return false
+	}
+	if lhs.GracefulRestartReconcile != rhs.GracefulRestartReconcile {
+		return false
+	}
+	if lhs.GracefulRestartReconcileTimeout != rhs.GracefulRestartReconcileTimeout {
+		return false
+	}
+	if lhs.LabelRewriteAction != rhs.LabelRewriteAction {
+		return false
+	}
+	if lhs.LabelRewriteLabel != rhs.LabelRewriteLabel {
+		return false
+	}
+	if lhs.InterfaceSyncTimeout != rhs.InterfaceSyncTimeout {
+		return false
+	}
+	if lhs.NexthopMetricPrecedence != rhs.NexthopMetricPrecedence {
+		return false
+	}
+	if lhs.NexthopTriggerPenaltyCharge != rhs.NexthopTriggerPenaltyCharge {
+		return false
+	}
+	if lhs.NexthopTriggerSuppressThreshold != rhs.NexthopTriggerSuppressThreshold {
+		return false
+	}
+	if lhs.NexthopTriggerDampingDelay != rhs.NexthopTriggerDampingDelay {
+		return false
+	}
+	if lhs.NexthopTriggerDecayInterval != rhs.NexthopTriggerDecayInterval {
+		return false
+	}
+	if lhs.ImportNilNexthopAction != rhs.ImportNilNexthopAction {
+		return false
+	}
+	if lhs.SourceNeighborAddress != rhs.SourceNeighborAddress {
+		return false
+	}
+	if lhs.ReconnectMaxInterval != rhs.ReconnectMaxInterval {
+		return false
+	}
+	if lhs.ImportDedup != rhs.ImportDedup {
+		return false
+	}
+	if lhs.EbgpDistance != rhs.EbgpDistance {
+		return false
+	}
+	if lhs.IbgpDistance != rhs.IbgpDistance {
+		return false
+	}
+	if lhs.InvalidateOnInterfaceDown != rhs.InvalidateOnInterfaceDown {
+		return false
+	}
+	if lhs.Tag != rhs.Tag {
+		return false
+	}
+	if len(lhs.CommunityTagList) != len(rhs.CommunityTagList) {
+		return false
+	}
+	{
+		lmap := make(map[string]*CommunityTag)
+		for i, l := range lhs.CommunityTagList {
+			lmap[mapkey(i, string(l.Community))] = &lhs.CommunityTagList[i]
+		}
+		for i, r := range rhs.CommunityTagList {
+			if l, y := lmap[mapkey(i, string(r.Community))]; !y {
+				return false
+			} else if !r.Equal(l) {
+				return false
+			}
+		}
+	}
+	if lhs.NexthopRegisterRetryInterval != rhs.NexthopRegisterRetryInterval {
+		return false
+	}
+	if len(lhs.BlackholeCommunityList) != len(rhs.BlackholeCommunityList) {
+		return false
+	}
+	for idx, l := range lhs.BlackholeCommunityList {
+		if l != rhs.BlackholeCommunityList[idx] {
+			return false
+		}
+	}
+	if len(lhs.RejectCommunityList) != len(rhs.RejectCommunityList) {
+		return false
+	}
+	for idx, l := range lhs.RejectCommunityList {
+		if l != rhs.RejectCommunityList[idx] {
+			return false
+		}
+	}
+	if lhs.AspathAsSetAction != rhs.AspathAsSetAction {
+		return false
+	}
+	if lhs.WithdrawOnShutdown != rhs.WithdrawOnShutdown {
+		return false
+	}
+	if lhs.ShutdownDrainTimeout != rhs.ShutdownDrainTimeout {
+		return false
+	}
+	if len(lhs.ImportFamilyList) != len(rhs.ImportFamilyList) {
+		return false
+	}
+	for idx, l := range lhs.ImportFamilyList {
+		if l != rhs.ImportFamilyList[idx] {
+			return false
+		}
+	}
+	if lhs.Mtu != rhs.Mtu {
+		return false
+	}
+	if lhs.MaxMetricUnreachable != rhs.MaxMetricUnreachable {
+		return false
+	}
+	if lhs.NexthopLabelResolution != rhs.NexthopLabelResolution {
+		return false
+	}
+	if len(lhs.SelfRouteWithdrawNexthopList) != len(rhs.SelfRouteWithdrawNexthopList) {
+		return false
+	}
+	for idx, l := range lhs.SelfRouteWithdrawNexthopList {
+		if l != rhs.SelfRouteWithdrawNexthopList[idx] {
+			return false
+		}
+	}
+	if lhs.SelfRouteWithdrawBlackhole != rhs.SelfRouteWithdrawBlackhole {
+		return false
+	}
+	if lhs.InvalidNexthopAction != rhs.InvalidNexthopAction {
+		return false
+	}
+	if lhs.UnsupportedFamilyAction != rhs.UnsupportedFamilyAction {
+		return false
+	}
+	if lhs.NexthopTriggerWithdrawPenaltyCharge != rhs.NexthopTriggerWithdrawPenaltyCharge {
+		return false
+	}
+	if len(lhs.AdditionalUrlList) != len(rhs.AdditionalUrlList) {
+		return false
+	}
+	for idx, l := range lhs.AdditionalUrlList {
+		if l != rhs.AdditionalUrlList[idx] {
+			return false
+		}
+	}
+	if len(lhs.InstallCommunityList) != len(rhs.InstallCommunityList) {
+		return false
+	}
+	for idx, l := range lhs.InstallCommunityList {
+		if l != rhs.InstallCommunityList[idx] {
+			return false
+		}
+	}
+	if lhs.CommunityFlagPrecedence != rhs.CommunityFlagPrecedence {
+		return false
+	}
+	if lhs.MetricsEnabled != rhs.MetricsEnabled {
+		return false
+	}
+	if lhs.DumpBatchSize != rhs.DumpBatchSize {
+		return false
+	}
+	if lhs.DumpBatchInterval != rhs.DumpBatchInterval {
+		return false
+	}
+	if lhs.NilSourceAction != rhs.NilSourceAction {
+		return false
+	}
+	if lhs.NexthopSelf != rhs.NexthopSelf {
+		return false
+	}
+	if lhs.NexthopTriggerIgnoreZone != rhs.NexthopTriggerIgnoreZone {
+		return false
+	}
+	if lhs.CrossVrfNexthopAction != rhs.CrossVrfNexthopAction {
+		return false
+	}
+	if lhs.RedistributeAllVrfs != rhs.RedistributeAllVrfs {
+		return false
+	}
 	return true
 }
 
@@ -388,8 +388,8 @@ func setDefaultConfigValuesWithViper(v *viper.Viper, b *BgpConfigSet) error {
 	}
 	if b.Zebra.Config.Version < 2 {
 		b.Zebra.Config.Version = 2
-	} else if b.Zebra.Config.Version > 4 {
-		b.Zebra.Config.Version = 4
+	} else if b.Zebra.Config.Version > 6 {
+		b.Zebra.Config.Version = 6
 	}
 	if !v.IsSet("zebra.config.nexthop-trigger-enable") && !b.Zebra.Config.NexthopTriggerEnable && b.Zebra.Config.Version > 2 {
 		b.Zebra.Config.NexthopTriggerEnable = true
@@ -397,6 +397,12 @@ func setDefaultConfigValuesWithViper(v *viper.Viper, b *BgpConfigSet) error {
 	if b.Zebra.Config.NexthopTriggerDelay == 0 {
 		b.Zebra.Config.NexthopTriggerDelay = 5
 	}
+	if !v.IsSet("zebra.config.dump-batch-size") {
+		b.Zebra.Config.DumpBatchSize = 1000
+	}
+	if !v.IsSet("zebra.config.dump-batch-interval") && b.Zebra.Config.DumpBatchSize > 0 {
+		b.Zebra.Config.DumpBatchInterval = 10
+	}
 
 	list, err := extractArray(v.Get("neighbors"))
 	if err != nil {
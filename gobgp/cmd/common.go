@@ -80,6 +80,8 @@ const (
 	CMD_LARGECOMMUNITY = "large-community"
 	CMD_SUMMARY        = "summary"
 	CMD_VALIDATION     = "validation"
+	CMD_ZEBRA          = "zebra"
+	CMD_NEXTHOP        = "nexthop"
 )
 
 const (
@@ -0,0 +1,75 @@
+// Copyright (C) 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func showZebraNexthops() error {
+	nexthops, err := client.GetZebraNexthops()
+	if err != nil {
+		return err
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(nexthops)
+		fmt.Println(string(j))
+		return nil
+	}
+	if globalOpts.Quiet {
+		for _, n := range nexthops {
+			fmt.Println(n.Address)
+		}
+		return nil
+	}
+	format := "%-6s %-39s %-16s %-10s %s\n"
+	fmt.Printf(format, "Family", "Address", "Zone", "Registered", "VRFs")
+	for _, n := range nexthops {
+		vrfs := make([]string, 0, len(n.VrfIds))
+		for _, id := range n.VrfIds {
+			vrfs = append(vrfs, fmt.Sprint(id))
+		}
+		registered := "no"
+		if n.Registered {
+			registered = "yes"
+		}
+		fmt.Printf(format, n.Family, n.Address, n.Zone, registered, strings.Join(vrfs, ","))
+	}
+	return nil
+}
+
+func NewZebraCmd() *cobra.Command {
+
+	zebraCmd := &cobra.Command{
+		Use: CMD_ZEBRA,
+	}
+
+	nexthopCmd := &cobra.Command{
+		Use: CMD_NEXTHOP,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showZebraNexthops(); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+
+	zebraCmd.AddCommand(nexthopCmd)
+	return zebraCmd
+}
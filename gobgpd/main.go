@@ -29,6 +29,7 @@ import (
 	"github.com/jessevdk/go-flags"
 	"github.com/kr/pretty"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
@@ -59,6 +60,7 @@ func main() {
 		Dry             bool   `short:"d" long:"dry-run" description:"check configuration"`
 		PProfHost       string `long:"pprof-host" description:"specify the host that gobgpd listens on for pprof" default:"localhost:6060"`
 		PProfDisable    bool   `long:"pprof-disable" description:"disable pprof profiling"`
+		MetricsHost     string `long:"metrics-host" description:"specify the host that gobgpd listens on for metrics, disabled if empty"`
 		TLS             bool   `long:"tls" description:"enable TLS authentication for gRPC API"`
 		TLSCertFile     string `long:"tls-cert-file" description:"The TLS cert file"`
 		TLSKeyFile      string `long:"tls-key-file" description:"The TLS key file"`
@@ -134,7 +136,20 @@ func main() {
 	log.Info("gobgpd started")
 	bgpServer := server.NewBgpServer()
 	go bgpServer.Serve()
-
+	// the metrics are served on their own listener, whether pprof is
+	// enabled or not.
+	if opts.MetricsHost != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+			if err := bgpServer.WriteZebraMetrics(w); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+			}
+		})
+		go func() {
+			log.Println(http.ListenAndServe(opts.MetricsHost, mux))
+		}()
+	}
 	var grpcOpts []grpc.ServerOption
 	if opts.TLS {
 		creds, err := credentials.NewServerTLSFromFile(opts.TLSCertFile, opts.TLSKeyFile)
@@ -169,7 +184,7 @@ func main() {
 					log.Fatalf("failed to set global config: %s", err)
 				}
 				if newConfig.Zebra.Config.Enabled {
-					if err := bgpServer.StartZebraClient(&newConfig.Zebra.Config); err != nil {
+					if err := bgpServer.StartZebraClient(context.Background(), &newConfig.Zebra.Config); err != nil {
 						log.Fatalf("failed to set zebra config: %s", err)
 					}
 				}
@@ -241,6 +256,12 @@ func main() {
 				added, deleted, updated = config.UpdateNeighborConfig(c, newConfig)
 				updatePolicy = config.CheckPolicyDifference(config.ConfigSetToRoutingPolicy(c), config.ConfigSetToRoutingPolicy(newConfig))
 
+				if newConfig.Global.Config.RouterId != c.Global.Config.RouterId {
+					if err := bgpServer.UpdateRouterId(newConfig.Global.Config.RouterId); err != nil {
+						log.Warn(err)
+					}
+				}
+
 				if updatePolicy {
 					log.Info("Policy config is updated")
 					p := config.ConfigSetToRoutingPolicy(newConfig)
@@ -280,6 +301,12 @@ func main() {
 					updatePolicy = true
 
 				}
+				// zebra config update, used when reconnecting to zebra
+				if newConfig.Zebra.Config.Enabled && !newConfig.Zebra.Config.Equal(&c.Zebra.Config) {
+					if err := bgpServer.UpdateZebraConfig(&newConfig.Zebra.Config); err != nil {
+						log.Warn(err)
+					}
+				}
 				c = newConfig
 			}
 			for i, pg := range addedPg {
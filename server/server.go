@@ -18,14 +18,17 @@ package server
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/eapache/channels"
 	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 
 	"github.com/osrg/gobgp/config"
 	"github.com/osrg/gobgp/packet/bgp"
@@ -119,9 +122,15 @@ type BgpServer struct {
 	shutdown     bool
 	watcherMap   map[WatchEventType][]*Watcher
 	zclient      *zebraClient
+	zebraConfig  *config.ZebraConfig
 	bmpManager   *bmpClientManager
 	mrtManager   *mrtManager
 	uuidMap      map[uuid.UUID]string
+
+	// zebraEndpoints maps the URL of every additional Zebra instance (see
+	// AdditionalUrlList in the zebra config) to the client connected to it.
+	zebraEndpoints map[string]*zebraClient
+	zebraMetrics   *zebraMetrics
 }
 
 func NewBgpServer() *BgpServer {
@@ -599,13 +608,13 @@ func (server *BgpServer) notifyBestWatcher(best []*table.Path, multipath [][]*ta
 		clonedM[i] = clonePathList(pathList)
 	}
 	clonedB := clonePathList(best)
-	m := make(map[string]uint16)
+	m := make(map[string]uint32)
 	for _, p := range clonedB {
 		switch p.GetRouteFamily() {
 		case bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN:
 			for _, vrf := range server.globalRib.Vrfs {
 				if vrf.Id != 0 && table.CanImportToVrf(vrf, p) {
-					m[p.GetNlri().String()] = uint16(vrf.Id)
+					m[p.GetNlri().String()] = vrf.Id
 				}
 			}
 		}
@@ -883,6 +892,12 @@ func (server *BgpServer) propagateUpdate(peer *Peer, pathList []*table.Path) {
 		}
 
 		if p := server.policy.ApplyPolicy(tableId, table.POLICY_DIRECTION_IMPORT, path, policyOptions); p != nil {
+			if path.IsNexthopMetric {
+				// restore the IGP metric possibly overridden by the policy.
+				if med, ok, _ := getMed(path); ok {
+					p.SetMed(int64(med), true)
+				}
+			}
 			path = p
 		} else {
 			path = path.Clone(true)
@@ -1221,6 +1236,7 @@ func (server *BgpServer) handleFSMMessage(peer *Peer, e *FsmMsg) {
 					log.WithFields(log.Fields{
 						"Topic": "Server",
 					}).Info("sync finished")
+					server.zebraRestartFinished()
 				} else {
 					deferral := peer.fsm.pConf.GracefulRestart.Config.DeferralTime
 					log.WithFields(log.Fields{
@@ -1328,7 +1344,7 @@ func (server *BgpServer) handleFSMMessage(peer *Peer, e *FsmMsg) {
 						log.WithFields(log.Fields{
 							"Topic": "Server",
 						}).Info("sync finished")
-
+						server.zebraRestartFinished()
 					}
 
 					// we don't delay non-route-target NLRIs when local-restarting
@@ -1385,21 +1401,110 @@ func (s *BgpServer) StartCollector(c *config.CollectorConfig) error {
 	}, false)
 }
 
-func (s *BgpServer) StartZebraClient(c *config.ZebraConfig) error {
+// StartZebraClient connects to Zebra, and to the additional instances
+// listed in AdditionalUrlList. The clients run until ctx is cancelled,
+// each reconnecting on its own if its connection is lost.
+func (s *BgpServer) StartZebraClient(ctx context.Context, c *config.ZebraConfig) error {
 	return s.mgmtOperation(func() error {
 		if s.zclient != nil {
 			return fmt.Errorf("already connected to Zebra")
 		}
-		protos := make([]string, 0, len(c.RedistributeRouteTypeList))
-		for _, p := range c.RedistributeRouteTypeList {
-			protos = append(protos, string(p))
+		if err := s.startZebraClient(ctx, c, ""); err != nil {
+			return err
 		}
-		var err error
-		s.zclient, err = newZebraClient(s, c.Url, protos, c.Version, c.NexthopTriggerEnable, c.NexthopTriggerDelay)
-		if s.zclient != nil {
-			s.zclient.config = *c
+		for _, url := range c.AdditionalUrlList {
+			if err := s.startZebraClient(ctx, c, url); err != nil {
+				for _, z := range s.zebraClients() {
+					z.stop()
+				}
+				s.zclient = nil
+				s.zebraEndpoints = nil
+				return err
+			}
+		}
+		return nil
+	}, false)
+}
+
+// startZebraClient connects to the additional Zebra instance of the given
+// URL, or to the one of c if it is empty. It must be called by the server
+// goroutine.
+func (s *BgpServer) startZebraClient(ctx context.Context, c *config.ZebraConfig, endpoint string) error {
+	if endpoint == "" {
+		z, err := newZebraClient(ctx, s, c)
+		if err != nil {
+			return err
 		}
+		s.zclient = z
+		zc := *c
+		s.zebraConfig = &zc
+		return nil
+	}
+	if _, ok := s.zebraEndpoints[endpoint]; ok {
+		return fmt.Errorf("already connected to Zebra at %s", endpoint)
+	}
+	z, err := newZebraEndpointClient(ctx, s, c, endpoint)
+	if err != nil {
 		return err
+	}
+	if s.zebraEndpoints == nil {
+		s.zebraEndpoints = make(map[string]*zebraClient)
+	}
+	s.zebraEndpoints[endpoint] = z
+	return nil
+}
+
+// removeZebraClient forgets z once it has lost its connection to Zebra,
+// unless another client has already replaced it. It must be called by the
+// server goroutine.
+func (s *BgpServer) removeZebraClient(z *zebraClient) {
+	if z.endpoint == "" {
+		if s.zclient == z {
+			s.zclient = nil
+		}
+	} else if s.zebraEndpoints[z.endpoint] == z {
+		delete(s.zebraEndpoints, z.endpoint)
+	}
+}
+
+// zebraRestartFinished tells the zebra clients that the graceful restart
+// of GoBGP has finished once no neighbor is restarting locally anymore.
+func (s *BgpServer) zebraRestartFinished() {
+	for _, p := range s.neighborMap {
+		if p.fsm.pConf.GracefulRestart.State.LocalRestarting {
+			return
+		}
+	}
+	for _, z := range s.zebraClients() {
+		z.restartFinished()
+	}
+}
+
+// zebraClients returns the clients connected to Zebra, the one of the
+// configured URL first and then those of the additional instances.
+func (s *BgpServer) zebraClients() []*zebraClient {
+	l := make([]*zebraClient, 0, 1+len(s.zebraEndpoints))
+	if s.zclient != nil {
+		l = append(l, s.zclient)
+	}
+	urls := make([]string, 0, len(s.zebraEndpoints))
+	for url := range s.zebraEndpoints {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		l = append(l, s.zebraEndpoints[url])
+	}
+	return l
+}
+
+// UpdateZebraConfig replaces the config the zebra client uses the next
+// time it reconnects to Zebra. The current connection is left as is.
+func (s *BgpServer) UpdateZebraConfig(c *config.ZebraConfig) error {
+	return s.mgmtOperation(func() error {
+		zc := *c
+		s.zebraConfig = &zc
+		return nil
 	}, false)
 }
 
@@ -1689,6 +1794,30 @@ func (s *BgpServer) Start(c *config.Global) error {
 	}, false)
 }
 
+// UpdateRouterId changes the router-id of the global config and notifies
+// the zebra clients. The sessions already established keep the router-id
+// they were opened with until they are reset.
+func (s *BgpServer) UpdateRouterId(routerId string) error {
+	return s.mgmtOperation(func() error {
+		if ip := net.ParseIP(routerId); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid router-id: %s", routerId)
+		}
+		if s.bgpConfig.Global.Config.RouterId == routerId {
+			return nil
+		}
+		log.WithFields(log.Fields{
+			"Topic": "Server",
+			"Old":   s.bgpConfig.Global.Config.RouterId,
+			"New":   routerId,
+		}).Info("router-id changed")
+		s.bgpConfig.Global.Config.RouterId = routerId
+		for _, z := range s.zebraClients() {
+			z.routerIdChanged(routerId)
+		}
+		return nil
+	}, true)
+}
+
 func (s *BgpServer) GetVrf() (l []*table.Vrf) {
 	s.mgmtOperation(func() error {
 		l = make([]*table.Vrf, 0, len(s.globalRib.Vrfs))
@@ -1700,6 +1829,102 @@ func (s *BgpServer) GetVrf() (l []*table.Vrf) {
 	return l
 }
 
+// GetZebraStats returns the counters of the messages exchanged with Zebra.
+func (s *BgpServer) GetZebraStats() (stats ZebraClientStats, err error) {
+	err = s.mgmtOperation(func() error {
+		if s.zclient == nil {
+			return fmt.Errorf("zebra client is not running")
+		}
+		stats = s.zclient.Stats()
+		return nil
+	}, true)
+	return stats, err
+}
+
+// AddZebraRedistribute makes Zebra redistribute the routes of the given
+// protocol, as named in RedistributeRouteTypeList, to the zebra clients,
+// which keep redistributing them when reconnecting.
+func (s *BgpServer) AddZebraRedistribute(proto string) error {
+	return s.mgmtOperation(func() error {
+		l := s.zebraClients()
+		if len(l) == 0 {
+			return fmt.Errorf("zebra client is not running")
+		}
+		if err := setZebraRedistribute(l, proto, false); err != nil {
+			return err
+		}
+		if s.zebraConfig != nil {
+			for _, typ := range s.zebraConfig.RedistributeRouteTypeList {
+				if string(typ) == proto {
+					return nil
+				}
+			}
+			types := make([]config.InstallProtocolType, 0, len(s.zebraConfig.RedistributeRouteTypeList)+1)
+			types = append(types, s.zebraConfig.RedistributeRouteTypeList...)
+			s.zebraConfig.RedistributeRouteTypeList = append(types, config.InstallProtocolType(proto))
+		}
+		return nil
+	}, true)
+}
+
+// DeleteZebraRedistribute makes Zebra stop redistributing the routes of
+// the given protocol to the zebra clients. A protocol redistributed
+// because of "all" is redistributed again when reconnecting.
+func (s *BgpServer) DeleteZebraRedistribute(proto string) error {
+	return s.mgmtOperation(func() error {
+		l := s.zebraClients()
+		if len(l) == 0 {
+			return fmt.Errorf("zebra client is not running")
+		}
+		if err := setZebraRedistribute(l, proto, true); err != nil {
+			return err
+		}
+		if s.zebraConfig != nil {
+			types := make([]config.InstallProtocolType, 0, len(s.zebraConfig.RedistributeRouteTypeList))
+			for _, typ := range s.zebraConfig.RedistributeRouteTypeList {
+				if string(typ) != proto {
+					types = append(types, typ)
+				}
+			}
+			s.zebraConfig.RedistributeRouteTypeList = types
+		}
+		return nil
+	}, true)
+}
+
+// GetZebraNexthops returns the nexthops the zebra client tracks, see
+// ZebraNexthop.
+func (s *BgpServer) GetZebraNexthops() ([]ZebraNexthop, error) {
+	var z *zebraClient
+	if err := s.mgmtOperation(func() error {
+		if s.zclient == nil {
+			return fmt.Errorf("zebra client is not running")
+		}
+		z = s.zclient
+		return nil
+	}, true); err != nil {
+		return nil, err
+	}
+	return z.Nexthops()
+}
+
+// WriteZebraMetrics writes the metrics of the zebra client in the
+// Prometheus text format. It fails unless MetricsEnabled is set in the
+// zebra config.
+func (s *BgpServer) WriteZebraMetrics(w io.Writer) error {
+	var m *zebraMetrics
+	if err := s.mgmtOperation(func() error {
+		m = s.zebraMetrics
+		return nil
+	}, true); err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("zebra metrics are not enabled")
+	}
+	return m.write(w)
+}
+
 func (s *BgpServer) AddVrf(name string, id uint32, rd bgp.RouteDistinguisherInterface, im, ex []bgp.ExtendedCommunityInterface) error {
 	return s.mgmtOperation(func() error {
 		pi := &table.PeerInfo{
@@ -1713,19 +1938,10 @@ func (s *BgpServer) AddVrf(name string, id uint32, rd bgp.RouteDistinguisherInte
 		if len(pathList) > 0 {
 			s.propagateUpdate(nil, pathList)
 		}
-		tbl, _ := s.globalRib.FetchExistingVrf(name)
-		if s.zclient != nil {
-			s.zclient.SendVrfRegister(id)
-		}
-		if s.zclient != nil && tbl != nil {
-			for _, dst := range tbl.GetDestinations() {
-				paths := dst.GetAllKnownPathList()
-				m := make(map[string]uint16)
-				for _, p := range paths {
-					m[p.GetNlri().String()] = uint16(id)
-				}
-				s.zclient.SendPaths(paths, m)
-			}
+		for _, z := range s.zebraClients() {
+			z.SendVrfRegister(id)
+			z.redistributeVrf(id, false)
+			z.vrfAdded(name, id, rd)
 		}
 		return nil
 	}, true)
@@ -1739,18 +1955,23 @@ func (s *BgpServer) DeleteVrf(name string) error {
 			}
 		}
 		tbl, id := s.globalRib.FetchExistingVrf(name)
-		if s.zclient != nil {
-			s.zclient.SendVrfUnregister(id)
+		zclients := s.zebraClients()
+		for _, z := range zclients {
+			z.redistributeVrf(id, true)
+			z.SendVrfUnregister(id)
+			z.vrfDeleted(name)
 		}
-		if s.zclient != nil && tbl != nil {
+		if len(zclients) > 0 && tbl != nil {
 			for _, dst := range tbl.GetDestinations() {
 				paths := dst.GetAllKnownPathList()
-				m := make(map[string]uint16)
+				m := make(map[string]uint32)
 				for _, p := range paths {
 					p.IsWithdraw = true
-					m[p.GetNlri().String()] = uint16(id)
+					m[p.GetNlri().String()] = id
+				}
+				for _, z := range zclients {
+					z.queuePaths(paths, m)
 				}
-				s.zclient.SendPaths(paths, m)
 			}
 		}
 		pathList, err := s.globalRib.DeleteVrf(name)
@@ -1848,6 +2069,7 @@ func (s *BgpServer) softResetOut(addr string, family bgp.RouteFamily, deferral b
 					"Key":      peer.ID(),
 					"Families": families,
 				}).Debug("deferral timer expired")
+				s.zebraRestartFinished()
 			} else if c := peer.fsm.pConf.GetAfiSafi(bgp.RF_RTC_UC); y && !c.MpGracefulRestart.State.EndOfRibReceived {
 				log.WithFields(log.Fields{
 					"Topic":    "Peer",
@@ -2692,6 +2914,8 @@ const (
 	WATCH_EVENT_TYPE_PEER_STATE  WatchEventType = "peerstate"
 	WATCH_EVENT_TYPE_TABLE       WatchEventType = "table"
 	WATCH_EVENT_TYPE_RECV_MSG    WatchEventType = "receivedmessage"
+	WATCH_EVENT_TYPE_ZEBRA_SYNC  WatchEventType = "zebrasync"
+	WATCH_EVENT_TYPE_ZEBRA_STATE WatchEventType = "zebrastate"
 )
 
 type WatchEvent interface {
@@ -2711,7 +2935,7 @@ type WatchEventUpdate struct {
 	Init         bool
 	PathList     []*table.Path
 	Neighbor     *config.Neighbor
-	Vrf          map[string]uint16
+	Vrf          map[string]uint32
 }
 
 type WatchEventPeerState struct {
@@ -2743,7 +2967,7 @@ type WatchEventTable struct {
 type WatchEventBestPath struct {
 	PathList      []*table.Path
 	MultiPathList [][]*table.Path
-	Vrf           map[string]uint16
+	Vrf           map[string]uint32
 }
 
 type WatchEventMessage struct {
@@ -2758,6 +2982,32 @@ type WatchEventMessage struct {
 	IsSent       bool
 }
 
+// WatchEventZebraSync is notified once the zebra client has finished
+// dumping the VRF tables to Zebra after (re)connecting.
+type WatchEventZebraSync struct {
+	NumVrf    int
+	NumPath   int
+	Timestamp time.Time
+	// LocalRestarting is set if a neighbor was still waiting for the
+	// graceful restart of GoBGP to finish.
+	LocalRestarting bool
+}
+
+type ZebraState string
+
+const (
+	ZEBRA_STATE_UP           ZebraState = "up"
+	ZEBRA_STATE_DOWN         ZebraState = "down"
+	ZEBRA_STATE_RECONNECTING ZebraState = "reconnecting"
+)
+
+// WatchEventZebraState is notified when the connection to zebra is lost,
+// before each attempt to reconnect and once reconnected.
+type WatchEventZebraState struct {
+	State     ZebraState
+	Timestamp time.Time
+}
+
 type watchOptions struct {
 	bestpath       bool
 	preUpdate      bool
@@ -2770,6 +3020,9 @@ type watchOptions struct {
 	tableName      string
 	recvMessage    bool
 	sentMessage    bool
+	zebraSync      bool
+	zebraState     bool
+	channelSize    int
 }
 
 type WatchOption func(*watchOptions)
@@ -2829,6 +3082,27 @@ func WatchMessage(isSent bool) WatchOption {
 	}
 }
 
+func WatchZebraSync() WatchOption {
+	return func(o *watchOptions) {
+		o.zebraSync = true
+	}
+}
+
+func WatchZebraState() WatchOption {
+	return func(o *watchOptions) {
+		o.zebraState = true
+	}
+}
+
+// WatchChannelSize sets the capacity of the channel returned by
+// Watcher.Event(). Events are queued without bound inside the watcher, so
+// the capacity only matters for those who write to the channel directly.
+func WatchChannelSize(size int) WatchOption {
+	return func(o *watchOptions) {
+		o.channelSize = size
+	}
+}
+
 type Watcher struct {
 	opts   watchOptions
 	realCh chan WatchEvent
@@ -2939,14 +3213,18 @@ func (s *BgpServer) notifyWatcher(typ WatchEventType, ev WatchEvent) {
 func (s *BgpServer) Watch(opts ...WatchOption) (w *Watcher) {
 	s.mgmtOperation(func() error {
 		w = &Watcher{
-			s:      s,
-			realCh: make(chan WatchEvent, 8),
-			ch:     channels.NewInfiniteChannel(),
+			s:  s,
+			ch: channels.NewInfiniteChannel(),
 		}
 
 		for _, opt := range opts {
 			opt(&w.opts)
 		}
+		size := 8
+		if w.opts.channelSize > 0 {
+			size = w.opts.channelSize
+		}
+		w.realCh = make(chan WatchEvent, size)
 
 		register := func(t WatchEventType, w *Watcher) {
 			s.watcherMap[t] = append(s.watcherMap[t], w)
@@ -3064,6 +3342,12 @@ func (s *BgpServer) Watch(opts ...WatchOption) (w *Watcher) {
 		if w.opts.recvMessage {
 			register(WATCH_EVENT_TYPE_RECV_MSG, w)
 		}
+		if w.opts.zebraSync {
+			register(WATCH_EVENT_TYPE_ZEBRA_SYNC, w)
+		}
+		if w.opts.zebraState {
+			register(WATCH_EVENT_TYPE_ZEBRA_STATE, w)
+		}
 
 		go w.loop()
 		return nil
@@ -16,15 +16,26 @@
 package server
 
 import (
+	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"net"
+	"os"
+	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 
 	"github.com/osrg/gobgp/config"
 	"github.com/osrg/gobgp/packet/bgp"
@@ -34,54 +45,179 @@ import (
 
 type pathList []*table.Path
 
+// cachedNexthop is a nexthop registered to Zebra along with the VRFs it
+// has been registered into, so that it can be unregistered from them.
+type cachedNexthop struct {
+	family uint16
+	prefix net.IP
+	zone   string
+	vrfIds []uint32
+}
+
+// nexthopKey returns the key of the given nexthop in nexthopCache. The
+// family is part of it, so that an IPv4 nexthop and its IPv4-mapped IPv6
+// form, which net.IP prints the same way, are kept apart.
+func nexthopKey(family uint16, nexthop net.IP) string {
+	return fmt.Sprintf("%d:%s", family, nexthop)
+}
+
+// registeredNexthopKey returns the key of the given registered nexthop
+// in nexthopCache, which includes the zone of link-local nexthops unless
+// ignoreZone is set.
+func registeredNexthopKey(nexthop *zebra.RegisteredNexthop, ignoreZone bool) string {
+	key := nexthopKey(nexthop.Family, nexthop.Prefix)
+	if nexthop.Zone != "" && !ignoreZone {
+		key += "%" + nexthop.Zone
+	}
+	return key
+}
+
+// registeredNexthop returns the nexthop of path in the form it is
+// registered to Zebra, or nil if the family of path is not tracked. The
+// zone of a link-local IPv6 nexthop is the one of the neighbor the path
+// has been received from.
+func registeredNexthop(path *table.Path) *zebra.RegisteredNexthop {
+	nexthop := path.GetNexthop()
+	switch path.GetRouteFamily() {
+	case bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN:
+		return &zebra.RegisteredNexthop{
+			Family: syscall.AF_INET,
+			Prefix: nexthop.To4(),
+		}
+	case bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN:
+		nh := &zebra.RegisteredNexthop{
+			Family: syscall.AF_INET6,
+			Prefix: nexthop.To16(),
+		}
+		if info := path.GetSource(); info != nil && nexthop.IsLinkLocalUnicast() {
+			nh.Zone = info.Zone
+		}
+		return nh
+	}
+	return nil
+}
+
 type nexthopTrackingManager struct {
 	dead              chan struct{}
-	nexthopCache      map[string]struct{}
+	nexthopCache      map[string]*cachedNexthop
 	server            *BgpServer
 	delay             int
+	maxDelay          int
+	maxCoalesceAge    int
+	minDelay          int
+	penaltyCharge     int
+	suppressThreshold int
+	dampingDelay      int
+	decayInterval     time.Duration
+	skipDefaultRoute  bool
+	bypassCommunities map[uint32]struct{}
+	metricPrecedence  config.ZebraNexthopMetricPrecedence
 	isScheduled       bool
 	scheduledPathList map[string]pathList
 	trigger           chan struct{}
 	pathListCh        chan pathList
+	// withdrawPenaltyCharge is charged instead of penaltyCharge for the
+	// updates invalidating the nexthop, unless it is zero.
+	withdrawPenaltyCharge int
+	metrics               *zebraMetrics
+	// ignoreZone makes link-local nexthops cached by address only.
+	ignoreZone bool
+	// scheduledPathNum is the size of scheduledPathList, which is owned
+	// by loop(), for the other goroutines to read atomically.
+	scheduledPathNum int64
 }
 
-func newNexthopTrackingManager(server *BgpServer, delay int) *nexthopTrackingManager {
+func newNexthopTrackingManager(server *BgpServer, delay, maxDelay, maxCoalesceAge int) *nexthopTrackingManager {
 	return &nexthopTrackingManager{
 		dead:              make(chan struct{}),
-		nexthopCache:      make(map[string]struct{}),
+		nexthopCache:      make(map[string]*cachedNexthop),
 		server:            server,
 		delay:             delay,
+		maxDelay:          maxDelay,
+		maxCoalesceAge:    maxCoalesceAge,
+		penaltyCharge:     500,
+		suppressThreshold: 950,
+		dampingDelay:      8,
+		decayInterval:     8 * time.Second,
+		bypassCommunities: make(map[uint32]struct{}),
 		scheduledPathList: make(map[string]pathList, 0),
 		trigger:           make(chan struct{}),
 		pathListCh:        make(chan pathList),
 	}
 }
 
+// stop terminates loop(). Only dead is closed, so that senders racing
+// with it give up instead of panicking on a closed channel.
 func (m *nexthopTrackingManager) stop() {
-	fmt.Println("nexthopTrackingManager stop is called")
-	close(m.pathListCh)
-	close(m.trigger)
+	log.WithFields(log.Fields{
+		"Topic": "Zebra",
+		"Event": "Nexthop Tracking",
+	}).Debug("stopping nexthop tracking manager")
 	close(m.dead)
 }
 
-func (m *nexthopTrackingManager) isRegisteredNexthop(nexthop net.IP) bool {
-	key := nexthop.String()
-	_, ok := m.nexthopCache[key]
+func (m *nexthopTrackingManager) isRegisteredNexthop(nexthop *zebra.RegisteredNexthop) bool {
+	_, ok := m.nexthopCache[registeredNexthopKey(nexthop, m.ignoreZone)]
 	return ok
 }
 
-func (m *nexthopTrackingManager) registerNexthop(nexthop net.IP) bool {
-	key := nexthop.String()
-	if _, ok := m.nexthopCache[key]; ok {
+func (m *nexthopTrackingManager) registerNexthop(vrfId uint32, nexthop *zebra.RegisteredNexthop) bool {
+	key := registeredNexthopKey(nexthop, m.ignoreZone)
+	if c, ok := m.nexthopCache[key]; ok {
+		c.vrfIds = appendVrfId(c.vrfIds, vrfId)
 		return false
 	}
-	m.nexthopCache[key] = struct{}{}
+	m.nexthopCache[key] = &cachedNexthop{
+		family: nexthop.Family,
+		prefix: nexthop.Prefix,
+		zone:   nexthop.Zone,
+		vrfIds: []uint32{vrfId},
+	}
 	return true
 }
 
-func (m *nexthopTrackingManager) unregisterNexthop(nexthop net.IP) {
-	key := nexthop.String()
+// unregisterNexthop removes the given nexthop from the cache. Since Zebra
+// does not know about zones, a link-local nexthop is removed whatever the
+// interface it is reached through.
+func (m *nexthopTrackingManager) unregisterNexthop(family uint16, nexthop net.IP) {
+	key := nexthopKey(family, nexthop)
 	delete(m.nexthopCache, key)
+	if nexthop.IsLinkLocalUnicast() {
+		for k := range m.nexthopCache {
+			if strings.HasPrefix(k, key+"%") {
+				delete(m.nexthopCache, k)
+			}
+		}
+	}
+}
+
+// unregisterAll empties the cache and returns the NEXTHOP_UNREGISTER
+// bodies covering every cached nexthop, keyed by the VRF id they have to
+// be sent to.
+func (m *nexthopTrackingManager) unregisterAll() map[uint32]*zebra.NexthopRegisterBody {
+	keys := make([]string, 0, len(m.nexthopCache))
+	for key := range m.nexthopCache {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	bodies := make(map[uint32]*zebra.NexthopRegisterBody)
+	for _, key := range keys {
+		c := m.nexthopCache[key]
+		for _, id := range c.vrfIds {
+			b, ok := bodies[id]
+			if !ok {
+				b = &zebra.NexthopRegisterBody{}
+				bodies[id] = b
+			}
+			b.Nexthops = append(b.Nexthops, &zebra.RegisteredNexthop{
+				Family: c.family,
+				Prefix: c.prefix,
+				Zone:   c.zone,
+			})
+		}
+	}
+	m.nexthopCache = make(map[string]*cachedNexthop)
+	return bodies
 }
 
 func (m *nexthopTrackingManager) appendPathList(paths pathList) {
@@ -93,45 +229,99 @@ func (m *nexthopTrackingManager) appendPathList(paths pathList) {
 	m.scheduledPathList[path.GetNexthop().String()] = paths
 }
 
+// setScheduled publishes the number of scheduled paths and the penalty.
+func (m *nexthopTrackingManager) setScheduled(penalty int) {
+	atomic.StoreInt64(&m.scheduledPathNum, int64(len(m.scheduledPathList)))
+	m.metrics.setScheduled(len(m.scheduledPathList), penalty)
+}
+
+// charge returns the penalty charged for scheduling the update of the
+// given paths, which depends on whether their nexthop is invalidated.
+func (m *nexthopTrackingManager) charge(paths pathList) int {
+	if m.withdrawPenaltyCharge > 0 && len(paths) > 0 && paths[0].IsNexthopInvalid {
+		return m.withdrawPenaltyCharge
+	}
+	return m.penaltyCharge
+}
+
 func (m *nexthopTrackingManager) calculateDelay(penalty int) int {
-	if penalty <= 950 {
+	if penalty <= m.suppressThreshold {
 		return m.delay
 	}
 
-	delay := 8
-	for penalty > 950 {
-		delay += 8
+	delay := m.dampingDelay
+	for penalty > m.suppressThreshold {
+		delay += m.dampingDelay
 		penalty /= 2
+		if m.maxDelay > 0 && delay >= m.maxDelay {
+			return m.maxDelay
+		}
+	}
+	return delay
+}
+
+// scheduleDelay returns the delay until the pending updates are flushed.
+// Because updates arriving while a flush is scheduled are coalesced into
+// it, this also bounds how long any of them is held back. Zero is allowed
+// and means flushing as soon as the loop gets to it, in which case only
+// the updates queued meanwhile are coalesced.
+func (m *nexthopTrackingManager) scheduleDelay(penalty int) int {
+	delay := m.calculateDelay(penalty)
+	if delay < m.minDelay {
+		delay = m.minDelay
+	}
+	if m.maxCoalesceAge > 0 && delay > m.maxCoalesceAge {
+		return m.maxCoalesceAge
 	}
 	return delay
 }
 
 func (m *nexthopTrackingManager) triggerUpdatePathAfter() {
-	m.trigger <- struct{}{}
+	select {
+	case m.trigger <- struct{}{}:
+	case <-m.dead:
+	}
 }
 
-func (m *nexthopTrackingManager) loop() {
-	t := time.NewTicker(8 * time.Second)
+// loop runs until the manager is stopped or ctx is cancelled.
+func (m *nexthopTrackingManager) loop(ctx context.Context) {
+	t := time.NewTicker(m.decayInterval)
 	defer t.Stop()
 
 	penalty := 0
+	var triggerTimer *time.Timer
+	cancelTimer := func() {
+		if triggerTimer != nil && triggerTimer.Stop() {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Event": "Nexthop Tracking",
+			}).Debug("scheduled nexthop tracking event cancelled")
+		}
+	}
 
 	for {
 		select {
 		case <-m.dead:
+			cancelTimer()
+			return
+		case <-ctx.Done():
+			cancelTimer()
 			return
 
 		case <-t.C:
 			penalty /= 2
+			m.setScheduled(penalty)
 
 		case paths := <-m.pathListCh:
-			penalty += 500
+			charge := m.charge(paths)
+			penalty += charge
 			log.WithFields(log.Fields{
 				"Topic": "Zebra",
 				"Event": "Nexthop Tracking",
-			}).Debugf("penalty 500 charged: penalty: %d", penalty)
+			}).Debugf("penalty %d charged: penalty: %d", charge, penalty)
 
 			m.appendPathList(paths)
+			m.setScheduled(penalty)
 
 			isScheduled := m.isScheduled
 			if isScheduled {
@@ -144,49 +334,102 @@ func (m *nexthopTrackingManager) loop() {
 				m.isScheduled = true
 			}
 
-			delay := m.calculateDelay(penalty)
-			fmt.Println("triggerUpdatePathAfter is scheduled", delay)
-			triggerTimer := time.AfterFunc(time.Duration(delay)*time.Second, m.triggerUpdatePathAfter)
-			defer func() {
-				fmt.Println("triggerdUpdatePathAfter is cancelled")
-				triggerTimer.Stop()
-			}()
-			//go m.triggerUpdatePathAfter(delay)
+			delay := m.scheduleDelay(penalty)
+			triggerTimer = time.AfterFunc(time.Duration(delay)*time.Second, m.triggerUpdatePathAfter)
 			log.WithFields(log.Fields{
 				"Topic": "Zebra",
 				"Event": "Nexthop Tracking",
 			}).Debugf("nexthop tracking event scheduled in %d secs", delay)
 
 		case <-m.trigger:
+			triggerTimer = nil
 			paths := make(pathList, 0)
 			for _, pList := range m.scheduledPathList {
 				for _, p := range pList {
 					paths = append(paths, p)
 				}
 			}
+			m.isScheduled = false
+			m.scheduledPathList = make(map[string]pathList, 0)
+			m.setScheduled(penalty)
+			if len(paths) == 0 {
+				log.WithFields(log.Fields{
+					"Topic": "Zebra",
+					"Event": "Nexthop Tracking",
+				}).Debug("no path to update nexthop reachability of")
+				continue
+			}
 			log.WithFields(log.Fields{
 				"Topic": "Zebra",
 				"Event": "Nexthop Tracking",
 			}).Debugf("update nexthop reachability: %s", paths)
 
-			if err := m.server.UpdatePath("", paths); err != nil {
-				log.WithFields(log.Fields{
-					"Topic": "Zebra",
-					"Event": "Nexthop Tracking",
-				}).Error("failed to update nexthop reachability")
+			for _, t := range flushTargets(paths, m.server.GetVrf()) {
+				if err := m.server.UpdatePath(t.vrf, t.paths); err != nil {
+					log.WithFields(log.Fields{
+						"Topic": "Zebra",
+						"Event": "Nexthop Tracking",
+						"Vrf":   t.vrf,
+						"Error": err,
+					}).Error("failed to update nexthop reachability")
+				}
 			}
+		}
+	}
+}
 
-			m.isScheduled = false
-			m.scheduledPathList = make(map[string]pathList, 0)
+// flushTarget holds the paths of a nexthop reachability flush updated into
+// the VRF of the given name, or into the global RIB if it is empty.
+type flushTarget struct {
+	vrf   string
+	paths pathList
+}
+
+// flushTargets splits paths by the RIB they are updated into, the global
+// one first and then the VRFs by name. The paths are taken from the global
+// RIB, where the ones originated into a VRF are held in their VPN form with
+// the RD of the VRF: they are updated into their VRF in their local form,
+// the way they were added. The others, such as the VPN paths received from
+// peers, stay in the global RIB, since updating them into a VRF would have
+// their route targets overwritten with the export ones of the VRF.
+func flushTargets(paths pathList, vrfs []*table.Vrf) []flushTarget {
+	byRd := make(map[string]string, len(vrfs))
+	for _, vrf := range vrfs {
+		if vrf.Rd != nil {
+			byRd[vrf.Rd.String()] = vrf.Name
 		}
 	}
+	byVrf := make(map[string]pathList)
+	for _, path := range paths {
+		name := ""
+		if rd := NlriRD(path.GetNlri()); rd != "" && path.IsLocal() {
+			name = byRd[rd]
+		}
+		if name != "" {
+			// cloned, for the route targets the VRF sets back not to be
+			// hidden by the ones ToLocal() deletes.
+			local := path.ToLocal().Clone(false)
+			local.IsNexthopMetric = path.IsNexthopMetric
+			path = local
+		}
+		byVrf[name] = append(byVrf[name], path)
+	}
+	targets := make([]flushTarget, 0, len(byVrf))
+	for name, l := range byVrf {
+		targets = append(targets, flushTarget{vrf: name, paths: l})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].vrf < targets[j].vrf })
+	return targets
 }
 
 func (m *nexthopTrackingManager) scheduleUpdate(paths pathList) {
 	if len(paths) == 0 {
 		return
 	}
-	m.pathListCh <- paths
+	select {
+	case m.pathListCh <- paths:
+	case <-m.dead:
+	}
 }
 
 func (m *nexthopTrackingManager) filterPathToRegister(paths pathList) pathList {
@@ -201,12 +444,23 @@ func (m *nexthopTrackingManager) filterPathToRegister(paths pathList) pathList {
 		// Here filters out withdraw paths and paths whose nexthop is:
 		// - already invalidated
 		// - already registered
-		// - unspecified address
+		// - missing or unspecified address
+		// Also filters out default routes if configured so, because their
+		// nexthop tends to flap with every upstream change, and the routes
+		// marked to bypass nexthop tracking.
 		if path.IsWithdraw || path.IsNexthopInvalid {
 			continue
 		}
-		nexthop := path.GetNexthop()
-		if m.isRegisteredNexthop(nexthop) || nexthop.IsUnspecified() {
+		if m.skipDefaultRoute && isDefaultRoute(path.GetNlri()) {
+			continue
+		}
+		if m.isBypassed(path) {
+			continue
+		}
+		if nh := registeredNexthop(path); nh != nil && m.isRegisteredNexthop(nh) {
+			continue
+		}
+		if isUnspecifiedNexthop(path.GetNexthop()) {
 			continue
 		}
 		filteredPaths = append(filteredPaths, path)
@@ -214,6 +468,24 @@ func (m *nexthopTrackingManager) filterPathToRegister(paths pathList) pathList {
 	return filteredPaths
 }
 
+// isUnspecifiedNexthop returns whether nexthop is missing or is 0.0.0.0 or
+// ::, either of which means the route is reachable through this router
+// itself rather than through a gateway.
+func isUnspecifiedNexthop(nexthop net.IP) bool {
+	return len(nexthop) == 0 || nexthop.IsUnspecified()
+}
+
+// isBypassed returns whether path carries one of the communities marking
+// the routes which bypass nexthop tracking.
+func (m *nexthopTrackingManager) isBypassed(path *table.Path) bool {
+	for _, c := range path.GetCommunities() {
+		if _, ok := m.bypassCommunities[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func filterOutExternalPath(paths pathList) pathList {
 	filteredPaths := make(pathList, 0, len(paths))
 	for _, path := range paths {
@@ -225,16 +497,236 @@ func filterOutExternalPath(paths pathList) pathList {
 	return filteredPaths
 }
 
-func newIPRouteBody(dst pathList, selfRouteWithdraw bool) (body *zebra.IPRouteBody, isWithdraw bool) {
+// isLocalPath tells whether the given path has been originated locally,
+// in which case its route is withdrawn from Zebra. The paths without
+// source which do not come from Zebra either are local unless
+// NilSourceAction is INSTALL.
+func isLocalPath(path *table.Path, c *config.ZebraConfig) bool {
+	if path.GetSource() == nil {
+		return !path.IsFromExternal() && c.NilSourceAction != config.ZEBRA_NIL_SOURCE_ACTION_INSTALL
+	}
+	return path.IsLocal()
+}
+
+// isIgnoredPath tells whether the given path is left out for having no
+// source while NilSourceAction is IGNORE.
+func (z *zebraClient) isIgnoredPath(path *table.Path) bool {
+	if z.config.NilSourceAction != config.ZEBRA_NIL_SOURCE_ACTION_IGNORE || path.GetSource() != nil || path.IsFromExternal() {
+		return false
+	}
+	log.WithFields(log.Fields{
+		"Topic": "Zebra",
+		"Key":   path.GetNlri().String(),
+	}).Debug("skipping path without source")
+	return true
+}
+
+// getMed returns the MED carried by the given path. ok reports whether the
+// path has a MULTI_EXIT_DISC attribute at all, and err is only set when the
+// attribute is present but malformed, so that callers can tell an absent
+// MED apart from a broken one.
+func getMed(path *table.Path) (med uint32, ok bool, err error) {
+	for _, a := range path.GetPathAttrs() {
+		if a.GetType() != bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC {
+			continue
+		}
+		m, isMed := a.(*bgp.PathAttributeMultiExitDisc)
+		if !isMed {
+			return 0, false, fmt.Errorf("malformed MED attribute: %v", a)
+		}
+		return m.Value, true, nil
+	}
+	return 0, false, nil
+}
+
+// isIPRouteFamily reports whether the given family carries plain IP
+// prefixes, for which a zero length prefix denotes the default route.
+func isIPRouteFamily(rf bgp.RouteFamily) bool {
+	switch rf {
+	case bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN:
+		return true
+	}
+	return false
+}
+
+// neighborDistance returns the administrative distance configured for
+// the neighbor with the given address.
+func neighborDistance(addr net.IP, l []config.NeighborDistance) (uint8, bool) {
+	for _, n := range l {
+		if addr.Equal(net.ParseIP(n.Address)) {
+			return n.Distance, true
+		}
+	}
+	return 0, false
+}
+
+// routeTag returns the route tag of the given path: the one mapped to the
+// first of its communities found in CommunityTagList, or else Tag.
+func routeTag(path *table.Path, c *config.ZebraConfig) uint32 {
+	if len(c.CommunityTagList) > 0 {
+		comms := path.GetCommunities()
+		for _, t := range c.CommunityTagList {
+			v, err := table.ParseCommunity(t.Community)
+			if err != nil {
+				continue
+			}
+			for _, comm := range comms {
+				if comm == v {
+					return t.Tag
+				}
+			}
+		}
+	}
+	return c.Tag
+}
+
+// asSetAspath returns aspath with its AS_SET segments turned into
+// AS_SEQUENCE ones or left out as configured by action.
+func asSetAspath(aspath *bgp.PathAttributeAsPath, action config.ZebraAspathAsSetAction) *bgp.PathAttributeAsPath {
+	switch action {
+	case config.ZEBRA_ASPATH_AS_SET_ACTION_FLATTEN, config.ZEBRA_ASPATH_AS_SET_ACTION_OMIT:
+	default:
+		return aspath
+	}
+	params := make([]bgp.AsPathParamInterface, 0, len(aspath.Value))
+	changed := false
+	for _, param := range aspath.Value {
+		if param.GetType() != bgp.BGP_ASPATH_ATTR_TYPE_SET {
+			params = append(params, param)
+			continue
+		}
+		changed = true
+		if action == config.ZEBRA_ASPATH_AS_SET_ACTION_OMIT {
+			continue
+		}
+		switch p := param.(type) {
+		case *bgp.AsPathParam:
+			params = append(params, bgp.NewAsPathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, p.AS))
+		default:
+			params = append(params, bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, param.GetAS()))
+		}
+	}
+	if !changed {
+		return aspath
+	}
+	return bgp.NewPathAttributeAsPath(params)
+}
+
+// hasCommunity returns whether path has any of the given communities.
+func hasCommunity(path *table.Path, communities []string) bool {
+	if len(communities) == 0 {
+		return false
+	}
+	comms := path.GetCommunities()
+	for _, s := range communities {
+		v, err := table.ParseCommunity(s)
+		if err != nil {
+			continue
+		}
+		for _, comm := range comms {
+			if comm == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tagFlag returns the message flag of the route tag, which differs since
+// version 4.
+func tagFlag(version uint8) zebra.MESSAGE_FLAG {
+	if version >= 4 {
+		return zebra.FRR_MESSAGE_TAG
+	}
+	return zebra.MESSAGE_TAG
+}
+
+// mtuFlag returns the message flag of the MTU, which differs since
+// version 4.
+func mtuFlag(version uint8) zebra.MESSAGE_FLAG {
+	if version >= 4 {
+		return zebra.FRR_MESSAGE_MTU
+	}
+	return zebra.MESSAGE_MTU
+}
+
+// labelStack returns the label stack of the given labeled VPN path.
+func labelStack(path *table.Path) []uint32 {
+	switch n := path.GetNlri().(type) {
+	case *bgp.LabeledVPNIPAddrPrefix:
+		return n.Labels.Labels
+	case *bgp.LabeledVPNIPv6AddrPrefix:
+		return n.Labels.Labels
+	}
+	return nil
+}
+
+// rewriteLabels returns the label stack to be programmed for the given
+// one according to LabelRewriteAction. The given one is left untouched.
+func rewriteLabels(labels []uint32, c *config.ZebraConfig) []uint32 {
+	if len(labels) == 0 {
+		return labels
+	}
+	switch c.LabelRewriteAction {
+	case config.ZEBRA_LABEL_REWRITE_ACTION_POP:
+		return labels[1:]
+	case config.ZEBRA_LABEL_REWRITE_ACTION_SWAP:
+		l := make([]uint32, len(labels))
+		copy(l, labels)
+		l[0] = c.LabelRewriteLabel
+		return l
+	}
+	return labels
+}
+
+// translateAs returns the effective AS of the given neighbor AS according
+// to the given translations.
+func translateAs(as uint32, l []config.AsTranslation) uint32 {
+	for _, t := range l {
+		if t.SourceAs == as {
+			return t.TranslatedAs
+		}
+	}
+	return as
+}
+
+// isZebraRoute returns whether the given path can be installed into
+// Zebra, which requires an address family Zebra has a table for and, for
+// EVPN, a route type carrying an IP prefix.
+func isZebraRoute(path *table.Path) bool {
+	switch path.GetRouteFamily() {
+	case bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN:
+		return true
+	case bgp.RF_EVPN:
+		prefix, _, _ := evpnIPPrefix(path.GetNlri())
+		return prefix != nil
+	}
+	return false
+}
+
+func newIPRouteBody(dst pathList, selfRouteWithdraw bool, c *config.ZebraConfig) (body *zebra.IPRouteBody, isWithdraw bool) {
 	paths := filterOutExternalPath(dst)
 	if len(paths) == 0 {
 		return nil, false
 	}
 	path := paths[0]
 
-	l := strings.SplitN(path.GetNlri().String(), "/", 2)
+	var plen uint8
+	if l := strings.SplitN(path.GetNlri().String(), "/", 2); len(l) == 2 {
+		n, _ := strconv.ParseUint(l[1], 10, 8)
+		plen = uint8(n)
+	}
 	var prefix net.IP
 	nexthops := make([]net.IP, 0, len(paths))
+	// ifindexs holds the interface index of every IPv6 nexthop, 0 if it
+	// has none, Zebra pairing them by position.
+	ifindexs := make([]uint32, 0, len(paths))
+	hasIfindex := false
+	hasNilNexthop := false
+	// hasInvalidNexthop is set if a nexthop is left out for not being an
+	// address of the family of the route, e.g. an IPv6 nexthop of an IPv4
+	// route.
+	hasInvalidNexthop := false
 	switch path.GetRouteFamily() {
 	case bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN:
 		if path.GetRouteFamily() == bgp.RF_IPv4_UC {
@@ -245,12 +737,24 @@ func newIPRouteBody(dst pathList, selfRouteWithdraw bool) (body *zebra.IPRouteBo
 		for _, p := range paths {
 			var nhop net.IP
 			if selfRouteWithdraw {
-				nhop = net.ParseIP("127.0.0.1").To4()
+				nhop = selfRouteWithdrawNexthop(prefix, c)
+			} else if isUnspecifiedNexthop(p.GetNexthop()) {
+				hasNilNexthop = true
+				continue
 			} else {
 				nhop = p.GetNexthop().To4()
 			}
 			if nhop != nil {
 				nexthops = append(nexthops, nhop)
+			} else {
+				hasInvalidNexthop = true
+			}
+			if table.UseMultiplePaths.Enabled && !selfRouteWithdraw {
+				for _, nh := range p.GetEcmpNexthops() {
+					if nh = nh.To4(); nh != nil {
+						nexthops = append(nexthops, nh)
+					}
+				}
 			}
 		}
 	case bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN:
@@ -262,54 +766,272 @@ func newIPRouteBody(dst pathList, selfRouteWithdraw bool) (body *zebra.IPRouteBo
 		for _, p := range paths {
 			var nhop net.IP
 			if selfRouteWithdraw {
-				nhop = net.ParseIP("::1").To16()
+				nhop = selfRouteWithdrawNexthop(prefix, c)
+			} else if isUnspecifiedNexthop(p.GetNexthop()) {
+				hasNilNexthop = true
+				continue
 			} else {
 				nhop = p.GetNexthop().To16()
 			}
 			if nhop != nil {
 				nexthops = append(nexthops, nhop)
+				var index uint32
+				if !selfRouteWithdraw {
+					index = nexthopIfindex(p, nhop)
+				}
+				ifindexs = append(ifindexs, index)
+				hasIfindex = hasIfindex || index != 0
+			} else {
+				hasInvalidNexthop = true
+			}
+			if table.UseMultiplePaths.Enabled && !selfRouteWithdraw {
+				for _, nh := range p.GetEcmpNexthops() {
+					if nh = nh.To16(); nh != nil {
+						nexthops = append(nexthops, nh)
+						ifindexs = append(ifindexs, 0)
+					}
+				}
+			}
+		}
+	case bgp.RF_EVPN:
+		prefix, plen, _ = evpnIPPrefix(path.GetNlri())
+		if prefix == nil {
+			return nil, false
+		}
+		for _, p := range paths {
+			// The gateway address of IP prefix routes takes precedence
+			// over the VTEP address carried as the BGP nexthop.
+			_, _, gw := evpnIPPrefix(p.GetNlri())
+			var nhop net.IP
+			if selfRouteWithdraw {
+				nhop = selfRouteWithdrawNexthop(prefix, c)
+			} else if !isUnspecifiedNexthop(gw) {
+				nhop = gw
+			} else if isUnspecifiedNexthop(p.GetNexthop()) {
+				hasNilNexthop = true
+				continue
+			} else {
+				nhop = p.GetNexthop()
 			}
+			if v4 := nhop.To4(); v4 != nil {
+				nhop = v4
+			}
+			nexthops = append(nexthops, nhop)
 		}
 	default:
 		return nil, false
 	}
+	if c.NexthopSelf && !selfRouteWithdraw && len(nexthops) > 0 {
+		if nhop := selfNexthop(path, prefix); nhop != nil {
+			nexthops = []net.IP{nhop}
+			hasIfindex = false
+		} else {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Key":   path.GetNlri().String(),
+			}).Debug("no local address to rewrite the nexthop to, keeping it")
+		}
+	}
+	// Blackhole routes only have the blackhole nexthop.
+	isBlackhole := hasCommunity(path, c.BlackholeCommunityList) || selfRouteWithdraw && c.SelfRouteWithdrawBlackhole
+	if !isBlackhole && hasInvalidNexthop && len(nexthops) == 0 && !path.IsWithdraw {
+		if c.InvalidNexthopAction != config.ZEBRA_INVALID_NEXTHOP_ACTION_BLACKHOLE {
+			log.WithFields(log.Fields{
+				"Topic":   "Zebra",
+				"Key":     path.GetNlri().String(),
+				"Nexthop": path.GetNexthop(),
+			}).Warn("skipping route without valid nexthop")
+			return nil, false
+		}
+		log.WithFields(log.Fields{
+			"Topic":   "Zebra",
+			"Key":     path.GetNlri().String(),
+			"Nexthop": path.GetNexthop(),
+		}).Warn("installing route without valid nexthop as blackhole")
+		isBlackhole = true
+	}
+	if isBlackhole {
+		nexthops = nil
+	} else if hasNilNexthop && len(nexthops) == 0 {
+		if c.NilNexthopAction == config.ZEBRA_NIL_NEXTHOP_ACTION_SKIP {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Key":   path.GetNlri().String(),
+			}).Warn("skipping route without nexthop")
+			return nil, false
+		}
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Key":   path.GetNlri().String(),
+		}).Debug("sending route without nexthop")
+	}
 	var msgFlags zebra.MESSAGE_FLAG
-	if len(nexthops) > 0 {
+	if len(nexthops) > 0 || isBlackhole {
 		msgFlags = zebra.MESSAGE_NEXTHOP
 	}
-	plen, _ := strconv.ParseUint(l[1], 10, 8)
-	med, err := path.GetMed()
-	if err == nil {
+	if isBlackhole || !hasIfindex {
+		ifindexs = nil
+	} else {
+		msgFlags |= zebra.MESSAGE_IFINDEX
+	}
+	isWithdraw = path.IsWithdraw
+	med, ok, err := getMed(path)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Key":   path.GetNlri().String(),
+		}).Warnf("failed to get MED, sending route without metric: %s", err)
+	} else if ok {
 		msgFlags |= zebra.MESSAGE_METRIC
+		max := zebra.MaxMetric
+		if c.MaxMetricUnreachable && med >= max && !isWithdraw {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Key":   path.GetNlri().String(),
+				"Med":   med,
+			}).Debug("withdrawing route with the maximum metric")
+			isWithdraw = true
+		}
+		if c.MaxMetric > 0 && c.MaxMetric < max {
+			max = c.MaxMetric
+		}
+		if med > max {
+			log.WithFields(log.Fields{
+				"Topic":  "Zebra",
+				"Key":    path.GetNlri().String(),
+				"Med":    med,
+				"Metric": max,
+			}).Debug("clamping MED to the maximum metric")
+			med = max
+		}
 	}
+	// The paths without source are sent like the ones of an eBGP peer to
+	// which no neighbor setting applies, see NilSourceAction.
 	var flags zebra.FLAG
-	info := path.GetSource()
-	if info.AS == info.LocalAS {
-		flags = zebra.FLAG_IBGP | zebra.FLAG_INTERNAL
-	} else if info.MultihopTtl > 0 {
-		flags = zebra.FLAG_INTERNAL
+	var distance uint8
+	isIBGP, ok := false, false
+	if info := path.GetSource(); info != nil {
+		isIBGP = translateAs(info.AS, c.AsTranslationList) == info.LocalAS
+		if isIBGP {
+			flags = zebra.FLAG_IBGP | zebra.FLAG_INTERNAL
+		} else if info.MultihopTtl > 0 {
+			flags = zebra.FLAG_INTERNAL
+		}
+		distance, ok = neighborDistance(info.Address, c.NeighborDistanceList)
 	}
+	if !ok {
+		if isIBGP {
+			distance = c.IbgpDistance
+		} else {
+			distance = c.EbgpDistance
+		}
+		ok = distance > 0
+	}
+	if ok {
+		msgFlags |= zebra.MESSAGE_DISTANCE
+	}
+	isReject := hasCommunity(path, c.RejectCommunityList)
 	for _, c := range path.GetCommunities() {
 		if c == bgp.COMMUNITY_REGION_BACKUP {
-			flags |= zebra.FLAG_REJECT
+			isReject = true
 		}
 	}
+	if isReject && c.CommunityFlagPrecedence == config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL && hasCommunity(path, c.InstallCommunityList) {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Key":   path.GetNlri().String(),
+		}).Debug("installing route with both reject and install communities normally")
+		isReject = false
+	}
+	if isBlackhole {
+		flags |= zebra.FLAG_BLACKHOLE
+	} else if isReject {
+		flags |= zebra.FLAG_REJECT
+	}
 	var aux []byte
 	if path.GetAsPathLen() > 0 {
 		aspath := path.GetAsPath()
 		if aspath != nil {
-			aux, _ = aspath.Serialize()
+			var err error
+			aux, err = asSetAspath(aspath, c.AspathAsSetAction).Serialize()
+			if err != nil {
+				switch c.AspathErrorAction {
+				case config.ZEBRA_ASPATH_ERROR_ACTION_SKIP:
+					log.WithFields(log.Fields{
+						"Topic": "Zebra",
+						"Key":   path.GetNlri().String(),
+					}).Errorf("failed to serialize AS_PATH, skipping route: %s", err)
+					return nil, false
+				case config.ZEBRA_ASPATH_ERROR_ACTION_LOG:
+					log.WithFields(log.Fields{
+						"Topic": "Zebra",
+						"Key":   path.GetNlri().String(),
+					}).Errorf("failed to serialize AS_PATH, sending route without AS_PATH: %s", err)
+				}
+			}
 			if len(aux) > 3 {
 				aux = aux[3:]
 				msgFlags |= zebra.MESSAGE_ASPATH
 			}
 		}
+	} else if c.SendEmptyAspath && isIBGP {
+		aux = []byte{}
+		msgFlags |= zebra.MESSAGE_ASPATH
+	}
+	if c.AuxEncoding == config.ZEBRA_AUX_ENCODING_TLV {
+		entries := make([]zebra.AuxEntry, 0, 2)
+		if msgFlags&zebra.MESSAGE_ASPATH > 0 {
+			entries = append(entries, zebra.AuxEntry{Type: zebra.AUX_TYPE_ASPATH, Value: aux})
+		}
+		if labels := rewriteLabels(labelStack(path), c); len(labels) > 0 {
+			buf := make([]byte, 4*len(labels))
+			for i, label := range labels {
+				binary.BigEndian.PutUint32(buf[4*i:], label)
+			}
+			entries = append(entries, zebra.AuxEntry{Type: zebra.AUX_TYPE_LABEL, Value: buf})
+		}
+		aux = nil
+		msgFlags &^= zebra.MESSAGE_ASPATH
+		if len(entries) > 0 {
+			aux, err = zebra.SerializeAux(entries)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"Topic": "Zebra",
+					"Key":   path.GetNlri().String(),
+				}).Errorf("failed to encode aux, sending route without it: %s", err)
+				aux = nil
+			} else {
+				msgFlags |= zebra.MESSAGE_ASPATH
+			}
+		}
+	}
+	// Since version 4, the labels of VPN routes are sent along with their
+	// nexthops, unless the TLV encoded aux already carries them. They
+	// replace the aux, whose flag they share.
+	var labels []uint32
+	if c.Version >= 4 && c.AuxEncoding != config.ZEBRA_AUX_ENCODING_TLV && !isBlackhole {
+		if labels = rewriteLabels(labelStack(path), c); len(labels) > 0 {
+			aux = nil
+			msgFlags |= zebra.FRR_MESSAGE_LABEL
+		}
+	}
+	tag := routeTag(path, c)
+	if tag > 0 {
+		msgFlags |= tagFlag(c.Version)
+	}
+	var mtu uint32
+	if c.Mtu > 0 && !isWithdraw && !selfRouteWithdraw {
+		mtu = c.Mtu
+		msgFlags |= mtuFlag(c.Version)
 	}
 	var pathId uint32
-	if plen == 0 {
+	if isIPRouteFamily(path.GetRouteFamily()) && isSpecialDefaultRoute(path, c) {
 		pathId = path.GetNlri().PathLocalIdentifier()
 		if pathId == 0 {
-			log.Warnf("Skipping zero LocalId default route")
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Key":   path.GetNlri().String(),
+			}).Warn("skipping default route with zero local path identifier")
 			return nil, false
 		}
 		msgFlags |= zebra.MESSAGE_PATH_ID
@@ -320,53 +1042,134 @@ func newIPRouteBody(dst pathList, selfRouteWithdraw bool) (body *zebra.IPRouteBo
 		SAFI:         zebra.SAFI_UNICAST,
 		Message:      msgFlags,
 		Prefix:       prefix,
-		PrefixLength: uint8(plen),
+		PrefixLength: plen,
 		Nexthops:     nexthops,
+		Ifindexs:     ifindexs,
+		Distance:     distance,
 		Metric:       med,
 		Aux:          aux,
 		PathId:       pathId,
-	}, path.IsWithdraw
+		Tag:          tag,
+		Mtu:          mtu,
+		Labels:       labels,
+	}, isWithdraw
 }
 
-func newNexthopRegisterBody(dst pathList, nhtManager *nexthopTrackingManager) (body *zebra.NexthopRegisterBody, isWithdraw bool) {
-	if nhtManager == nil {
-		return nil, false
+// selfRouteWithdrawNexthop returns the nexthop of the route of the given
+// prefix withdrawn from Zebra when a local path is selected: the address
+// of the same family in SelfRouteWithdrawNexthopList if any, otherwise the
+// loopback address.
+func selfRouteWithdrawNexthop(prefix net.IP, c *config.ZebraConfig) net.IP {
+	isV4 := prefix.To4() != nil
+	for _, a := range c.SelfRouteWithdrawNexthopList {
+		if nhop := net.ParseIP(a); nhop != nil && (nhop.To4() != nil) == isV4 {
+			if isV4 {
+				return nhop.To4()
+			}
+			return nhop.To16()
+		}
 	}
-
-	paths := nhtManager.filterPathToRegister(dst)
-	if len(paths) == 0 {
-		return nil, false
+	if isV4 {
+		return net.ParseIP("127.0.0.1").To4()
 	}
-	path := paths[0]
+	return net.ParseIP("::1").To16()
+}
 
-	if path.IsWithdraw == true {
-		// NEXTHOP_UNREGISTER message will be sent when GoBGP received
-		// NEXTHOP_UPDATE message and there is no path bound for the updated
-		// nexthop. So there is nothing to do here.
-		return nil, true
+// selfNexthop returns the nexthop of the route of the given prefix when
+// NexthopSelf is enabled: the local address of the session over which the
+// path has been received if it is of the family of the prefix, or else
+// the router id for IPv4 prefixes. It returns nil for the paths without
+// source.
+func selfNexthop(path *table.Path, prefix net.IP) net.IP {
+	info := path.GetSource()
+	if info == nil {
+		return nil
 	}
-
-	family := path.GetRouteFamily()
-	nexthops := make([]*zebra.RegisteredNexthop, 0, len(paths))
-	for _, p := range paths {
-		nexthop := p.GetNexthop()
-		var nh *zebra.RegisteredNexthop
-		switch family {
-		case bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN:
-			nh = &zebra.RegisteredNexthop{
-				Family: syscall.AF_INET,
-				Prefix: nexthop.To4(),
-			}
-		case bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN:
-			nh = &zebra.RegisteredNexthop{
-				Family: syscall.AF_INET6,
-				Prefix: nexthop.To16(),
-			}
-		default:
-			continue
+	if prefix.To4() != nil {
+		if a := info.LocalAddress.To4(); a != nil && !a.IsUnspecified() {
+			return a
+		}
+		if id := info.LocalID.To4(); id != nil && !id.IsUnspecified() {
+			return id
+		}
+		return nil
+	}
+	if a := info.LocalAddress; a != nil && a.To4() == nil && !a.IsUnspecified() {
+		return a.To16()
+	}
+	return nil
+}
+
+// nexthopIfindex returns the index of the interface through which the
+// given link-local nexthop of path is reached, i.e. the interface of the
+// BGP session, as for unnumbered sessions. It returns 0 if it is unknown.
+func nexthopIfindex(path *table.Path, nexthop net.IP) uint32 {
+	info := path.GetSource()
+	if !nexthop.IsLinkLocalUnicast() || info == nil || info.Zone == "" {
+		return 0
+	}
+	ifi, err := net.InterfaceByName(info.Zone)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Topic":     "Zebra",
+			"Key":       path.GetNlri().String(),
+			"Nexthop":   nexthop,
+			"Interface": info.Zone,
+		}).Debugf("failed to get interface of nexthop: %s", err)
+		return 0
+	}
+	return uint32(ifi.Index)
+}
+
+// evpnIPPrefix returns the IP prefix and the gateway address carried by
+// an EVPN NLRI. The prefix is nil for the route types which carry no IP
+// prefix, including MAC/IP advertisement routes without an IP address.
+func evpnIPPrefix(nlri bgp.AddrPrefixInterface) (prefix net.IP, length uint8, gw net.IP) {
+	n, ok := nlri.(*bgp.EVPNNLRI)
+	if !ok {
+		return nil, 0, nil
+	}
+	switch r := n.RouteTypeData.(type) {
+	case *bgp.EVPNMacIPAdvertisementRoute:
+		if r.IPAddressLength == 0 || len(r.IPAddress) == 0 {
+			return nil, 0, nil
+		}
+		if v4 := r.IPAddress.To4(); v4 != nil {
+			return v4, 32, nil
+		}
+		return r.IPAddress.To16(), 128, nil
+	case *bgp.EVPNIPPrefixRoute:
+		if v4 := r.IPPrefix.To4(); v4 != nil {
+			return v4, r.IPPrefixLength, r.GWIPAddress
+		}
+		return r.IPPrefix.To16(), r.IPPrefixLength, r.GWIPAddress
+	}
+	return nil, 0, nil
+}
+
+func newNexthopRegisterBody(dst pathList, nhtManager *nexthopTrackingManager) (body *zebra.NexthopRegisterBody, isWithdraw bool) {
+	if nhtManager == nil {
+		return nil, false
+	}
+
+	paths := nhtManager.filterPathToRegister(dst)
+	if len(paths) == 0 {
+		return nil, false
+	}
+	path := paths[0]
+
+	if path.IsWithdraw == true {
+		// NEXTHOP_UNREGISTER message will be sent when GoBGP received
+		// NEXTHOP_UPDATE message and there is no path bound for the updated
+		// nexthop. So there is nothing to do here.
+		return nil, true
+	}
+
+	nexthops := make([]*zebra.RegisteredNexthop, 0, len(paths))
+	for _, p := range paths {
+		if nh := registeredNexthop(p); nh != nil {
+			nexthops = append(nexthops, nh)
 		}
-		nexthops = append(nexthops, nh)
-		nhtManager.registerNexthop(nexthop)
 	}
 
 	// If no nexthop needs to be registered or unregistered,
@@ -380,7 +1183,35 @@ func newNexthopRegisterBody(dst pathList, nhtManager *nexthopTrackingManager) (b
 	}, path.IsWithdraw
 }
 
-func createPathFromIPRouteMessage(m *zebra.Message) *table.Path {
+// isValidNexthop returns whether nexthop can be used as the nexthop of a
+// path of the given family.
+func isValidNexthop(family bgp.RouteFamily, nexthop net.IP) bool {
+	switch len(nexthop) {
+	case net.IPv4len, net.IPv6len:
+	default:
+		return false
+	}
+	if family == bgp.RF_IPv4_UC {
+		return nexthop.To4() != nil
+	}
+	return true
+}
+
+// isImportedFamily returns whether the routes of the given family are
+// imported from Zebra according to ImportFamilyList.
+func isImportedFamily(family bgp.RouteFamily, c *config.ZebraConfig) bool {
+	if len(c.ImportFamilyList) == 0 {
+		return true
+	}
+	for _, f := range c.ImportFamilyList {
+		if rf, err := bgp.GetRouteFamily(string(f)); err == nil && rf == family {
+			return true
+		}
+	}
+	return false
+}
+
+func createPathFromIPRouteMessage(m *zebra.Message, c *config.ZebraConfig) *table.Path {
 	header := m.Header
 	body := m.Body.(*zebra.IPRouteBody)
 	family := body.RouteFamily()
@@ -406,6 +1237,35 @@ func createPathFromIPRouteMessage(m *zebra.Message) *table.Path {
 		"api":          header.Command.String(),
 	}).Debugf("create path from ip route message.")
 
+	if !isImportedFamily(family, c) {
+		log.WithFields(log.Fields{
+			"Topic":        "Zebra",
+			"Prefix":       body.Prefix,
+			"PrefixLength": body.PrefixLength,
+			"Family":       family,
+		}).Debug("skip route from zebra of a family not to be imported")
+		return nil
+	}
+
+	if len(body.Nexthops) > 0 && !isValidNexthop(family, body.Nexthops[0]) {
+		log.WithFields(log.Fields{
+			"Topic":        "Zebra",
+			"Prefix":       body.Prefix,
+			"PrefixLength": body.PrefixLength,
+			"Nexthop":      []byte(body.Nexthops[0]),
+		}).Warn("skip route from zebra with malformed nexthop")
+		return nil
+	}
+
+	// The first nexthop stands for the path and the others are kept
+	// along, so that the nexthops of ECMP routes are not lost.
+	var ecmpNexthops []net.IP
+	for i := 1; i < len(body.Nexthops); i++ {
+		if nh := body.Nexthops[i]; isValidNexthop(family, nh) && !isUnspecifiedNexthop(nh) {
+			ecmpNexthops = append(ecmpNexthops, nh)
+		}
+	}
+
 	switch family {
 	case bgp.RF_IPv4_UC:
 		nlri = bgp.NewIPAddrPrefix(body.PrefixLength, body.Prefix.String())
@@ -414,11 +1274,30 @@ func createPathFromIPRouteMessage(m *zebra.Message) *table.Path {
 		}
 	case bgp.RF_IPv6_UC:
 		nlri = bgp.NewIPv6AddrPrefix(body.PrefixLength, body.Prefix.String())
-		nexthop := ""
-		if len(body.Nexthops) > 0 {
+		// MP_REACH_NLRI cannot be built without a nexthop, so routes
+		// without one get the unspecified address unless they are to be
+		// withdrawn.
+		nexthop := net.IPv6unspecified.String()
+		var linkLocal net.IP
+		if len(body.Nexthops) > 0 && body.Nexthops[0].IsLinkLocalUnicast() {
+			// A link-local nexthop is only meaningful along with its
+			// interface, so it is carried next to the unspecified address
+			// (RFC 2545), which is replaced by our address when the path
+			// is advertised.
+			linkLocal = linkLocalNexthop(body)
+		} else if len(body.Nexthops) > 0 {
 			nexthop = body.Nexthops[0].String()
+		} else if !isWithdraw && c.ImportNilNexthopAction == config.ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_WITHDRAW {
+			log.WithFields(log.Fields{
+				"Topic":        "Zebra",
+				"Prefix":       body.Prefix,
+				"PrefixLength": body.PrefixLength,
+			}).Debug("treating route from zebra without nexthop as withdrawn")
+			isWithdraw = true
 		}
-		pattr = append(pattr, bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{nlri}))
+		mpreach := bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{nlri})
+		mpreach.LinkLocalNexthop = linkLocal
+		pattr = append(pattr, mpreach)
 	default:
 		log.WithFields(log.Fields{
 			"Topic": "Zebra",
@@ -429,21 +1308,75 @@ func createPathFromIPRouteMessage(m *zebra.Message) *table.Path {
 	med := bgp.NewPathAttributeMultiExitDisc(body.Metric)
 	pattr = append(pattr, med)
 
+	if !isWithdraw && c.MaxMetricUnreachable && body.Message&zebra.MESSAGE_METRIC > 0 && body.Metric >= zebra.MaxMetric {
+		log.WithFields(log.Fields{
+			"Topic":        "Zebra",
+			"Prefix":       body.Prefix,
+			"PrefixLength": body.PrefixLength,
+			"Metric":       body.Metric,
+		}).Debug("treating route from zebra with the maximum metric as withdrawn")
+		isWithdraw = true
+	}
+
 	path := table.NewPath(nil, nlri, isWithdraw, pattr, time.Now(), false)
 	path.SetIsFromExternal(true)
+	if !isWithdraw && len(ecmpNexthops) > 0 {
+		path.SetEcmpNexthops(ecmpNexthops)
+	}
+	if c.SourceNeighborAddress != "" {
+		path.SetNeighborAddress(net.ParseIP(c.SourceNeighborAddress))
+	}
 	return path
 }
 
+// linkLocalNexthop returns the link-local nexthop of body, or nil if the
+// interface it is reached through is unknown.
+func linkLocalNexthop(body *zebra.IPRouteBody) net.IP {
+	if len(body.Ifindexs) == 0 || body.Ifindexs[0] == 0 {
+		log.WithFields(log.Fields{
+			"Topic":        "Zebra",
+			"Prefix":       body.Prefix,
+			"PrefixLength": body.PrefixLength,
+			"Nexthop":      body.Nexthops[0],
+		}).Debug("ignoring link-local nexthop without interface of route from zebra")
+		return nil
+	}
+	return body.Nexthops[0].To16()
+}
+
+// rfListFromNexthopUpdateBody returns the route families of the paths
+// which may use the updated nexthop. Besides body.Family, the address
+// family of the prefix is taken into account, so that messages carrying
+// an unknown or more than one family query every relevant table. As IPv4
+// paths may have IPv6 nexthops (RFC 5549), IPv6 nexthops are looked up in
+// the IPv4 tables too.
 func rfListFromNexthopUpdateBody(body *zebra.NexthopUpdateBody) (rfList []bgp.RouteFamily) {
+	v4, v6 := false, false
 	switch body.Family {
 	case uint16(syscall.AF_INET):
-		return []bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN}
+		v4 = true
 	case uint16(syscall.AF_INET6):
-		return []bgp.RouteFamily{bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN}
+		v6 = true
 	}
-	return nil
+	if len(body.Prefix) > 0 {
+		if body.Prefix.To4() != nil {
+			v4 = true
+		} else {
+			v6 = true
+		}
+	}
+	if v4 || v6 {
+		rfList = append(rfList, bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN)
+	}
+	if v6 {
+		rfList = append(rfList, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN)
+	}
+	return rfList
 }
 
+// createPathListFromNexthopUpdateMessage returns a copy of every path
+// bound to the nexthop updated by body, with its reachability updated,
+// along with the NEXTHOP_UNREGISTER message to send if there is none.
 func createPathListFromNexthopUpdateMessage(body *zebra.NexthopUpdateBody, manager *table.TableManager, nhtManager *nexthopTrackingManager) (pathList, *zebra.NexthopRegisterBody, error) {
 	isNexthopInvalid := len(body.Nexthops) == 0
 	paths := manager.GetPathListWithNexthop(table.GLOBAL_RIB_NAME, rfListFromNexthopUpdateBody(body), body.Prefix)
@@ -459,7 +1392,7 @@ func createPathListFromNexthopUpdateMessage(body *zebra.NexthopUpdateBody, manag
 				Prefix: body.Prefix,
 			}},
 		}
-		nhtManager.unregisterNexthop(body.Prefix)
+		nhtManager.unregisterNexthop(body.Family, body.Prefix)
 	}
 
 	updatedPathList := make(pathList, 0, pathsLen)
@@ -471,30 +1404,509 @@ func createPathListFromNexthopUpdateMessage(body *zebra.NexthopUpdateBody, manag
 			newPath.IsNexthopInvalid = true
 		} else {
 			// If NEXTHOP_UPDATE message contains valid nexthops,
-			// copies Metric into MED.
+			// copies Metric into MED, which import policies may override
+			// unless configured otherwise.
 			newPath.IsNexthopInvalid = false
+			newPath.IsNexthopMetric = nhtManager.metricPrecedence == config.ZEBRA_NEXTHOP_METRIC_PRECEDENCE_NEXTHOP
 			newPath.SetMed(int64(body.Metric), true)
 		}
 		updatedPathList = append(updatedPathList, newPath)
 	}
 
+	log.WithFields(log.Fields{
+		"Topic":      "Zebra",
+		"Nexthop":    body.Prefix,
+		"Invalid":    isNexthopInvalid,
+		"Paths":      pathsLen,
+		"Unregister": nexthopUnregisterBody != nil,
+	}).Debug("paths bound to the updated nexthop")
+
 	return updatedPathList, nexthopUnregisterBody, nil
 }
 
+// zebraConn is the connection to Zebra used by zebraClient. It is
+// implemented by *zebra.Client, and by a fake in the tests for loop() to be
+// run without Zebra.
+type zebraConn interface {
+	Receive() chan *zebra.Message
+	MessageVersion() uint8
+	SendIPRoute(vrfId uint32, body *zebra.IPRouteBody, isWithdraw bool) error
+	SendNexthopRegister(vrfId uint32, body *zebra.NexthopRegisterBody, isWithdraw bool) error
+	SendCommand(command zebra.API_TYPE, vrfId uint32, body zebra.Body) error
+	SendRedistribute(t zebra.ROUTE_TYPE, vrfId uint32) error
+	SendRedistributeDelete(t zebra.ROUTE_TYPE, vrfId uint32) error
+	SendInterfaceAdd() error
+	Close() error
+	Abort() error
+}
+
 type zebraClient struct {
-	client     *zebra.Client
+	// The counters reported by Stats() are accessed atomically and come
+	// first to be 64-bit aligned.
+	ipRoutesSent           uint64
+	ipRoutesWithdrawn      uint64
+	nexthopRegistersSent   uint64
+	nexthopUpdatesReceived uint64
+	nexthopUpdatePaths     uint64
+
+	client     zebraConn
 	server     *BgpServer
+	ctx        context.Context
 	dead       chan struct{}
+	stopOnce   sync.Once
 	nhtManager *nexthopTrackingManager
 	watcher    *Watcher
 	config     config.ZebraConfig
+	// installed maps a route (see ipRouteKey) to the VRF/table ids it has
+	// been programmed into, so that a withdraw reaches every one of them.
+	installed map[string][]uint32
+	// installedBody keeps the last message sent for each installed route.
+	installedBody map[string]*zebra.IPRouteBody
+	// rdRoutes holds the installed routes whose VRF has been resolved by
+	// their RD, keyed the same way as installed.
+	rdRoutes map[string]rdRoute
+	// imported maps the prefix of every route imported from Zebra to its
+	// nexthop and metric, when ImportDedup is enabled.
+	imported map[string]importedRoute
+	// interfaces maps the index of every interface known from Zebra to
+	// its state, when InvalidateOnInterfaceDown is enabled or
+	// CrossVrfNexthopAction is RESOLVE or REJECT.
+	interfaces map[uint32]*zebraInterface
+	// failedNexthops holds the nexthops whose registration could not be
+	// sent, keyed by VRF id and nexthop, until they are sent again.
+	failedNexthops map[string]failedNexthop
+	// nexthopLabels maps the nexthops (see nexthopKey) Zebra resolves
+	// with labels to them, when NexthopLabelResolution is enabled.
+	nexthopLabels map[string][]uint32
+	// savedRoutes holds the routes loaded from InstallStateFile until
+	// they are reconciled, and installStateDirty tells whether installed
+	// has changed since the state was last saved.
+	savedRoutes       map[string]savedRoute
+	installStateDirty bool
+	// synced is set once the paths have been dumped to Zebra, the saved
+	// routes not installed again being withdrawn from then on.
+	synced bool
+	// tasks are run by loop(), which owns the maps above.
+	tasks chan func()
+	// ready is closed once the watcher used by SendPaths is set up.
+	ready chan struct{}
+	// dumpedVrfs maps the name of every VRF whose paths have been sent to
+	// its id, and vrfRds the name of every VRF seen to its RD.
+	dumpedVrfs   map[string]uint32
+	vrfRds       map[string]string
+	dumpedVrfsMu sync.Mutex
+	// reconnects and disconnected are accessed atomically because they
+	// are read outside of loop().
+	reconnects   uint32
+	disconnected int32
+	panics       uint32
+	// vrfRegistrations maps the id of every VRF registered with Zebra, or
+	// whose unregistration failed to be sent, to its state.
+	vrfRegistrations   map[uint32]vrfRegistration
+	vrfRegistrationsMu sync.Mutex
+	// unsupportedPaths counts the paths of every address family which
+	// cannot be installed into Zebra, unless UnsupportedFamilyAction is
+	// IGNORE, and unsupportedPathsLogged holds when they were last warned
+	// about.
+	unsupportedPaths       map[bgp.RouteFamily]uint64
+	unsupportedPathsLogged map[bgp.RouteFamily]time.Time
+	unsupportedPathsMu     sync.Mutex
+	// redistributed holds the route types Zebra has been asked to
+	// redistribute, see AddRedistribute, and redistributedVrfs the VRFs
+	// they are redistributed from, see RedistributeAllVrfs.
+	redistributed     map[zebra.ROUTE_TYPE]bool
+	redistributedVrfs map[uint32]bool
+	redistributedMu   sync.Mutex
+	// endpoint is the URL of the additional Zebra instance the client is
+	// connected to, see AdditionalUrlList. It is empty for the client of
+	// the configured URL.
+	endpoint string
+	// metrics is nil unless MetricsEnabled is set.
+	metrics *zebraMetrics
+}
+
+// vrfRegistration is the state of a VRF towards Zebra: registered tells
+// whether it is to be registered or unregistered, and sent whether the
+// corresponding message has been sent.
+type vrfRegistration struct {
+	registered bool
+	sent       bool
+}
+
+type failedNexthop struct {
+	vrfId   uint32
+	nexthop *zebra.RegisteredNexthop
+}
+
+func failedNexthopKey(vrfId uint32, nexthop *zebra.RegisteredNexthop) string {
+	key := fmt.Sprintf("%d:%s", vrfId, nexthop.Prefix)
+	if nexthop.Zone != "" {
+		key += "%" + nexthop.Zone
+	}
+	return key
+}
+
+type rdRoute struct {
+	vrfId uint32
+	rd    string
+}
+
+// zebraInterface is the state of an interface and its connected prefixes.
+type zebraInterface struct {
+	up       bool
+	vrfId    uint32
+	prefixes []*net.IPNet
+}
+
+type importedRoute struct {
+	nexthop string
+	metric  uint32
+}
+
+type zebraClientState struct {
+	Connected         bool   `json:"connected"`
+	Reconnects        uint32 `json:"reconnects"`
+	Panics            uint32 `json:"panics"`
+	NexthopCacheSize  int    `json:"nexthop-cache-size"`
+	ScheduledPathSize int    `json:"scheduled-path-size"`
+	InstalledRouteNum int    `json:"installed-route-num"`
+}
+
+// ZebraClientStats counts the messages exchanged with Zebra since the
+// client was started. The counters survive reconnections.
+type ZebraClientStats struct {
+	IPRoutesSent      uint64 `json:"ip-routes-sent"`
+	IPRoutesWithdrawn uint64 `json:"ip-routes-withdrawn"`
+	// NexthopRegistersSent includes the unregistrations.
+	NexthopRegistersSent   uint64 `json:"nexthop-registers-sent"`
+	NexthopUpdatesReceived uint64 `json:"nexthop-updates-received"`
+	// NexthopUpdatePaths counts the paths bound to the nexthops of the
+	// NEXTHOP_UPDATE messages received.
+	NexthopUpdatePaths uint64 `json:"nexthop-update-paths"`
+	ReconnectCount     uint32 `json:"reconnect-count"`
+	// UnsupportedFamilyPaths counts the paths not installed for their
+	// address family, unless UnsupportedFamilyAction is IGNORE.
+	UnsupportedFamilyPaths map[string]uint64 `json:"unsupported-family-paths,omitempty"`
+}
+
+// Stats returns the message counters of the client. It is safe to call
+// while loop() runs.
+func (z *zebraClient) Stats() ZebraClientStats {
+	stats := ZebraClientStats{
+		IPRoutesSent:           atomic.LoadUint64(&z.ipRoutesSent),
+		IPRoutesWithdrawn:      atomic.LoadUint64(&z.ipRoutesWithdrawn),
+		NexthopRegistersSent:   atomic.LoadUint64(&z.nexthopRegistersSent),
+		NexthopUpdatesReceived: atomic.LoadUint64(&z.nexthopUpdatesReceived),
+		NexthopUpdatePaths:     atomic.LoadUint64(&z.nexthopUpdatePaths),
+		ReconnectCount:         atomic.LoadUint32(&z.reconnects),
+	}
+	z.unsupportedPathsMu.Lock()
+	defer z.unsupportedPathsMu.Unlock()
+	if len(z.unsupportedPaths) > 0 {
+		stats.UnsupportedFamilyPaths = make(map[string]uint64, len(z.unsupportedPaths))
+		for family, n := range z.unsupportedPaths {
+			stats.UnsupportedFamilyPaths[family.String()] = n
+		}
+	}
+	return stats
+}
+
+// ZebraNexthop is a nexthop tracked by the zebra client. Registered tells
+// whether it has been registered to Zebra or is waiting to be sent again.
+type ZebraNexthop struct {
+	Address    net.IP   `json:"address"`
+	Family     string   `json:"family"`
+	Zone       string   `json:"zone,omitempty"`
+	VrfIds     []uint32 `json:"vrf-ids"`
+	Registered bool     `json:"registered"`
+}
+
+// Nexthops returns the nexthops tracked by the client sorted by family,
+// address and zone. They are read by loop(), so that it is safe to call
+// while it runs.
+func (z *zebraClient) Nexthops() ([]ZebraNexthop, error) {
+	if z.nhtManager == nil {
+		return nil, fmt.Errorf("nexthop tracking is not enabled")
+	}
+	var l []ZebraNexthop
+	done := make(chan struct{})
+	snapshot := func() {
+		defer close(done)
+		family := func(f uint16) string {
+			if f == syscall.AF_INET {
+				return "ipv4"
+			}
+			return "ipv6"
+		}
+		for _, c := range z.nhtManager.nexthopCache {
+			l = append(l, ZebraNexthop{
+				Address:    c.prefix,
+				Family:     family(c.family),
+				Zone:       c.zone,
+				VrfIds:     append([]uint32(nil), c.vrfIds...),
+				Registered: true,
+			})
+		}
+		failed := make(map[string]int)
+		for _, f := range z.failedNexthops {
+			key := registeredNexthopKey(f.nexthop, false)
+			if i, ok := failed[key]; ok {
+				l[i].VrfIds = appendVrfId(l[i].VrfIds, f.vrfId)
+				continue
+			}
+			failed[key] = len(l)
+			l = append(l, ZebraNexthop{
+				Address: f.nexthop.Prefix,
+				Family:  family(f.nexthop.Family),
+				Zone:    f.nexthop.Zone,
+				VrfIds:  []uint32{f.vrfId},
+			})
+		}
+	}
+	select {
+	case z.tasks <- snapshot:
+	case <-z.dead:
+		return nil, fmt.Errorf("zebra client is not running")
+	}
+	<-done
+	sort.Slice(l, func(i, j int) bool {
+		if l[i].Family != l[j].Family {
+			return l[i].Family < l[j].Family
+		}
+		if c := bytes.Compare(l[i].Address.To16(), l[j].Address.To16()); c != 0 {
+			return c < 0
+		}
+		if l[i].Zone != l[j].Zone {
+			return l[i].Zone < l[j].Zone
+		}
+		return !l[i].Registered && l[j].Registered
+	})
+	for _, n := range l {
+		sort.Slice(n.VrfIds, func(i, j int) bool { return n.VrfIds[i] < n.VrfIds[j] })
+	}
+	return l, nil
+}
+
+// zebraMetrics holds the metrics of the zebra client exported by
+// WriteZebraMetrics() when MetricsEnabled is set. It is kept by the server
+// across reconnections and only fed by the client of the configured URL.
+// The gauges and the reconnection counter are accessed atomically.
+type zebraMetrics struct {
+	connected          int32
+	reconnects         uint32
+	registeredNexthops int64
+	scheduledPaths     int64
+	penalty            int64
+	// messages counts the messages sent to and received from Zebra by
+	// direction and type.
+	messages   map[zebraMessageMetric]uint64
+	messagesMu sync.Mutex
+}
+
+type zebraMessageMetric struct {
+	direction string
+	typ       string
+}
+
+// All the methods of zebraMetrics do nothing on a nil receiver, which is
+// the case when the metrics are disabled.
+
+func (m *zebraMetrics) setConnected(connected bool) {
+	if m == nil {
+		return
+	}
+	var v int32
+	if connected {
+		v = 1
+	}
+	atomic.StoreInt32(&m.connected, v)
+}
+
+func (m *zebraMetrics) reconnected() {
+	if m == nil {
+		return
+	}
+	atomic.AddUint32(&m.reconnects, 1)
+}
+
+func (m *zebraMetrics) setRegisteredNexthops(n int) {
+	if m == nil {
+		return
+	}
+	atomic.StoreInt64(&m.registeredNexthops, int64(n))
+}
+
+func (m *zebraMetrics) setScheduled(paths, penalty int) {
+	if m == nil {
+		return
+	}
+	atomic.StoreInt64(&m.scheduledPaths, int64(paths))
+	atomic.StoreInt64(&m.penalty, int64(penalty))
+}
+
+func (m *zebraMetrics) countMessage(direction, typ string) {
+	if m == nil {
+		return
+	}
+	m.messagesMu.Lock()
+	defer m.messagesMu.Unlock()
+	if m.messages == nil {
+		m.messages = make(map[zebraMessageMetric]uint64)
+	}
+	m.messages[zebraMessageMetric{direction: direction, typ: typ}]++
+}
+
+// write writes the metrics in the Prometheus text format.
+func (m *zebraMetrics) write(w io.Writer) error {
+	gauges := []struct {
+		name, help string
+		value      int64
+	}{
+		{"gobgp_zebra_connected", "Whether the zebra client is connected to zebra.", int64(atomic.LoadInt32(&m.connected))},
+		{"gobgp_zebra_registered_nexthops", "Number of nexthops registered for tracking.", atomic.LoadInt64(&m.registeredNexthops)},
+		{"gobgp_zebra_scheduled_paths", "Number of nexthops whose paths are scheduled to be updated.", atomic.LoadInt64(&m.scheduledPaths)},
+		{"gobgp_zebra_nexthop_penalty", "Current damping penalty of the nexthop tracking updates.", atomic.LoadInt64(&m.penalty)},
+	}
+	for _, g := range gauges {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", g.name, g.help, g.name, g.name, g.value); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "# HELP gobgp_zebra_reconnects_total Number of reconnections to zebra.\n# TYPE gobgp_zebra_reconnects_total counter\ngobgp_zebra_reconnects_total %d\n", atomic.LoadUint32(&m.reconnects)); err != nil {
+		return err
+	}
+	m.messagesMu.Lock()
+	keys := make([]zebraMessageMetric, 0, len(m.messages))
+	for k := range m.messages {
+		keys = append(keys, k)
+	}
+	counts := make([]uint64, len(keys))
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].direction != keys[j].direction {
+			return keys[i].direction < keys[j].direction
+		}
+		return keys[i].typ < keys[j].typ
+	})
+	for i, k := range keys {
+		counts[i] = m.messages[k]
+	}
+	m.messagesMu.Unlock()
+	if _, err := fmt.Fprint(w, "# HELP gobgp_zebra_messages_total Number of messages exchanged with zebra.\n# TYPE gobgp_zebra_messages_total counter\n"); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if _, err := fmt.Fprintf(w, "gobgp_zebra_messages_total{direction=%q,type=%q} %d\n", k.direction, k.typ, counts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// meteredZebraConn is a zebraConn counting the messages sent into metrics.
+type meteredZebraConn struct {
+	zebraConn
+	metrics *zebraMetrics
+}
+
+func (c *meteredZebraConn) SendIPRoute(vrfId uint32, body *zebra.IPRouteBody, isWithdraw bool) error {
+	typ := "IPV4_ROUTE"
+	if body.Prefix.To4() == nil {
+		typ = "IPV6_ROUTE"
+	}
+	if isWithdraw {
+		typ += "_DELETE"
+	} else {
+		typ += "_ADD"
+	}
+	c.metrics.countMessage("sent", typ)
+	return c.zebraConn.SendIPRoute(vrfId, body, isWithdraw)
+}
+
+func (c *meteredZebraConn) SendNexthopRegister(vrfId uint32, body *zebra.NexthopRegisterBody, isWithdraw bool) error {
+	typ := "NEXTHOP_REGISTER"
+	if isWithdraw {
+		typ = "NEXTHOP_UNREGISTER"
+	}
+	c.metrics.countMessage("sent", typ)
+	return c.zebraConn.SendNexthopRegister(vrfId, body, isWithdraw)
+}
+
+func (c *meteredZebraConn) SendCommand(command zebra.API_TYPE, vrfId uint32, body zebra.Body) error {
+	c.metrics.countMessage("sent", command.String())
+	return c.zebraConn.SendCommand(command, vrfId, body)
+}
+
+func (c *meteredZebraConn) SendRedistribute(t zebra.ROUTE_TYPE, vrfId uint32) error {
+	c.metrics.countMessage("sent", "REDISTRIBUTE_ADD")
+	return c.zebraConn.SendRedistribute(t, vrfId)
+}
+
+func (c *meteredZebraConn) SendRedistributeDelete(t zebra.ROUTE_TYPE, vrfId uint32) error {
+	c.metrics.countMessage("sent", "REDISTRIBUTE_DELETE")
+	return c.zebraConn.SendRedistributeDelete(t, vrfId)
+}
+
+func (c *meteredZebraConn) SendInterfaceAdd() error {
+	c.metrics.countMessage("sent", "INTERFACE_ADD")
+	return c.zebraConn.SendInterfaceAdd()
+}
+
+type zebraClientSnapshot struct {
+	Config config.ZebraConfig `json:"config"`
+	State  zebraClientState   `json:"state"`
+}
+
+// Snapshot returns the effective configuration and the current state of
+// the client serialized as JSON. The state is read by loop(), so that it
+// is safe to call while it runs. Once the client is stopped, only the
+// counters are left in the state.
+func (z *zebraClient) Snapshot() ([]byte, error) {
+	state := zebraClientState{
+		Reconnects: atomic.LoadUint32(&z.reconnects),
+		Panics:     atomic.LoadUint32(&z.panics),
+	}
+	done := make(chan struct{})
+	snapshot := func() {
+		defer close(done)
+		state.Connected = atomic.LoadInt32(&z.disconnected) == 0
+		state.InstalledRouteNum = len(z.installed)
+		if z.nhtManager != nil {
+			state.NexthopCacheSize = len(z.nhtManager.nexthopCache)
+			state.ScheduledPathSize = int(atomic.LoadInt64(&z.nhtManager.scheduledPathNum))
+		}
+	}
+	select {
+	case z.tasks <- snapshot:
+		<-done
+	case <-z.dead:
+	}
+	return json.Marshal(&zebraClientSnapshot{
+		Config: z.config,
+		State:  state,
+	})
 }
 
+// stop terminates loop(). It is also called once the context of the
+// client is cancelled, so that the goroutines waiting on dead give up.
 func (z *zebraClient) stop() {
-	close(z.dead)
+	z.stopOnce.Do(func() { close(z.dead) })
+}
+
+// done returns the channel closed once the context of the client is
+// cancelled. It is nil, thus never ready, if there is no context.
+func (z *zebraClient) done() <-chan struct{} {
+	if z.ctx == nil {
+		return nil
+	}
+	return z.ctx.Done()
 }
 
-func (z *zebraClient) SendPaths(paths []*table.Path, vrfs map[string]uint16) {
+// SendPaths queues the given paths to be processed by loop(). It writes to
+// the channel of the watcher directly and blocks while the channel is full
+// (see WatchChannelSize in the config), which throttles the producer to
+// the speed of loop(). As loop() calls into the server while processing
+// events, this must not be called from the server goroutine.
+func (z *zebraClient) SendPaths(paths []*table.Path, vrfs map[string]uint32) {
 	if z.watcher == nil {
 		return
 	}
@@ -508,234 +1920,1999 @@ func (z *zebraClient) SendPaths(paths []*table.Path, vrfs map[string]uint16) {
 	}
 }
 
-func (z *zebraClient) SendVrfRegister(vrfId uint32) {
-	buf := make([]byte, 4)
-	binary.BigEndian.PutUint32(buf, vrfId)
-	body := &zebra.UnknownBody{}
-	body.Data = buf
-	z.client.SendCommand(zebra.VRF_REGISTER, zebra.VRF_DEFAULT, body)
+// queuePaths queues the given paths to be processed by loop() after the
+// events already notified to its watcher. Unlike SendPaths, it does not
+// block and has to be called from the server goroutine, so that the paths
+// fetched from the RIB there are processed before their later updates.
+func (z *zebraClient) queuePaths(paths []*table.Path, vrfs map[string]uint32) {
+	if z.watcher == nil {
+		return
+	}
+	z.watcher.notify(&WatchEventUpdate{
+		PathList: paths,
+		Vrf:      vrfs,
+	})
+	z.watcher.notify(&WatchEventBestPath{
+		PathList: paths,
+		Vrf:      vrfs,
+	})
 }
 
-func (z *zebraClient) SendVrfUnregister(vrfId uint32) {
-	buf := make([]byte, 4)
-	binary.BigEndian.PutUint32(buf, vrfId)
-	body := &zebra.UnknownBody{}
-	body.Data = buf
-	z.client.SendCommand(zebra.VRF_UNREGISTER, zebra.VRF_DEFAULT, body)
+// ipRouteKey returns the key of the route of body in installed. The
+// family is part of it, so that an IPv4 route and an IPv6 one whose
+// prefix net.IP prints the same way, e.g. an IPv4-mapped one, are kept
+// apart.
+func ipRouteKey(vrfId uint32, body *zebra.IPRouteBody) string {
+	return fmt.Sprintf("%d:%s:%s/%d:%d", vrfId, ipRouteFamily(body), body.Prefix, body.PrefixLength, body.PathId)
 }
 
-func (z *zebraClient) reconnect() {
-	for {
-		time.Sleep(time.Second * 3)
-		err := z.server.StartZebraClient(&z.config)
-		if err == nil {
-			return
-		}
+// ipRouteFamily returns the family of the route of body, which is IPv4
+// for the 4 bytes prefixes newIPRouteBody() builds for IPv4 routes.
+func ipRouteFamily(body *zebra.IPRouteBody) string {
+	if len(body.Prefix) == net.IPv4len {
+		return "ipv4"
 	}
+	return "ipv6"
 }
 
-func NlriPrefix(str string) string {
-	nlri := strings.Split(str, ":")
-	return nlri[len(nlri)-1]
+func hasVrfId(ids []uint32, id uint32) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
 }
 
-func NlriRD(str string) string {
-	nlri := strings.Split(str, ":")
-	if len(nlri) > 1 {
-		return fmt.Sprintf("%s:%s", nlri[0], nlri[1])
+func appendVrfId(ids []uint32, id uint32) []uint32 {
+	for _, i := range ids {
+		if i == id {
+			return ids
+		}
 	}
-	return ""
+	return append(ids, id)
 }
 
-func (z *zebraClient) loop() {
-	w := z.server.Watch([]WatchOption{
-		WatchBestPath(true),
-		WatchPostUpdate(true),
-	}...)
-	z.watcher = w
-	defer w.Stop()
-
-	if z.nhtManager != nil {
-		go z.nhtManager.loop()
-		defer z.nhtManager.stop()
+// installTargets returns the VRF/table ids into which a route destined for
+// vrfId is programmed. Routes of the default VRF are also installed into
+// every table listed in InstallTableIdList.
+func (z *zebraClient) installTargets(vrfId uint32) []uint32 {
+	ids := []uint32{vrfId}
+	if vrfId != zebra.VRF_DEFAULT {
+		return ids
+	}
+	for _, id := range z.config.InstallTableIdList {
+		ids = appendVrfId(ids, uint32(id))
 	}
+	return ids
+}
 
-	go func() {
-		if z.server.globalRib == nil {
-			fmt.Println("z.server.globalRib is not ready")
-			return
+// trackIPRoute updates the install state of the given route and returns
+// the VRF/table ids the message has to be sent to. A withdraw is sent to
+// every id the route was installed into, even if InstallTableIdList has
+// changed since.
+func (z *zebraClient) trackIPRoute(vrfId uint32, body *zebra.IPRouteBody, isWithdraw bool) []uint32 {
+	key := ipRouteKey(vrfId, body)
+	if isWithdraw {
+		ids, ok := z.installed[key]
+		if !ok {
+			return z.installTargets(vrfId)
 		}
+		delete(z.installed, key)
+		delete(z.installedBody, key)
+		return ids
+	}
+	ids := z.installTargets(vrfId)
+	for _, id := range z.installed[key] {
+		ids = appendVrfId(ids, id)
+	}
+	z.installed[key] = ids
+	z.installedBody[key] = body
+	z.installStateDirty = true
+	return ids
+}
 
-		globalVrfs := z.server.GetVrf()
-		for _, vrf := range globalVrfs {
-			tbl, _ := z.server.globalRib.FetchExistingVrf(vrf.Name)
-			if tbl != nil {
-				for _, dst := range tbl.GetDestinations() {
-					paths := dst.GetAllKnownPathList()
-					m := make(map[string]uint16)
-					for _, p := range paths {
-						m[p.GetNlri().String()] = uint16(vrf.Id)
-					}
-					z.SendPaths(paths, m)
+// savedRoute is a route installed into Zebra as saved into
+// InstallStateFile.
+type savedRoute struct {
+	VrfIds []uint32           `json:"vrf-ids"`
+	Body   *zebra.IPRouteBody `json:"body"`
+}
+
+// installStateSaveInterval is the interval at which the routes installed
+// into Zebra are saved into InstallStateFile if they have changed.
+const installStateSaveInterval = 5 * time.Second
+
+// loadInstallState returns the routes saved into the given file, or nil
+// if it does not exist.
+func loadInstallState(file string) (map[string]savedRoute, error) {
+	buf, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	saved := make(map[string]savedRoute)
+	if err := json.Unmarshal(buf, &saved); err != nil {
+		return nil, err
+	}
+	routes := make(map[string]savedRoute, len(saved))
+	for key, r := range saved {
+		l := strings.SplitN(key, ":", 3)
+		vrfId, err := strconv.ParseUint(l[0], 10, 32)
+		if r.Body == nil || err != nil {
+			continue
+		}
+		// JSON decodes every address in its 16 bytes form. The key tells
+		// the family of the route, unless it has been saved before the
+		// key included it.
+		isV4 := r.Body.Prefix.To4() != nil
+		if len(l) == 3 && (l[1] == "ipv4" || l[1] == "ipv6") {
+			isV4 = l[1] == "ipv4" && isV4
+		}
+		if isV4 {
+			r.Body.Prefix = r.Body.Prefix.To4()
+			for i, nh := range r.Body.Nexthops {
+				if v4 := nh.To4(); v4 != nil {
+					r.Body.Nexthops[i] = v4
 				}
 			}
 		}
-	}()
+		routes[ipRouteKey(uint32(vrfId), r.Body)] = r
+	}
+	return routes, nil
+}
 
-	for {
-		select {
-		case <-z.dead:
-			return
-		case msg := <-z.client.Receive():
-			if msg == nil {
-				z.server.zclient = nil
-				go z.reconnect()
+// saveInstallState writes the routes installed into Zebra, along with the
+// saved ones not reconciled yet, to InstallStateFile.
+func (z *zebraClient) saveInstallState() {
+	if z.config.InstallStateFile == "" {
+		return
+	}
+	routes := make(map[string]savedRoute, len(z.installed)+len(z.savedRoutes))
+	for key, r := range z.savedRoutes {
+		routes[key] = r
+	}
+	for key, ids := range z.installed {
+		routes[key] = savedRoute{VrfIds: ids, Body: z.installedBody[key]}
+	}
+	buf, err := json.Marshal(routes)
+	if err == nil {
+		// Replace the file at once, so that it is never left incomplete.
+		tmp := z.config.InstallStateFile + ".tmp"
+		if err = ioutil.WriteFile(tmp, buf, 0600); err == nil {
+			err = os.Rename(tmp, z.config.InstallStateFile)
+		}
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"File":  z.config.InstallStateFile,
+			"Error": err,
+		}).Warn("failed to save the routes installed into zebra")
+		return
+	}
+	z.installStateDirty = false
+}
+
+// reconcileInstallState withdraws the routes loaded from InstallStateFile
+// which have not been installed again since. It is called by loop() once
+// the routes to be installed have been sent to Zebra.
+func (z *zebraClient) reconcileInstallState() {
+	if z.savedRoutes == nil {
+		return
+	}
+	withdrawn := 0
+	for key, r := range z.savedRoutes {
+		for _, id := range r.VrfIds {
+			if hasVrfId(z.installed[key], id) {
+				continue
+			}
+			if err := z.client.SendIPRoute(id, r.Body, true); err == nil {
+				withdrawn++
+				atomic.AddUint64(&z.ipRoutesWithdrawn, 1)
+			}
+		}
+	}
+	log.WithFields(log.Fields{
+		"Topic":     "Zebra",
+		"Saved":     len(z.savedRoutes),
+		"Withdrawn": withdrawn,
+	}).Info("reconciled the routes installed into zebra before restart")
+	z.savedRoutes = nil
+	z.saveInstallState()
+}
+
+// isSavedIPRoute returns true if the given route has been installed into
+// the VRF, or table, of id before GoBGP restarted and is unchanged, in
+// which case it is not sent again when GracefulRestartReconcile is
+// enabled.
+func (z *zebraClient) isSavedIPRoute(vrfId, id uint32, body *zebra.IPRouteBody) bool {
+	if !z.config.GracefulRestartReconcile || z.savedRoutes == nil {
+		return false
+	}
+	r, ok := z.savedRoutes[ipRouteKey(vrfId, body)]
+	if !ok || !hasVrfId(r.VrfIds, id) {
+		return false
+	}
+	version := z.client.MessageVersion()
+	saved, err := r.Body.Serialize(version)
+	if err != nil {
+		return false
+	}
+	b, err := body.Serialize(version)
+	return err == nil && bytes.Equal(saved, b)
+}
+
+// deferReconcile postpones the withdraws of the saved routes not installed
+// again until the graceful restart of GoBGP finishes, see restartFinished,
+// or GracefulRestartReconcileTimeout expires.
+func (z *zebraClient) deferReconcile() {
+	timeout := 360 * time.Second
+	if z.config.GracefulRestartReconcileTimeout > 0 {
+		timeout = time.Duration(z.config.GracefulRestartReconcileTimeout) * time.Second
+	}
+	log.WithFields(log.Fields{
+		"Topic":   "Zebra",
+		"Saved":   len(z.savedRoutes),
+		"Timeout": timeout,
+	}).Info("graceful restart in progress, deferring the reconciliation of the routes installed into zebra")
+	time.AfterFunc(timeout, func() {
+		z.reconcileLater("timed out waiting for graceful restart to finish")
+	})
+}
+
+// restartFinished is called once no neighbor is restarting gracefully
+// anymore, to reconcile the routes installed into Zebra before GoBGP
+// restarted.
+func (z *zebraClient) restartFinished() {
+	if !z.config.GracefulRestartReconcile {
+		return
+	}
+	go z.reconcileLater("graceful restart finished")
+}
+
+func (z *zebraClient) reconcileLater(reason string) {
+	reconcile := func() {
+		if !z.synced || z.savedRoutes == nil {
+			return
+		}
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+		}).Infof("%s, reconciling the routes installed into zebra", reason)
+		z.reconcileInstallState()
+	}
+	select {
+	case z.tasks <- reconcile:
+	case <-z.dead:
+	}
+}
+
+// shutdown unregisters the nexthops tracked by Zebra and, if configured
+// to, sends a delete for every route installed into it. The connection is
+// closed once they have been written, waiting at most ShutdownDrainTimeout
+// seconds for it before closing it regardless, so that an unresponsive
+// Zebra cannot hang the shutdown.
+func (z *zebraClient) shutdown() {
+	var nexthops map[uint32]*zebra.NexthopRegisterBody
+	if z.nhtManager != nil {
+		nexthops = z.nhtManager.unregisterAll()
+	}
+	type route struct {
+		vrfId uint32
+		body  *zebra.IPRouteBody
+	}
+	routes := make([]route, 0, len(z.installedBody))
+	if z.config.WithdrawOnShutdown {
+		for key, body := range z.installedBody {
+			for _, id := range z.installed[key] {
+				routes = append(routes, route{vrfId: id, body: body})
+			}
+		}
+		z.installed = make(map[string][]uint32)
+		z.installedBody = make(map[string]*zebra.IPRouteBody)
+	}
+	z.saveInstallState()
+
+	timeout := 5 * time.Second
+	if z.config.ShutdownDrainTimeout > 0 {
+		timeout = time.Duration(z.config.ShutdownDrainTimeout) * time.Second
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for vrfId, b := range nexthops {
+			if err := z.client.SendNexthopRegister(vrfId, b, true); err == nil {
+				atomic.AddUint64(&z.nexthopRegistersSent, 1)
+			}
+		}
+		for _, r := range routes {
+			if err := z.client.SendIPRoute(r.vrfId, r.body, true); err == nil {
+				atomic.AddUint64(&z.ipRoutesWithdrawn, 1)
+			}
+		}
+		z.client.Close()
+	}()
+	select {
+	case <-done:
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+		}).Infof("unregistered nexthops of %d VRFs and withdrew %d routes on shutdown", len(nexthops), len(routes))
+	case <-time.After(timeout):
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+		}).Warnf("timed out unregistering nexthops of %d VRFs and withdrawing %d routes on shutdown", len(nexthops), len(routes))
+		z.client.Abort()
+	}
+}
+
+func isIPv4Nexthop(body *zebra.IPRouteBody) bool {
+	return len(body.Nexthops) > 0 && body.Nexthops[0].To4() != nil
+}
+
+// staleIPRoute returns the installed version of the given route if its
+// nexthop address family differs from the one of body, e.g. when an RFC
+// 5549 IPv6 nexthop replaces an IPv4 one. Zebra would otherwise keep the
+// old nexthop in the kernel, so it has to be withdrawn first.
+func (z *zebraClient) staleIPRoute(vrfId uint32, body *zebra.IPRouteBody) *zebra.IPRouteBody {
+	old, ok := z.installedBody[ipRouteKey(vrfId, body)]
+	if !ok || len(old.Nexthops) == 0 || len(body.Nexthops) == 0 {
+		return nil
+	}
+	if isIPv4Nexthop(old) == isIPv4Nexthop(body) {
+		return nil
+	}
+	return old
+}
+
+// withInstalledTag returns body with the route tag the route was installed
+// with, as Zebra only deletes the route carrying it. body is left
+// untouched.
+func (z *zebraClient) withInstalledTag(vrfId uint32, body *zebra.IPRouteBody) *zebra.IPRouteBody {
+	old, ok := z.installedBody[ipRouteKey(vrfId, body)]
+	if !ok || old.Tag == body.Tag {
+		return body
+	}
+	flag := tagFlag(z.client.MessageVersion())
+	b := *body
+	b.Tag = old.Tag
+	b.Message = b.Message&^flag | old.Message&flag
+	return &b
+}
+
+func (z *zebraClient) sendIPRoute(vrfId uint32, body *zebra.IPRouteBody, isWithdraw bool) {
+	if max := zebra.MaxVrfId(z.client.MessageVersion()); vrfId > max {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Key":   fmt.Sprintf("%s/%d", body.Prefix, body.PrefixLength),
+			"VrfId": vrfId,
+		}).Errorf("VRF id exceeds %d which message version %d can carry, skipping route", max, z.client.MessageVersion())
+		return
+	}
+	if isWithdraw {
+		body = z.withInstalledTag(vrfId, body)
+	} else {
+		body = z.withNexthopLabels(body)
+		if b := z.withCrossVrfNexthops(vrfId, body); b != nil {
+			body = b
+		} else {
+			// Withdraw the route if it has been installed with another
+			// nexthop.
+			if _, ok := z.installedBody[ipRouteKey(vrfId, body)]; ok {
+				z.sendIPRoute(vrfId, body, true)
+			}
+			return
+		}
+		if old := z.staleIPRoute(vrfId, body); old != nil {
+			log.WithFields(log.Fields{
+				"Topic":   "Zebra",
+				"Key":     fmt.Sprintf("%s/%d", body.Prefix, body.PrefixLength),
+				"Old":     old.Nexthops,
+				"Nexthop": body.Nexthops,
+			}).Debug("nexthop address family changed, withdrawing the old route")
+			z.sendIPRoute(vrfId, old, true)
+		}
+	}
+	for _, id := range z.trackIPRoute(vrfId, body, isWithdraw) {
+		if !isWithdraw && z.isSavedIPRoute(vrfId, id, body) {
+			continue
+		}
+		if err := z.client.SendIPRoute(id, body, isWithdraw); err != nil {
+			continue
+		}
+		if isWithdraw {
+			atomic.AddUint64(&z.ipRoutesWithdrawn, 1)
+		} else {
+			atomic.AddUint64(&z.ipRoutesSent, 1)
+		}
+	}
+}
+
+// withNexthopLabels returns body with the labels its first nexthop is
+// resolved with pushed on top of its own labels, when NexthopLabelResolution
+// is enabled and the body has labels of its own.
+func (z *zebraClient) withNexthopLabels(body *zebra.IPRouteBody) *zebra.IPRouteBody {
+	if !z.config.NexthopLabelResolution || z.client.MessageVersion() < 5 || len(body.Labels) == 0 || len(body.Nexthops) == 0 {
+		return body
+	}
+	family, nexthop := uint16(syscall.AF_INET6), body.Nexthops[0].To16()
+	if body.Prefix.To4() != nil && body.Nexthops[0].To4() != nil {
+		family, nexthop = syscall.AF_INET, body.Nexthops[0].To4()
+	}
+	underlay := z.nexthopLabels[nexthopKey(family, nexthop)]
+	if len(underlay) == 0 {
+		return body
+	}
+	b := *body
+	b.Labels = make([]uint32, 0, len(underlay)+len(body.Labels))
+	b.Labels = append(append(b.Labels, underlay...), body.Labels...)
+	return &b
+}
+
+// updateNexthopLabels records the labels Zebra resolves the nexthop of
+// body with, which are used by withNexthopLabels().
+func (z *zebraClient) updateNexthopLabels(body *zebra.NexthopUpdateBody) {
+	key := nexthopKey(body.Family, body.Prefix)
+	if len(body.Nexthops) == 0 || len(body.Nexthops[0].Labels) == 0 {
+		delete(z.nexthopLabels, key)
+		return
+	}
+	log.WithFields(log.Fields{
+		"Topic":   "Zebra",
+		"Nexthop": body.Prefix,
+		"Labels":  body.Nexthops[0].Labels,
+	}).Debug("nexthop resolved with labels")
+	z.nexthopLabels[key] = body.Nexthops[0].Labels
+}
+
+// sendNexthopRegister sends body and, if it registers nexthops, only
+// caches them once sent. The nexthops which failed to be registered are
+// sent again by retryNexthopRegisters().
+func (z *zebraClient) sendNexthopRegister(vrfId uint32, body *zebra.NexthopRegisterBody, isUnregister bool) {
+	err := z.client.SendNexthopRegister(vrfId, body, isUnregister)
+	if err == nil {
+		atomic.AddUint64(&z.nexthopRegistersSent, 1)
+	}
+	for _, nh := range body.Nexthops {
+		key := failedNexthopKey(vrfId, nh)
+		switch {
+		case isUnregister:
+			delete(z.failedNexthops, key)
+		case err != nil:
+			if _, ok := z.failedNexthops[key]; !ok {
+				log.WithFields(log.Fields{
+					"Topic":   "Zebra",
+					"VrfId":   vrfId,
+					"Nexthop": nh.Prefix,
+					"Error":   err,
+				}).Warn("failed to register nexthop, going to retry")
+			}
+			z.failedNexthops[key] = failedNexthop{vrfId: vrfId, nexthop: nh}
+		default:
+			delete(z.failedNexthops, key)
+			if z.nhtManager != nil {
+				z.nhtManager.registerNexthop(vrfId, nh)
+			}
+		}
+	}
+}
+
+// retryNexthopRegisters sends again the nexthops which failed to be
+// registered, unless they have been registered meanwhile.
+func (z *zebraClient) retryNexthopRegisters() {
+	if len(z.failedNexthops) == 0 {
+		return
+	}
+	bodies := make(map[uint32]*zebra.NexthopRegisterBody)
+	for key, f := range z.failedNexthops {
+		if z.nhtManager != nil && z.nhtManager.isRegisteredNexthop(f.nexthop) {
+			delete(z.failedNexthops, key)
+			continue
+		}
+		b, ok := bodies[f.vrfId]
+		if !ok {
+			b = &zebra.NexthopRegisterBody{}
+			bodies[f.vrfId] = b
+		}
+		b.Nexthops = append(b.Nexthops, f.nexthop)
+	}
+	for vrfId, b := range bodies {
+		z.sendNexthopRegister(vrfId, b, false)
+	}
+}
+
+func (z *zebraClient) SendVrfRegister(vrfId uint32) {
+	z.vrfRegistrationsMu.Lock()
+	defer z.vrfRegistrationsMu.Unlock()
+	z.sendVrfRegister(vrfId, false)
+}
+
+func (z *zebraClient) SendVrfUnregister(vrfId uint32) {
+	z.vrfRegistrationsMu.Lock()
+	defer z.vrfRegistrationsMu.Unlock()
+	z.sendVrfRegister(vrfId, true)
+}
+
+// sendVrfRegister sends VRF_REGISTER or VRF_UNREGISTER for the given VRF
+// and records the outcome in vrfRegistrations, so that the messages which
+// failed to be sent are sent again by retryVrfRegisters(). The caller
+// must hold vrfRegistrationsMu.
+func (z *zebraClient) sendVrfRegister(vrfId uint32, isUnregister bool) {
+	command := zebra.VRF_REGISTER
+	if isUnregister {
+		command = zebra.VRF_UNREGISTER
+	}
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, vrfId)
+	body := &zebra.UnknownBody{}
+	body.Data = buf
+	err := z.client.SendCommand(command, zebra.VRF_DEFAULT, body)
+	switch {
+	case err != nil:
+		if r, ok := z.vrfRegistrations[vrfId]; !ok || r.sent || r.registered == isUnregister {
+			log.WithFields(log.Fields{
+				"Topic":   "Zebra",
+				"VrfId":   vrfId,
+				"Command": command.String(),
+				"Error":   err,
+			}).Warn("failed to send vrf registration, going to retry")
+		}
+		z.vrfRegistrations[vrfId] = vrfRegistration{registered: !isUnregister}
+	case isUnregister:
+		delete(z.vrfRegistrations, vrfId)
+	default:
+		z.vrfRegistrations[vrfId] = vrfRegistration{registered: true, sent: true}
+	}
+}
+
+// retryVrfRegisters sends again the VRF registrations which failed to be
+// sent.
+func (z *zebraClient) retryVrfRegisters() {
+	z.vrfRegistrationsMu.Lock()
+	defer z.vrfRegistrationsMu.Unlock()
+	ids := make([]uint32, 0)
+	for id, r := range z.vrfRegistrations {
+		if !r.sent {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		z.sendVrfRegister(id, !z.vrfRegistrations[id].registered)
+	}
+}
+
+// reconnectInterval returns the interval before the first attempt to
+// reconnect to Zebra.
+func (z *zebraClient) reconnectInterval() time.Duration {
+	if z.config.ReconnectInterval == 0 {
+		return time.Second
+	}
+	return time.Second * time.Duration(z.config.ReconnectInterval)
+}
+
+// reconnectBackoff returns the interval before the given attempt, counted
+// from zero, to reconnect to Zebra. It starts at reconnectInterval() and
+// is doubled after each failed attempt up to ReconnectMaxInterval.
+func (z *zebraClient) reconnectBackoff(attempt int) time.Duration {
+	max := time.Second * 30
+	if z.config.ReconnectMaxInterval > 0 {
+		max = time.Second * time.Duration(z.config.ReconnectMaxInterval)
+	}
+	interval := z.reconnectInterval()
+	if max < interval {
+		max = interval
+	}
+	for i := 0; i < attempt && interval < max; i++ {
+		interval *= 2
+	}
+	if interval > max {
+		return max
+	}
+	return interval
+}
+
+// reconnect retries to connect to Zebra until it succeeds, the client is
+// stopped or the server is. The interval between the attempts is picked
+// at random between the half and the whole of reconnectBackoff(), so that
+// the clients of a restarted Zebra do not reconnect in lockstep.
+func (z *zebraClient) reconnect() {
+	z.notifyState(ZEBRA_STATE_DOWN)
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for attempt := 0; ; attempt++ {
+		backoff := z.reconnectBackoff(attempt)
+		interval := backoff/2 + time.Duration(r.Int63n(int64(backoff/2)+1))
+		log.WithFields(log.Fields{
+			"Topic":    "Zebra",
+			"Interval": interval,
+		}).Debug("reconnecting to zebra")
+		select {
+		case <-time.After(interval):
+		case <-z.dead:
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+			}).Debug("zebra client stopped, giving up reconnecting")
+			return
+		case <-z.done():
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+			}).Debug("zebra client cancelled, giving up reconnecting")
+			return
+		}
+		// the config may have been updated since the client was started.
+		c := z.config
+		if err := z.server.mgmtOperation(func() error {
+			if z.server.zebraConfig != nil {
+				c = *z.server.zebraConfig
+			}
+			return nil
+		}, true); err != nil {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Error": err,
+			}).Debug("server stopped, giving up reconnecting to zebra")
+			return
+		}
+		z.notifyState(ZEBRA_STATE_RECONNECTING)
+		if err := z.server.mgmtOperation(func() error {
+			if z.endpoint == "" && z.server.zclient != nil {
+				return fmt.Errorf("already connected to Zebra")
+			}
+			return z.server.startZebraClient(z.ctx, &c, z.endpoint)
+		}, false); err != nil {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Error": err,
+			}).Debug("failed to reconnect to zebra")
+			continue
+		}
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+		}).Info("reconnected to zebra")
+		z.metrics.reconnected()
+		n := atomic.LoadUint32(&z.reconnects) + 1
+		z.server.mgmtOperation(func() error {
+			c := z.server.zclient
+			if z.endpoint != "" {
+				c = z.server.zebraEndpoints[z.endpoint]
+			}
+			if c != nil {
+				// The install state is not carried over, so the routes
+				// dumped to the new client are encoded for the version
+				// it has negotiated.
+				if z.client != nil && c.client.MessageVersion() != z.client.MessageVersion() {
+					log.WithFields(log.Fields{
+						"Topic": "Zebra",
+					}).Infof("zebra message version changed from %d to %d, sending the routes again", z.client.MessageVersion(), c.client.MessageVersion())
+				}
+				atomic.StoreUint32(&c.reconnects, n)
+				atomic.StoreUint64(&c.ipRoutesSent, atomic.LoadUint64(&z.ipRoutesSent))
+				atomic.StoreUint64(&c.ipRoutesWithdrawn, atomic.LoadUint64(&z.ipRoutesWithdrawn))
+				atomic.StoreUint64(&c.nexthopRegistersSent, atomic.LoadUint64(&z.nexthopRegistersSent))
+				atomic.StoreUint64(&c.nexthopUpdatesReceived, atomic.LoadUint64(&z.nexthopUpdatesReceived))
+			}
+			return nil
+		}, false)
+		z.notifyState(ZEBRA_STATE_UP)
+		return
+	}
+}
+
+// notifyState notifies WATCH_EVENT_TYPE_ZEBRA_STATE watchers of the
+// state of the connection to Zebra. It must not be called by loop(), which
+// the server may be waiting for.
+func (z *zebraClient) notifyState(state ZebraState) {
+	ev := &WatchEventZebraState{
+		State:     state,
+		Timestamp: time.Now(),
+	}
+	z.server.mgmtOperation(func() error {
+		z.server.notifyWatcher(WATCH_EVENT_TYPE_ZEBRA_STATE, ev)
+		return nil
+	}, false)
+}
+
+// NlriPrefix returns the prefix of nlri without the route distinguisher
+// of VPN NLRIs, e.g. "2001:db8::/64" for "100:1:2001:db8::/64".
+func NlriPrefix(nlri bgp.AddrPrefixInterface) string {
+	switch n := nlri.(type) {
+	case *bgp.LabeledVPNIPAddrPrefix:
+		return n.IPPrefix()
+	case *bgp.LabeledVPNIPv6AddrPrefix:
+		return n.IPPrefix()
+	}
+	return nlri.String()
+}
+
+// NlriRD returns the route distinguisher of the VPN NLRI nlri, or an empty
+// string for the other NLRIs.
+func NlriRD(nlri bgp.AddrPrefixInterface) string {
+	var rd bgp.RouteDistinguisherInterface
+	switch n := nlri.(type) {
+	case *bgp.LabeledVPNIPAddrPrefix:
+		rd = n.RD
+	case *bgp.LabeledVPNIPv6AddrPrefix:
+		rd = n.RD
+	}
+	if rd == nil {
+		return ""
+	}
+	return rd.String()
+}
+
+// dumpProgressInterval is the interval at which the progress of dumping
+// a VRF in several batches is logged.
+const dumpProgressInterval = 10 * time.Second
+
+// dumpVrf sends the paths of the given VRF to Zebra unless they have
+// already been sent since the VRF was added, and returns the number of
+// paths sent.
+func (z *zebraClient) dumpVrf(vrf *table.Vrf) int {
+	z.dumpedVrfsMu.Lock()
+	if z.dumpedVrfs == nil {
+		z.dumpedVrfs = make(map[string]uint32)
+		z.vrfRds = make(map[string]string)
+	}
+	if id, ok := z.dumpedVrfs[vrf.Name]; ok && id == vrf.Id {
+		z.dumpedVrfsMu.Unlock()
+		return 0
+	}
+	z.dumpedVrfs[vrf.Name] = vrf.Id
+	oldRd, newRd := z.vrfRds[vrf.Name], ""
+	if vrf.Rd != nil {
+		newRd = vrf.Rd.String()
+		z.vrfRds[vrf.Name] = newRd
+	}
+	z.dumpedVrfsMu.Unlock()
+
+	if oldRd != "" && newRd != "" && oldRd != newRd {
+		z.vrfRdChanged(vrf.Id, oldRd, newRd)
+	}
+
+	// The paths are queued as they are fetched, so that the updates of
+	// the same paths notified afterwards are processed after them. Large
+	// tables are fetched in batches of DumpBatchSize paths, each in its own
+	// operation of the server, and the next batch waits for loop() to
+	// process the previous one. Since FetchExistingVrf copies the whole
+	// table, only the keys of its destinations are kept and the batches
+	// read the current paths from the VPN table.
+	numPath := 0
+	batchSize := int(z.config.DumpBatchSize)
+	var keys []string
+	next, done := 0, false
+	lastProgress := time.Now()
+	for !done {
+		z.server.mgmtOperation(func() error {
+			if keys == nil {
+				tbl, _ := z.server.globalRib.FetchExistingVrf(vrf.Name)
+				if tbl == nil {
+					done = true
+					return nil
+				}
+				keys = make([]string, 0, len(tbl.GetDestinations()))
+				for key := range tbl.GetDestinations() {
+					keys = append(keys, key)
+				}
+			}
+			// the VRF deleted or added back meanwhile is not dumped
+			// anymore by this call.
+			v := z.server.globalRib.Vrfs[vrf.Name]
+			vpn := z.server.globalRib.Tables[bgp.RF_IPv4_VPN]
+			if v == nil || v.Id != vrf.Id || vpn == nil {
+				done = true
+				return nil
+			}
+			dsts := vpn.GetDestinations()
+			n := 0
+			for ; next < len(keys) && (batchSize == 0 || n < batchSize); next++ {
+				d, ok := dsts[keys[next]]
+				if !ok {
+					continue
+				}
+				dst := d.Select(table.DestinationSelectOption{VRF: v, Best: true})
+				if dst == nil {
+					continue
+				}
+				// Like the best path selection does in steady state, leave
+				// out paths whose nexthop is known to be unreachable.
+				paths := make([]*table.Path, 0, len(dst.GetAllKnownPathList()))
+				m := make(map[string]uint32)
+				for _, p := range dst.GetAllKnownPathList() {
+					if !p.IsNexthopInvalid {
+						paths = append(paths, p)
+						m[p.GetNlri().String()] = vrf.Id
+					}
+				}
+				if len(paths) > 0 {
+					z.queuePaths(paths, m)
+					n += len(paths)
+				}
+			}
+			numPath += n
+			done = next >= len(keys)
+			return nil
+		}, false)
+		if done {
+			break
+		}
+		// the progress of large tables is logged every dumpProgressInterval.
+		l := log.WithFields(log.Fields{
+			"Topic":        "Zebra",
+			"Vrf":          vrf.Name,
+			"Paths":        numPath,
+			"Destinations": fmt.Sprintf("%d/%d", next, len(keys)),
+		})
+		if time.Since(lastProgress) >= dumpProgressInterval {
+			l.Info("dumping vrf to zebra")
+			lastProgress = time.Now()
+		} else {
+			l.Debug("dumping vrf to zebra")
+		}
+		if !z.waitDumpBatch(batchSize) {
+			break
+		}
+	}
+	return numPath
+}
+
+// waitDumpBatch waits for loop() to take the paths queued by the previous
+// batch of dumpVrf, then for DumpBatchInterval. The events queued to the
+// watcher meanwhile are not waited for beyond batchSize of them, so that
+// the dump goes on while routes are updated. It returns false if the
+// client is stopped.
+func (z *zebraClient) waitDumpBatch(batchSize int) bool {
+	interval := time.Duration(z.config.DumpBatchInterval) * time.Millisecond
+	for z.watcher != nil && z.watcher.ch.Len() > batchSize {
+		select {
+		case <-z.dead:
+			return false
+		case <-time.After(time.Millisecond):
+		}
+	}
+	select {
+	case <-z.dead:
+		return false
+	case <-time.After(interval):
+	}
+	return true
+}
+
+// vrfRdChanged withdraws the routes installed into the given VRF because
+// of its old RD and passes the paths carrying the new RD to loop() to be
+// installed.
+func (z *zebraClient) vrfRdChanged(vrfId uint32, oldRd, newRd string) {
+	log.WithFields(log.Fields{
+		"Topic": "Zebra",
+		"VrfId": vrfId,
+		"OldRd": oldRd,
+		"NewRd": newRd,
+	}).Info("vrf rd changed, reinstalling routes")
+
+	done := make(chan struct{})
+	withdraw := func() {
+		defer close(done)
+		for key, r := range z.rdRoutes {
+			if r.vrfId != vrfId || r.rd != oldRd {
+				continue
+			}
+			if body, ok := z.installedBody[key]; ok {
+				z.sendIPRoute(vrfId, body, true)
+			}
+			delete(z.rdRoutes, key)
+		}
+	}
+	select {
+	case z.tasks <- withdraw:
+	case <-z.dead:
+		return
+	}
+	<-done
+
+	z.server.mgmtOperation(func() error {
+		var paths []*table.Path
+		for _, rf := range []bgp.RouteFamily{bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN} {
+			for _, p := range z.server.globalRib.GetBestPathList(table.GLOBAL_RIB_NAME, 0, []bgp.RouteFamily{rf}) {
+				if NlriRD(p.GetNlri()) == newRd {
+					paths = append(paths, p)
+				}
+			}
+		}
+		if len(paths) > 0 {
+			z.queuePaths(paths, nil)
+		}
+		return nil
+	}, false)
+}
+
+// vrfAdded sends the paths of a VRF added while the client is running.
+// It may race with dumpVrfs, which is fine since each VRF is dumped once.
+func (z *zebraClient) vrfAdded(name string, id uint32, rd bgp.RouteDistinguisherInterface) {
+	go func() {
+		select {
+		case <-z.ready:
+		case <-z.dead:
+			return
+		}
+		z.dumpVrf(&table.Vrf{Name: name, Id: id, Rd: rd})
+	}()
+}
+
+// redistributeVrfIds returns the ids of the VRFs the routes are
+// redistributed from: the default VRF, and every VRF of the global RIB if
+// RedistributeAllVrfs is set. It must be called by the server goroutine.
+func redistributeVrfIds(s *BgpServer, c *config.ZebraConfig) map[uint32]bool {
+	ids := map[uint32]bool{zebra.VRF_DEFAULT: true}
+	if !c.RedistributeAllVrfs || s == nil || s.globalRib == nil {
+		return ids
+	}
+	for _, vrf := range s.globalRib.Vrfs {
+		ids[vrf.Id] = true
+	}
+	return ids
+}
+
+func sortedVrfIds(ids map[uint32]bool) []uint32 {
+	l := make([]uint32, 0, len(ids))
+	for id := range ids {
+		l = append(l, id)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+	return l
+}
+
+// redistributeVrf starts, or stops, redistributing the route types of the
+// client from the given VRF when RedistributeAllVrfs is set.
+func (z *zebraClient) redistributeVrf(vrfId uint32, isDelete bool) {
+	if !z.config.RedistributeAllVrfs || vrfId == zebra.VRF_DEFAULT {
+		return
+	}
+	z.redistributedMu.Lock()
+	defer z.redistributedMu.Unlock()
+	if z.redistributedVrfs == nil {
+		z.redistributedVrfs = map[uint32]bool{zebra.VRF_DEFAULT: true}
+	}
+	if z.redistributedVrfs[vrfId] != isDelete {
+		return
+	}
+	types := make([]zebra.ROUTE_TYPE, 0, len(z.redistributed))
+	for t := range z.redistributed {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, t := range types {
+		var err error
+		if isDelete {
+			err = z.client.SendRedistributeDelete(t, vrfId)
+		} else {
+			err = z.client.SendRedistribute(t, vrfId)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Topic":  "Zebra",
+				"VrfId":  vrfId,
+				"Type":   t,
+				"Delete": isDelete,
+				"Error":  err,
+			}).Warn("failed to send redistribute for vrf")
+		}
+	}
+	if isDelete {
+		delete(z.redistributedVrfs, vrfId)
+	} else {
+		z.redistributedVrfs[vrfId] = true
+	}
+}
+
+// redistributeTypes returns the route types of the given protocol, or of
+// every protocol but BGP for "all", which are not redistributed to the
+// client yet, or which are if isDelete. It fails if there is none, so
+// that the request can be rejected before asking Zebra for anything.
+func (z *zebraClient) redistributeTypes(proto string, isDelete bool) ([]zebra.ROUTE_TYPE, error) {
+	types, err := zebra.RouteTypesFromString(proto, z.client.MessageVersion())
+	if err != nil {
+		return nil, err
+	}
+	z.redistributedMu.Lock()
+	defer z.redistributedMu.Unlock()
+	l := make([]zebra.ROUTE_TYPE, 0, len(types))
+	for _, t := range types {
+		if z.redistributed[t] == isDelete {
+			l = append(l, t)
+		}
+	}
+	if len(l) == 0 {
+		if isDelete {
+			return nil, fmt.Errorf("not redistributing %s", proto)
+		}
+		return nil, fmt.Errorf("already redistributing %s", proto)
+	}
+	return l, nil
+}
+
+// setRedistribute asks Zebra to redistribute the routes of the given
+// types to the client, or to stop redistributing them if isDelete. If a
+// message fails to be sent, the ones already sent are undone so that the
+// redistribution is left as it was, and the error is returned.
+func (z *zebraClient) setRedistribute(types []zebra.ROUTE_TYPE, isDelete bool) error {
+	z.redistributedMu.Lock()
+	defer z.redistributedMu.Unlock()
+	if z.redistributed == nil {
+		z.redistributed = make(map[zebra.ROUTE_TYPE]bool)
+	}
+	send := func(t zebra.ROUTE_TYPE, vrfId uint32, isDelete bool) error {
+		if isDelete {
+			return z.client.SendRedistributeDelete(t, vrfId)
+		}
+		return z.client.SendRedistribute(t, vrfId)
+	}
+	ids := z.redistributedVrfIds()
+	for i, t := range types {
+		for j, id := range ids {
+			if err := send(t, id, isDelete); err != nil {
+				z.undoRedistribute(types[:i+1], ids, j, isDelete, send)
+				return err
+			}
+		}
+		if isDelete {
+			delete(z.redistributed, t)
+		} else {
+			z.redistributed[t] = true
+		}
+	}
+	return nil
+}
+
+// undoRedistribute reverts what setRedistribute has sent for the given
+// types, the last one of which has only been sent for the first n VRFs.
+// z.redistributedMu must be held.
+func (z *zebraClient) undoRedistribute(types []zebra.ROUTE_TYPE, ids []uint32, n int, isDelete bool, send func(zebra.ROUTE_TYPE, uint32, bool) error) {
+	for i, t := range types {
+		sent := ids
+		if i == len(types)-1 {
+			sent = ids[:n]
+		}
+		for _, id := range sent {
+			if err := send(t, id, !isDelete); err != nil {
+				log.WithFields(log.Fields{
+					"Topic":  "Zebra",
+					"VrfId":  id,
+					"Type":   t,
+					"Delete": !isDelete,
+					"Error":  err,
+				}).Warn("failed to undo redistribute")
+			}
+		}
+		if isDelete {
+			z.redistributed[t] = true
+		} else {
+			delete(z.redistributed, t)
+		}
+	}
+}
+
+// setZebraRedistribute makes Zebra redistribute the routes of the given
+// protocol to every one of the zebra clients, or stop redistributing them
+// if isDelete. Nothing is changed unless it can be changed for every
+// client, and the clients already changed are rolled back if it fails
+// for one of them.
+func setZebraRedistribute(l []*zebraClient, proto string, isDelete bool) error {
+	types := make([][]zebra.ROUTE_TYPE, 0, len(l))
+	for _, z := range l {
+		t, err := z.redistributeTypes(proto, isDelete)
+		if err != nil {
+			return err
+		}
+		types = append(types, t)
+	}
+	for i, z := range l {
+		if err := z.setRedistribute(types[i], isDelete); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if err := l[j].setRedistribute(types[j], !isDelete); err != nil {
+					log.WithFields(log.Fields{
+						"Topic":    "Zebra",
+						"Protocol": proto,
+						"Error":    err,
+					}).Warn("failed to roll back redistribute")
+				}
+			}
+			return err
+		}
+	}
+	msg := "redistribution added"
+	if isDelete {
+		msg = "redistribution removed"
+	}
+	log.WithFields(log.Fields{
+		"Topic":    "Zebra",
+		"Protocol": proto,
+	}).Info(msg)
+	return nil
+}
+
+// redistributedVrfIds returns the sorted ids of the VRFs the routes are
+// redistributed from. z.redistributedMu must be held.
+func (z *zebraClient) redistributedVrfIds() []uint32 {
+	if z.redistributedVrfs == nil {
+		return []uint32{zebra.VRF_DEFAULT}
+	}
+	return sortedVrfIds(z.redistributedVrfs)
+}
+
+// vrfDeleted makes the paths of the given VRF to be dumped again if it is
+// added back.
+func (z *zebraClient) vrfDeleted(name string) {
+	z.dumpedVrfsMu.Lock()
+	delete(z.dumpedVrfs, name)
+	z.dumpedVrfsMu.Unlock()
+}
+
+// routerIdChanged sends ROUTER_ID_ADD to Zebra again when the router-id of
+// GoBGP changes. ZAPI has no message for a client to announce its own
+// router-id; subscribing again makes Zebra reply with ROUTER_ID_UPDATE so
+// that both sides resynchronize.
+func (z *zebraClient) routerIdChanged(routerId string) {
+	go func() {
+		select {
+		case <-z.ready:
+		case <-z.dead:
+			return
+		}
+		send := func() {
+			command := zebra.ROUTER_ID_ADD
+			if z.client.MessageVersion() >= 5 {
+				command = zebra.FRR_ZAPI5_ROUTER_ID_ADD
+			} else if z.client.MessageVersion() >= 4 {
+				command = zebra.FRR_ROUTER_ID_ADD
+			}
+			if err := z.client.SendCommand(command, zebra.VRF_DEFAULT, nil); err != nil {
+				log.WithFields(log.Fields{
+					"Topic":    "Zebra",
+					"RouterId": routerId,
+					"Error":    err,
+				}).Warn("failed to send router-id to zebra")
 				return
 			}
-			switch body := msg.Body.(type) {
-			case *zebra.IPRouteBody:
-				if p := createPathFromIPRouteMessage(msg); p != nil {
-					if _, err := z.server.AddPath("", pathList{p}); err != nil {
-						log.Errorf("failed to add path from zebra: %s", p)
+			log.WithFields(log.Fields{
+				"Topic":    "Zebra",
+				"RouterId": routerId,
+			}).Info("router-id changed, sent to zebra")
+		}
+		select {
+		case z.tasks <- send:
+		case <-z.dead:
+		}
+	}()
+}
+
+// dumpVrfs sends the paths of every VRF table to Zebra in the order of
+// their ids and notifies WATCH_EVENT_TYPE_ZEBRA_SYNC watchers once all of
+// them have been queued. VRFs added afterwards are handled by vrfAdded.
+func (z *zebraClient) dumpVrfs() {
+	if z.server.globalRib == nil {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+		}).Warn("global RIB is not ready, skipping initial sync with zebra")
+		return
+	}
+
+	numPath := 0
+	globalVrfs := z.server.GetVrf()
+	sort.Slice(globalVrfs, func(i, j int) bool { return globalVrfs[i].Id < globalVrfs[j].Id })
+	for _, vrf := range globalVrfs {
+		numPath += z.dumpVrf(vrf)
+	}
+
+	log.WithFields(log.Fields{
+		"Topic": "Zebra",
+		"Vrfs":  len(globalVrfs),
+		"Paths": numPath,
+	}).Info("initial sync with zebra completed")
+	ev := &WatchEventZebraSync{
+		NumVrf:    len(globalVrfs),
+		NumPath:   numPath,
+		Timestamp: time.Now(),
+	}
+	z.server.mgmtOperation(func() error {
+		for _, p := range z.server.neighborMap {
+			if p.fsm.pConf.GracefulRestart.State.LocalRestarting {
+				ev.LocalRestarting = true
+			}
+		}
+		z.server.notifyWatcher(WATCH_EVENT_TYPE_ZEBRA_SYNC, ev)
+		return nil
+	}, false)
+}
+
+// resolveVrfIds returns the ids of the VRFs into which the given path is
+// installed. VPN paths go to the VRFs importing any of their route
+// targets. Unless mode is RT_ONLY, the VRFs whose route distinguisher
+// matches the one of the path are used as a last resort, in which case
+// byRd is true. Paths which belong to no VRF are installed into the
+// default one.
+func resolveVrfIds(path *table.Path, vrfs []*table.Vrf, mode config.ZebraVrfResolveMode) (ids []uint32, byRd bool) {
+	ids = make([]uint32, 0)
+	switch path.GetRouteFamily() {
+	case bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN:
+		for _, vrf := range vrfs {
+			if vrf.Id != 0 && table.CanImportToVrf(vrf, path) {
+				ids = appendVrfId(ids, vrf.Id)
+			}
+		}
+	}
+	if len(ids) == 0 && mode != config.ZEBRA_VRF_RESOLVE_MODE_RT_ONLY {
+		rd := NlriRD(path.GetNlri())
+		for _, vrf := range vrfs {
+			if vrf.Rd != nil && rd == vrf.Rd.String() {
+				ids = appendVrfId(ids, vrf.Id)
+				byRd = true
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return []uint32{zebra.VRF_DEFAULT}, false
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, byRd
+}
+
+// isDefaultRoute returns whether nlri is an IPv4 or IPv6 default route.
+func isDefaultRoute(nlri bgp.AddrPrefixInterface) bool {
+	switch n := nlri.(type) {
+	case *bgp.IPAddrPrefix:
+		return n.Length == 0
+	case *bgp.IPv6AddrPrefix:
+		return n.Length == 0
+	case *bgp.LabeledVPNIPAddrPrefix:
+		return n.IPPrefixLen() == 0
+	case *bgp.LabeledVPNIPv6AddrPrefix:
+		return n.IPPrefixLen() == 0
+	}
+	return false
+}
+
+// isSpecialDefaultRoute returns whether path is a default route which is
+// installed from post-policy update events rather than best path events.
+// Families listed in DefaultRouteNormalAfiSafiList opt out of this.
+func isSpecialDefaultRoute(path *table.Path, c *config.ZebraConfig) bool {
+	if !isDefaultRoute(path.GetNlri()) {
+		return false
+	}
+	family := config.AfiSafiType(bgp.AddressFamilyNameMap[path.GetRouteFamily()])
+	for _, f := range c.DefaultRouteNormalAfiSafiList {
+		if f == family {
+			return false
+		}
+	}
+	return true
+}
+
+// routeEventKind is the kind of watch event a route is handled from.
+type routeEventKind int
+
+const (
+	routeEventBestPath routeEventKind = iota
+	routeEventUpdate
+)
+
+func (k routeEventKind) String() string {
+	if k == routeEventUpdate {
+		return "update"
+	}
+	return "best path"
+}
+
+// routeEventOwner returns the kind of event the route of path is handled
+// from. It only depends on the prefix and its family, so that every
+// event carrying a given prefix into a given VRF is either handled or
+// ignored, whatever the order in which the best path and the post-policy
+// update events are received.
+func routeEventOwner(path *table.Path, c *config.ZebraConfig) routeEventKind {
+	if isSpecialDefaultRoute(path, c) {
+		return routeEventUpdate
+	}
+	return routeEventBestPath
+}
+
+// acceptRouteEvent returns whether the route of path into the VRF vrfId
+// is handled from an event of the given kind, see routeEventOwner().
+func (z *zebraClient) acceptRouteEvent(kind routeEventKind, vrfId uint32, path *table.Path) bool {
+	if owner := routeEventOwner(path, &z.config); owner != kind {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Key":   path.GetNlri().String(),
+			"VRF":   vrfId,
+			"Event": kind,
+		}).Debugf("ignoring route handled from %s events", owner)
+		return false
+	}
+	return true
+}
+
+// handleUpdate installs the default routes carried by post-policy update
+// events. Skipping the other routes is intended: they, VPN routes
+// included, are installed from best path events, for which the server
+// resolves the VRFs importing VPN routes, and installing them here too
+// would send each of them twice. The default routes are the exception,
+// unless their family is listed in DefaultRouteNormalAfiSafiList, see
+// routeEventOwner().
+func (z *zebraClient) handleUpdate(msg *WatchEventUpdate) {
+	for _, path := range msg.PathList {
+		if z.isIgnoredPath(path) {
+			continue
+		}
+		if isLocalPath(path, &z.config) {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Key":   path.GetNlri().String(),
+			}).Debug("skipping local path")
+			continue
+		}
+		var vrfs []uint32
+		byRd := false
+		if v, ok := msg.Vrf[path.GetNlri().String()]; ok {
+			vrfs = []uint32{v}
+		} else {
+			vrfs, byRd = resolveVrfIds(path, z.server.GetVrf(), z.config.VrfResolveMode)
+		}
+		for _, vrfId := range vrfs {
+			if !z.acceptRouteEvent(routeEventUpdate, vrfId, path) {
+				continue
+			}
+			if body, isWithdraw := z.newIPRouteBody(pathList{path}, false); body != nil {
+				z.sendIPRoute(vrfId, body, isWithdraw)
+				key := ipRouteKey(vrfId, body)
+				if byRd && !isWithdraw {
+					z.rdRoutes[key] = rdRoute{vrfId: vrfId, rd: NlriRD(path.GetNlri())}
+				} else {
+					delete(z.rdRoutes, key)
+				}
+			}
+			if body, isWithdraw := newNexthopRegisterBody(pathList{path}, z.nhtManager); body != nil {
+				z.sendNexthopRegister(vrfId, body, isWithdraw)
+			}
+		}
+	}
+}
+
+// handle runs f on behalf of loop(), recovering from a panic in it so
+// that a single bad message does not silently disconnect us from Zebra.
+// It returns false if loop() has to reconnect as configured by
+// PanicAction.
+func (z *zebraClient) handle(f func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			atomic.AddUint32(&z.panics, 1)
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"Error": r,
+				"Stack": string(debug.Stack()),
+			}).Error("recovered from panic in zebra client")
+			ok = z.config.PanicAction != config.ZEBRA_PANIC_ACTION_RECONNECT
+		}
+	}()
+	f()
+	if z.metrics != nil && z.nhtManager != nil {
+		z.metrics.setRegisteredNexthops(len(z.nhtManager.nexthopCache))
+	}
+	return true
+}
+
+// disconnect is called by loop() when the connection to Zebra is lost.
+func (z *zebraClient) disconnect() {
+	atomic.StoreInt32(&z.disconnected, 1)
+	z.metrics.setConnected(false)
+	go func() {
+		// the server goroutine owns the clients and may be waiting for
+		// this one, hence the removal from another goroutine.
+		z.server.mgmtOperation(func() error {
+			z.server.removeZebraClient(z)
+			return nil
+		}, false)
+		z.reconnect()
+	}()
+}
+
+// restart closes the connection to Zebra and starts reconnecting.
+func (z *zebraClient) restart() {
+	z.client.Close()
+	z.disconnect()
+}
+
+func (z *zebraClient) handleMessage(msg *zebra.Message) {
+	switch body := msg.Body.(type) {
+	case *zebra.IPRouteBody:
+		if z.config.ImportDedup && z.isDuplicateImport(body) {
+			log.WithFields(log.Fields{
+				"Topic":        "Zebra",
+				"Prefix":       body.Prefix,
+				"PrefixLength": body.PrefixLength,
+			}).Debug("ignoring route already imported from zebra")
+			return
+		}
+		if p := createPathFromIPRouteMessage(msg, &z.config); p != nil {
+			if _, err := z.server.AddPath("", pathList{p}); err != nil {
+				log.Errorf("failed to add path from zebra: %s", p)
+			} else if z.config.ImportDedup {
+				z.trackImport(body, p)
+			}
+		}
+	case *zebra.InterfaceUpdateBody:
+		if z.tracksInterfaces() {
+			z.handleInterfaceUpdate(msg.Header.Version, msg.Header.VrfId, msg.Header.Command, body)
+		}
+	case *zebra.InterfaceAddressUpdateBody:
+		if z.tracksInterfaces() {
+			z.handleInterfaceAddressUpdate(msg.Header.Version, msg.Header.Command, body)
+		}
+	case *zebra.NexthopUpdateBody:
+		atomic.AddUint64(&z.nexthopUpdatesReceived, 1)
+		if z.config.NexthopLabelResolution {
+			z.updateNexthopLabels(body)
+		}
+		if z.nhtManager == nil {
+			return
+		}
+		manager := &table.TableManager{
+			Tables: make(map[bgp.RouteFamily]*table.Table),
+		}
+		for _, rf := range rfListFromNexthopUpdateBody(body) {
+			rib, _, err := z.server.GetRib("", rf, nil)
+			if err != nil {
+				log.Errorf("failed to get global rib by family %s", rf.String())
+				continue
+			}
+			manager.Tables[rf] = rib
+		}
+		if paths, b, err := createPathListFromNexthopUpdateMessage(body, manager, z.nhtManager); err != nil {
+			log.Errorf("failed to create updated path list related to nexthop %s", body.Prefix.String())
+		} else {
+			atomic.AddUint64(&z.nexthopUpdatePaths, uint64(len(paths)))
+			z.nhtManager.scheduleUpdate(paths)
+			if b != nil {
+				z.sendNexthopRegister(msg.Header.VrfId, b, true)
+			}
+		}
+	}
+}
+
+// tracksInterfaces returns true if the interfaces known from Zebra are
+// kept in z.interfaces.
+func (z *zebraClient) tracksInterfaces() bool {
+	switch z.config.CrossVrfNexthopAction {
+	case config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE, config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT:
+		return true
+	}
+	return z.config.InvalidateOnInterfaceDown
+}
+
+func (z *zebraClient) handleInterfaceUpdate(version uint8, vrfId uint32, command zebra.API_TYPE, body *zebra.InterfaceUpdateBody) {
+	ifc, ok := z.interfaces[body.Index]
+	if !ok {
+		ifc = &zebraInterface{}
+		z.interfaces[body.Index] = ifc
+	}
+	ifc.vrfId = vrfId
+	// The commands are numbered differently since version 4, and keep
+	// their numbers in versions 5 and 6.
+	upCmd, downCmd, deleteCmd := zebra.INTERFACE_UP, zebra.INTERFACE_DOWN, zebra.INTERFACE_DELETE
+	if version >= 4 {
+		upCmd, downCmd, deleteCmd = zebra.FRR_INTERFACE_UP, zebra.FRR_INTERFACE_DOWN, zebra.FRR_INTERFACE_DELETE
+	}
+	var up bool
+	switch command {
+	case upCmd:
+		up = true
+	case downCmd:
+		up = false
+	case deleteCmd:
+		up = false
+		delete(z.interfaces, body.Index)
+	default:
+		up = body.Flags&syscall.IFF_UP != 0
+	}
+	if up == ifc.up {
+		return
+	}
+	ifc.up = up
+	log.WithFields(log.Fields{
+		"Topic":     "Zebra",
+		"Interface": body.Name,
+		"Up":        up,
+	}).Debug("interface state changed")
+	if z.config.InvalidateOnInterfaceDown {
+		z.updateNexthopValidity(ifc.prefixes, !up)
+	}
+}
+
+// nexthopInterface returns the index and the VRF of the interface with the
+// most specific prefix containing the given nexthop, preferring the
+// interfaces of the given VRF.
+func (z *zebraClient) nexthopInterface(vrfId uint32, nexthop net.IP) (uint32, uint32, bool) {
+	var index, ifVrfId uint32
+	found, bestLen := false, -1
+	for i, ifc := range z.interfaces {
+		for _, p := range ifc.prefixes {
+			if !p.Contains(nexthop) {
+				continue
+			}
+			l, _ := p.Mask.Size()
+			if found {
+				if inVrf, bestInVrf := ifc.vrfId == vrfId, ifVrfId == vrfId; inVrf != bestInVrf {
+					if !inVrf {
+						continue
 					}
-				}
-			case *zebra.NexthopUpdateBody:
-				if z.nhtManager == nil {
+				} else if l < bestLen || l == bestLen && i > index {
 					continue
 				}
-				manager := &table.TableManager{
-					Tables: make(map[bgp.RouteFamily]*table.Table),
+			}
+			index, ifVrfId, bestLen, found = i, ifc.vrfId, l, true
+		}
+	}
+	return index, ifVrfId, found
+}
+
+// withCrossVrfNexthops applies CrossVrfNexthopAction to a route to be
+// installed into the given VRF whose nexthop belongs to the prefix of an
+// interface of another VRF, e.g. a route leaked from it. With RESOLVE, the
+// route carries the index of such interfaces, for Zebra to resolve the
+// nexthops there. With REJECT, nil is returned.
+func (z *zebraClient) withCrossVrfNexthops(vrfId uint32, body *zebra.IPRouteBody) *zebra.IPRouteBody {
+	action := z.config.CrossVrfNexthopAction
+	if action != config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE && action != config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT {
+		return body
+	}
+	// The interfaces of link-local nexthops are already known.
+	if len(body.Ifindexs) > 0 {
+		return body
+	}
+	ifindexs := make([]uint32, 0, len(body.Nexthops))
+	crossVrfId, isCrossVrf := uint32(0), false
+	for _, nh := range body.Nexthops {
+		index, ifVrfId, ok := z.nexthopInterface(vrfId, nh)
+		if !ok {
+			continue
+		}
+		if ifVrfId != vrfId {
+			crossVrfId, isCrossVrf = ifVrfId, true
+		}
+		ifindexs = append(ifindexs, index)
+	}
+	if !isCrossVrf {
+		return body
+	}
+	fields := log.Fields{
+		"Topic":      "Zebra",
+		"Key":        fmt.Sprintf("%s/%d", body.Prefix, body.PrefixLength),
+		"Nexthop":    body.Nexthops,
+		"VrfId":      vrfId,
+		"NexthopVrf": crossVrfId,
+	}
+	if action == config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT {
+		log.WithFields(fields).Debug("rejecting route with nexthop in another vrf")
+		return nil
+	}
+	if len(ifindexs) != len(body.Nexthops) {
+		log.WithFields(fields).Warn("not every nexthop of route has an interface, installing it unchanged")
+		return body
+	}
+	log.WithFields(fields).Debug("resolving nexthop of route in another vrf")
+	b := *body
+	b.Message |= zebra.MESSAGE_IFINDEX
+	b.Ifindexs = ifindexs
+	return &b
+}
+
+func (z *zebraClient) handleInterfaceAddressUpdate(version uint8, command zebra.API_TYPE, body *zebra.InterfaceAddressUpdateBody) {
+	ifc, ok := z.interfaces[body.Index]
+	if !ok {
+		return
+	}
+	bits := 8 * net.IPv6len
+	if body.Prefix.To4() != nil {
+		bits = 8 * net.IPv4len
+	}
+	mask := net.CIDRMask(int(body.Length), bits)
+	prefix := &net.IPNet{IP: body.Prefix.Mask(mask), Mask: mask}
+	for i, p := range ifc.prefixes {
+		if p.String() == prefix.String() {
+			ifc.prefixes = append(ifc.prefixes[:i], ifc.prefixes[i+1:]...)
+			break
+		}
+	}
+	addCmd := zebra.INTERFACE_ADDRESS_ADD
+	if version >= 4 {
+		addCmd = zebra.FRR_INTERFACE_ADDRESS_ADD
+	}
+	if command == addCmd {
+		ifc.prefixes = append(ifc.prefixes, prefix)
+	}
+}
+
+// updateNexthopValidity invalidates, or validates again, the paths of the
+// global RIB whose nexthop belongs to one of the given prefixes.
+func (z *zebraClient) updateNexthopValidity(prefixes []*net.IPNet, invalid bool) {
+	if len(prefixes) == 0 {
+		return
+	}
+	paths := make(pathList, 0)
+	for _, rf := range []bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN} {
+		rib, _, err := z.server.GetRib("", rf, nil)
+		if err != nil {
+			continue
+		}
+		for _, dst := range rib.GetDestinations() {
+			for _, path := range dst.GetAllKnownPathList() {
+				if path.IsFromExternal() || path.IsNexthopInvalid == invalid {
+					continue
 				}
-				for _, rf := range rfListFromNexthopUpdateBody(body) {
-					rib, _, err := z.server.GetRib("", rf, nil)
-					if err != nil {
-						log.Errorf("failed to get global rib by family %s", rf.String())
-						continue
+				for _, prefix := range prefixes {
+					if prefix.Contains(path.GetNexthop()) {
+						newPath := path.Clone(false)
+						newPath.IsNexthopInvalid = invalid
+						paths = append(paths, newPath)
+						break
 					}
-					manager.Tables[rf] = rib
 				}
-				if paths, b, err := createPathListFromNexthopUpdateMessage(body, manager, z.nhtManager); err != nil {
-					log.Errorf("failed to create updated path list related to nexthop %s", body.Prefix.String())
-				} else {
-					z.nhtManager.scheduleUpdate(paths)
-					if b != nil {
-						z.client.SendNexthopRegister(msg.Header.VrfId, b, true)
-					}
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	log.WithFields(log.Fields{
+		"Topic":   "Zebra",
+		"Invalid": invalid,
+	}).Debugf("update nexthop reachability on interface state change: %s", paths)
+	if err := z.server.UpdatePath("", paths); err != nil {
+		log.WithFields(log.Fields{
+			"Topic": "Zebra",
+			"Error": err,
+		}).Error("failed to update nexthop reachability on interface state change")
+	}
+}
+
+// importKey returns the key of the route carried by body in imported
+// along with its nexthop and metric.
+func importKey(body *zebra.IPRouteBody) (string, importedRoute) {
+	r := importedRoute{metric: body.Metric}
+	if len(body.Nexthops) > 0 {
+		r.nexthop = body.Nexthops[0].String()
+	}
+	return fmt.Sprintf("%s/%d", body.Prefix, body.PrefixLength), r
+}
+
+// isDuplicateImport returns whether body adds a route which has already
+// been imported from Zebra with the same nexthop and metric.
+func (z *zebraClient) isDuplicateImport(body *zebra.IPRouteBody) bool {
+	if body.IsWithdraw() {
+		return false
+	}
+	key, r := importKey(body)
+	old, ok := z.imported[key]
+	return ok && old == r
+}
+
+func (z *zebraClient) trackImport(body *zebra.IPRouteBody, path *table.Path) {
+	key, r := importKey(body)
+	if path.IsWithdraw {
+		delete(z.imported, key)
+	} else {
+		z.imported[key] = r
+	}
+}
+
+// unsupportedPathLogInterval is the minimum interval between two warnings
+// about the paths of the same address family which cannot be installed
+// into Zebra.
+const unsupportedPathLogInterval = time.Minute
+
+// newIPRouteBody is the same as newIPRouteBody() except that the paths
+// which cannot be installed into Zebra, see isZebraRoute(), are handled
+// according to UnsupportedFamilyAction rather than silently dropped.
+func (z *zebraClient) newIPRouteBody(dst pathList, selfRouteWithdraw bool) (*zebra.IPRouteBody, bool) {
+	if len(dst) == 0 {
+		return nil, false
+	}
+	if !isZebraRoute(dst[0]) {
+		if z.config.UnsupportedFamilyAction != config.ZEBRA_UNSUPPORTED_FAMILY_ACTION_IGNORE {
+			family := dst[0].GetRouteFamily()
+			z.unsupportedPathsMu.Lock()
+			if z.unsupportedPaths == nil {
+				z.unsupportedPaths = make(map[bgp.RouteFamily]uint64)
+				z.unsupportedPathsLogged = make(map[bgp.RouteFamily]time.Time)
+			}
+			z.unsupportedPaths[family]++
+			n := z.unsupportedPaths[family]
+			warn := time.Since(z.unsupportedPathsLogged[family]) >= unsupportedPathLogInterval
+			if warn {
+				z.unsupportedPathsLogged[family] = time.Now()
+			}
+			z.unsupportedPathsMu.Unlock()
+			fields := log.Fields{
+				"Topic":  "Zebra",
+				"Family": family,
+				"Key":    dst[0].GetNlri().String(),
+				"Count":  n,
+			}
+			if warn {
+				log.WithFields(fields).Warn("not installing path of unsupported address family or route type")
+			} else {
+				log.WithFields(fields).Debug("not installing path of unsupported address family or route type")
+			}
+		}
+		return nil, false
+	}
+	return newIPRouteBody(dst, selfRouteWithdraw, &z.config)
+}
+
+// handleEvent installs the routes carried by the given event. The route
+// of each prefix into each VRF is handled by a single kind of event, see
+// acceptRouteEvent(), so that the routes installed do not depend on the
+// order in which the best path and the post-policy update events of a
+// prefix are received, with multiple paths or not.
+func (z *zebraClient) handleEvent(ev WatchEvent) {
+	switch msg := ev.(type) {
+	case *WatchEventBestPath:
+		if table.UseMultiplePaths.Enabled {
+			for _, dst := range msg.MultiPathList {
+				if len(dst) == 0 || !z.acceptRouteEvent(routeEventBestPath, 0, dst[0]) {
+					continue
+				}
+				if body, isWithdraw := z.newIPRouteBody(dst, false); body != nil {
+					z.sendIPRoute(0, body, isWithdraw)
+				}
+				if body, isWithdraw := newNexthopRegisterBody(dst, z.nhtManager); body != nil {
+					z.sendNexthopRegister(0, body, isWithdraw)
 				}
 			}
-		case ev := <-w.Event():
-			switch msg := ev.(type) {
-			case *WatchEventBestPath:
-				if table.UseMultiplePaths.Enabled {
-					for _, dst := range msg.MultiPathList {
-						if body, isWithdraw := newIPRouteBody(dst, false); body != nil {
-							z.client.SendIPRoute(0, body, isWithdraw)
-						}
-						if body, isWithdraw := newNexthopRegisterBody(dst, z.nhtManager); body != nil {
-							z.client.SendNexthopRegister(0, body, isWithdraw)
-						}
-					}
-				} else {
-					for _, path := range msg.PathList {
-						selfRouteWithdraw := false
-						if NlriPrefix(path.GetNlri().String()) == "0.0.0.0/0" {
-							continue
-						}
-						if path.IsLocal() {
-							fmt.Println("Make Local Path selection to withdraw event", path.GetNlri().String())
-							selfRouteWithdraw = true
-						}
-						vrfs := []uint16{}
-						if msg.Vrf != nil {
-							if v, ok := msg.Vrf[path.GetNlri().String()]; ok {
-								vrfs = append(vrfs, v)
-							}
-						}
-						if len(vrfs) == 0 {
-							vrfs = append(vrfs, 0)
-						}
-						for _, i := range vrfs {
-							if body, isWithdraw := newIPRouteBody(pathList{path}, selfRouteWithdraw); body != nil {
-								if selfRouteWithdraw {
-									isWithdraw = true
-								}
-								z.client.SendIPRoute(i, body, isWithdraw)
-							}
-							if body, isWithdraw := newNexthopRegisterBody(pathList{path}, z.nhtManager); body != nil {
-								if selfRouteWithdraw {
-									isWithdraw = true
-								}
-								z.client.SendNexthopRegister(i, body, isWithdraw)
-							}
-						}
-					}
+		} else {
+			for _, path := range msg.PathList {
+				selfRouteWithdraw := false
+				if z.isIgnoredPath(path) {
+					continue
 				}
-			case *WatchEventUpdate:
-				m := make(map[string]uint16)
+				if isLocalPath(path, &z.config) {
+					log.WithFields(log.Fields{
+						"Topic": "Zebra",
+						"Key":   path.GetNlri().String(),
+					}).Debug("local path selected, withdrawing the route from zebra")
+					selfRouteWithdraw = true
+				}
+				vrfs := []uint32{}
 				if msg.Vrf != nil {
-					m = msg.Vrf
-				} else {
-					for _, p := range msg.PathList {
-						switch p.GetRouteFamily() {
-						case bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN:
-							globalVrfs := z.server.GetVrf()
-							for _, vrf := range globalVrfs {
-								if vrf.Id != 0 && table.CanImportToVrf(vrf, p) {
-									m[p.GetNlri().String()] = uint16(vrf.Id)
-								}
-							}
-						}
+					if v, ok := msg.Vrf[path.GetNlri().String()]; ok {
+						vrfs = append(vrfs, v)
 					}
 				}
-				for _, path := range msg.PathList {
-					if NlriPrefix(path.GetNlri().String()) != "0.0.0.0/0" {
-						continue
-					}
-					if path.IsLocal() {
-						fmt.Println("Skipping Local Path", path.GetNlri().String())
+				if len(vrfs) == 0 {
+					vrfs = append(vrfs, 0)
+				}
+				for _, i := range vrfs {
+					if !z.acceptRouteEvent(routeEventBestPath, i, path) {
 						continue
 					}
-					vrfs := []uint16{}
-					if v, ok := m[path.GetNlri().String()]; ok {
-						vrfs = append(vrfs, v)
-					}
-					if len(vrfs) == 0 {
-						globalVrfs := z.server.GetVrf()
-						for _, vrf := range globalVrfs {
-							if NlriRD(path.GetNlri().String()) == vrf.Rd.String() {
-								vrfs = append(vrfs, uint16(vrf.Id))
-							}
+					if body, isWithdraw := z.newIPRouteBody(pathList{path}, selfRouteWithdraw); body != nil {
+						if selfRouteWithdraw {
+							isWithdraw = true
 						}
+						z.sendIPRoute(i, body, isWithdraw)
 					}
-					if len(vrfs) == 0 {
-						vrfs = append(vrfs, 0)
-					}
-					for _, vrfId := range vrfs {
-						if body, isWithdraw := newIPRouteBody(pathList{path}, false); body != nil {
-							z.client.SendIPRoute(vrfId, body, isWithdraw)
-						}
-						if body, isWithdraw := newNexthopRegisterBody(pathList{path}, z.nhtManager); body != nil {
-							z.client.SendNexthopRegister(vrfId, body, isWithdraw)
+					if body, isWithdraw := newNexthopRegisterBody(pathList{path}, z.nhtManager); body != nil {
+						if selfRouteWithdraw {
+							isWithdraw = true
 						}
+						z.sendNexthopRegister(i, body, isWithdraw)
 					}
 				}
-				// if body, isWithdraw := newNexthopRegisterBody(msg.PathList, z.nhtManager); body != nil {
-				// 	z.client.SendNexthopRegister(0, body, isWithdraw)
-				// }
 			}
 		}
+	case *WatchEventUpdate:
+		z.handleUpdate(msg)
+	case *WatchEventZebraSync:
+		z.synced = true
+		if z.config.GracefulRestartReconcile && msg.LocalRestarting && z.savedRoutes != nil {
+			z.deferReconcile()
+		} else {
+			z.reconcileInstallState()
+		}
+		// if body, isWithdraw := newNexthopRegisterBody(msg.PathList, z.nhtManager); body != nil {
+		// 	z.client.SendNexthopRegister(0, body, isWithdraw)
+		// }
+	}
+}
+
+// waitInterfaceSync waits up to InterfaceSyncTimeout for Zebra to send
+// the interfaces in response to INTERFACE_ADD, handling the messages
+// received meanwhile. It returns false if loop() has to return.
+func (z *zebraClient) waitInterfaceSync() bool {
+	if z.config.InterfaceSyncTimeout == 0 {
+		return true
+	}
+	timeout := time.After(time.Duration(z.config.InterfaceSyncTimeout) * time.Second)
+	for {
+		select {
+		case <-z.dead:
+			return false
+		case <-z.done():
+			z.stop()
+			return false
+		case msg := <-z.client.Receive():
+			if msg == nil {
+				z.disconnect()
+				return false
+			}
+			z.handleMessage(msg)
+			if _, ok := msg.Body.(*zebra.InterfaceUpdateBody); ok {
+				log.WithFields(log.Fields{
+					"Topic": "Zebra",
+				}).Debug("interfaces received from zebra")
+				return true
+			}
+		case <-timeout:
+			log.WithFields(log.Fields{
+				"Topic":   "Zebra",
+				"Timeout": z.config.InterfaceSyncTimeout,
+			}).Warn("timed out waiting for interfaces from zebra, proceeding without them")
+			return true
+		}
+	}
+}
+
+func (z *zebraClient) loop() {
+	if z.nhtManager != nil {
+		ctx := z.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		go z.nhtManager.loop(ctx)
+		defer z.nhtManager.stop()
+	}
+
+	if !z.waitInterfaceSync() {
+		return
+	}
+
+	opts := []WatchOption{
+		WatchBestPath(true),
+		WatchPostUpdate(true),
+		WatchChannelSize(int(z.config.WatchChannelSize)),
+	}
+	if z.config.InstallStateFile != "" {
+		// The sync event is queued after the paths of the VRFs, for the
+		// saved routes to be reconciled once they are installed.
+		opts = append(opts, WatchZebraSync())
+	}
+	w := z.server.Watch(opts...)
+	z.watcher = w
+	close(z.ready)
+	defer w.Stop()
+
+	go z.dumpVrfs()
+
+	retryInterval := 5 * time.Second
+	if z.config.NexthopRegisterRetryInterval > 0 {
+		retryInterval = time.Duration(z.config.NexthopRegisterRetryInterval) * time.Second
+	}
+	retry := time.NewTicker(retryInterval)
+	defer retry.Stop()
+
+	var save <-chan time.Time
+	if z.config.InstallStateFile != "" {
+		t := time.NewTicker(installStateSaveInterval)
+		defer t.Stop()
+		save = t.C
+	}
+
+	for {
+		select {
+		case <-z.dead:
+			z.shutdown()
+			return
+		case <-z.done():
+			z.stop()
+			z.shutdown()
+			return
+		case <-retry.C:
+			if !z.handle(func() {
+				z.retryNexthopRegisters()
+				z.retryVrfRegisters()
+			}) {
+				z.restart()
+				return
+			}
+		case <-save:
+			if z.installStateDirty {
+				z.saveInstallState()
+			}
+		case f := <-z.tasks:
+			if !z.handle(f) {
+				z.restart()
+				return
+			}
+		case msg := <-z.client.Receive():
+			if msg == nil {
+				z.disconnect()
+				return
+			}
+			z.metrics.countMessage("received", msg.Header.Command.String())
+			if !z.handle(func() { z.handleMessage(msg) }) {
+				z.restart()
+				return
+			}
+		case ev := <-w.Event():
+			if !z.handle(func() { z.handleEvent(ev) }) {
+				z.restart()
+				return
+			}
+		}
+	}
+}
+
+// zebraVersions returns the message versions to try in turn to connect to
+// Zebra: the configured one, clamped to zebra.MaxVersion, and from version
+// 4 on the older ones down to version 3 in case Zebra is older than
+// configured.
+func zebraVersions(version uint8) []uint8 {
+	if version > zebra.MaxVersion {
+		version = zebra.MaxVersion
+	}
+	if version < 4 {
+		return []uint8{version}
 	}
+	versions := make([]uint8, 0, version-2)
+	for v := version; v >= 3; v-- {
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+func newZebraClient(ctx context.Context, s *BgpServer, c *config.ZebraConfig) (*zebraClient, error) {
+	return newZebraEndpointClient(ctx, s, c, "")
 }
 
-func newZebraClient(s *BgpServer, url string, protos []string, version uint8, nhtEnable bool, nhtDelay uint8) (*zebraClient, error) {
-	l := strings.SplitN(url, ":", 2)
+// newZebraEndpointClient is the same as newZebraClient() except that the
+// client connects to the additional Zebra instance of the given URL, if
+// not empty, instead of the one of c.
+func newZebraEndpointClient(ctx context.Context, s *BgpServer, c *config.ZebraConfig, endpoint string) (*zebraClient, error) {
+	if endpoint != "" {
+		ec := *c
+		ec.Url = endpoint
+		ec.AdditionalUrlList = nil
+		c = &ec
+	}
+	l := strings.SplitN(c.Url, ":", 2)
 	if len(l) != 2 {
-		return nil, fmt.Errorf("unsupported url: %s", url)
+		return nil, fmt.Errorf("unsupported url: %s", c.Url)
+	}
+	if c.SourceNeighborAddress != "" && net.ParseIP(c.SourceNeighborAddress) == nil {
+		return nil, fmt.Errorf("invalid source neighbor address: %s", c.SourceNeighborAddress)
+	}
+	for _, t := range c.CommunityTagList {
+		if _, err := table.ParseCommunity(t.Community); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range c.SelfRouteWithdrawNexthopList {
+		if net.ParseIP(a) == nil {
+			return nil, fmt.Errorf("invalid self route withdraw nexthop: %s", a)
+		}
+	}
+	for _, f := range c.ImportFamilyList {
+		if _, err := bgp.GetRouteFamily(string(f)); err != nil {
+			return nil, err
+		}
+	}
+	for _, list := range [][]string{c.BlackholeCommunityList, c.RejectCommunityList, c.InstallCommunityList, c.NexthopTriggerBypassCommunityList} {
+		for _, comm := range list {
+			if _, err := table.ParseCommunity(comm); err != nil {
+				return nil, err
+			}
+		}
 	}
 	var cli *zebra.Client
 	var err error
-	for _, ver := range []uint8{version} {
-		cli, err = zebra.NewClient(l[0], l[1], zebra.ROUTE_BGP, ver)
+	for _, ver := range zebraVersions(c.Version) {
+		cli, err = zebra.NewBufferedClient(l[0], l[1], zebra.ROUTE_BGP, ver, int(c.WriteBufferSize), time.Duration(c.WriteFlushInterval)*time.Millisecond)
 		if err == nil {
 			break
 		}
@@ -747,28 +3924,104 @@ func newZebraClient(s *BgpServer, url string, protos []string, version uint8, nh
 	if cli == nil {
 		return nil, err
 	}
+	var metrics *zebraMetrics
+	if s != nil && c.MetricsEnabled && endpoint == "" {
+		if s.zebraMetrics == nil {
+			s.zebraMetrics = &zebraMetrics{}
+		}
+		metrics = s.zebraMetrics
+	}
+	var conn zebraConn = cli
+	if metrics != nil {
+		conn = &meteredZebraConn{zebraConn: cli, metrics: metrics}
+	}
 	// Note: HELLO/ROUTER_ID_ADD messages are automatically sent to negotiate
 	// the Zebra message version in zebra.NewClient().
 	// cli.SendHello()
 	// cli.SendRouterIDAdd()
-	cli.SendInterfaceAdd()
-	for _, typ := range protos {
-		t, err := zebra.RouteTypeFromString(typ)
+	conn.SendInterfaceAdd()
+	redistributed := make(map[zebra.ROUTE_TYPE]bool)
+	redistributedVrfs := redistributeVrfIds(s, c)
+	for _, typ := range c.RedistributeRouteTypeList {
+		types, err := zebra.RouteTypesFromString(string(typ), cli.Version)
 		if err != nil {
+			cli.Close()
 			return nil, err
 		}
-		cli.SendRedistribute(t, zebra.VRF_DEFAULT)
+		for _, t := range types {
+			if redistributed[t] {
+				continue
+			}
+			for _, id := range sortedVrfIds(redistributedVrfs) {
+				conn.SendRedistribute(t, id)
+			}
+			redistributed[t] = true
+		}
 	}
 	var nhtManager *nexthopTrackingManager = nil
-	if nhtEnable {
-		nhtManager = newNexthopTrackingManager(s, int(nhtDelay))
+	if c.NexthopTriggerEnable {
+		nhtManager = newNexthopTrackingManager(s, int(c.NexthopTriggerDelay), int(c.NexthopTriggerMaxDelay), int(c.NexthopTriggerMaxCoalesceAge))
+		nhtManager.minDelay = int(c.NexthopTriggerMinDelay)
+		if c.NexthopTriggerPenaltyCharge > 0 {
+			nhtManager.penaltyCharge = int(c.NexthopTriggerPenaltyCharge)
+		}
+		nhtManager.withdrawPenaltyCharge = int(c.NexthopTriggerWithdrawPenaltyCharge)
+		nhtManager.metrics = metrics
+		nhtManager.ignoreZone = c.NexthopTriggerIgnoreZone
+		if c.NexthopTriggerSuppressThreshold > 0 {
+			nhtManager.suppressThreshold = int(c.NexthopTriggerSuppressThreshold)
+		}
+		if c.NexthopTriggerDampingDelay > 0 {
+			nhtManager.dampingDelay = int(c.NexthopTriggerDampingDelay)
+		}
+		if c.NexthopTriggerDecayInterval > 0 {
+			nhtManager.decayInterval = time.Duration(c.NexthopTriggerDecayInterval) * time.Second
+		}
+		nhtManager.skipDefaultRoute = c.NexthopTriggerSkipDefaultRoute
+		nhtManager.metricPrecedence = c.NexthopMetricPrecedence
+		for _, comm := range c.NexthopTriggerBypassCommunityList {
+			// already validated before connecting.
+			v, _ := table.ParseCommunity(comm)
+			nhtManager.bypassCommunities[v] = struct{}{}
+		}
 	}
 	w := &zebraClient{
-		dead:       make(chan struct{}),
-		client:     cli,
-		server:     s,
-		nhtManager: nhtManager,
+		ctx:               ctx,
+		dead:              make(chan struct{}),
+		client:            conn,
+		server:            s,
+		nhtManager:        nhtManager,
+		config:            *c,
+		installed:         make(map[string][]uint32),
+		installedBody:     make(map[string]*zebra.IPRouteBody),
+		rdRoutes:          make(map[string]rdRoute),
+		imported:          make(map[string]importedRoute),
+		interfaces:        make(map[uint32]*zebraInterface),
+		failedNexthops:    make(map[string]failedNexthop),
+		nexthopLabels:     make(map[string][]uint32),
+		tasks:             make(chan func()),
+		ready:             make(chan struct{}),
+		vrfRegistrations:  make(map[uint32]vrfRegistration),
+		redistributed:     redistributed,
+		redistributedVrfs: redistributedVrfs,
+		endpoint:          endpoint,
+		metrics:           metrics,
+	}
+	if c.InstallStateFile != "" {
+		routes, err := loadInstallState(c.InstallStateFile)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+				"File":  c.InstallStateFile,
+				"Error": err,
+			}).Warn("failed to load the routes installed into zebra before restart")
+		}
+		w.savedRoutes = routes
 	}
+	// The routes are encoded for the negotiated version, which is also
+	// tried first when reconnecting.
+	w.config.Version = cli.Version
+	metrics.setConnected(true)
 	go w.loop()
 	return w, nil
 }
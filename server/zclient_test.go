@@ -16,10 +16,28 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/eapache/channels"
+	"github.com/osrg/gobgp/config"
+	"github.com/osrg/gobgp/packet/bgp"
 	"github.com/osrg/gobgp/table"
 	"github.com/osrg/gobgp/zebra"
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"io"
+	"io/ioutil"
+	"math"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -52,7 +70,7 @@ func Test_createPathFromIPRouteMessage(t *testing.T) {
 	m.Header = *h
 	m.Body = b
 
-	path := createPathFromIPRouteMessage(m)
+	path := createPathFromIPRouteMessage(m, &config.ZebraConfig{})
 	pp := table.NewPath(nil, path.GetNlri(), path.IsWithdraw, path.GetPathAttrs(), time.Now(), false)
 	pp.SetIsFromExternal(path.IsFromExternal())
 	assert.Equal("0.0.0.0", pp.GetNexthop().String())
@@ -66,7 +84,7 @@ func Test_createPathFromIPRouteMessage(t *testing.T) {
 	m.Header = *h
 	m.Body = b
 
-	path = createPathFromIPRouteMessage(m)
+	path = createPathFromIPRouteMessage(m, &config.ZebraConfig{})
 	pp = table.NewPath(nil, path.GetNlri(), path.IsWithdraw, path.GetPathAttrs(), time.Now(), false)
 	pp.SetIsFromExternal(path.IsFromExternal())
 	assert.Equal("0.0.0.0", pp.GetNexthop().String())
@@ -85,7 +103,7 @@ func Test_createPathFromIPRouteMessage(t *testing.T) {
 	m.Header = *h
 	m.Body = b
 
-	path = createPathFromIPRouteMessage(m)
+	path = createPathFromIPRouteMessage(m, &config.ZebraConfig{})
 	pp = table.NewPath(nil, path.GetNlri(), path.IsWithdraw, path.GetPathAttrs(), time.Now(), false)
 	pp.SetIsFromExternal(path.IsFromExternal())
 	assert.Equal("::", pp.GetNexthop().String())
@@ -101,7 +119,7 @@ func Test_createPathFromIPRouteMessage(t *testing.T) {
 	m.Header = *h
 	m.Body = b
 
-	path = createPathFromIPRouteMessage(m)
+	path = createPathFromIPRouteMessage(m, &config.ZebraConfig{})
 	pp = table.NewPath(nil, path.GetNlri(), path.IsWithdraw, path.GetPathAttrs(), time.Now(), false)
 	pp.SetIsFromExternal(path.IsFromExternal())
 	assert.Equal("::", pp.GetNexthop().String())
@@ -109,3 +127,6087 @@ func Test_createPathFromIPRouteMessage(t *testing.T) {
 	assert.True(pp.IsFromExternal())
 	assert.True(pp.IsWithdraw)
 }
+
+func Test_newIPRouteBodyMed(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}
+
+	// No MED: no metric and nothing logged.
+	path := table.NewPath(peer, nlri, false, attrs, time.Now(), false)
+	body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	assert.NotNil(body)
+	assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_METRIC)
+	assert.Nil(hook.LastEntry())
+
+	// Valid MED.
+	path = table.NewPath(peer, nlri, false, append(attrs, bgp.NewPathAttributeMultiExitDisc(100)), time.Now(), false)
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	assert.NotNil(body)
+	assert.Equal(zebra.MESSAGE_METRIC, body.Message&zebra.MESSAGE_METRIC)
+	assert.Equal(uint32(100), body.Metric)
+	assert.Nil(hook.LastEntry())
+
+	// Malformed MED: still installed without metric, but the error is logged.
+	malformed := &bgp.PathAttributeUnknown{
+		PathAttribute: bgp.PathAttribute{
+			Flags: bgp.BGP_ATTR_FLAG_OPTIONAL,
+			Type:  bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC,
+		},
+		Value: []byte{0x01},
+	}
+	path = table.NewPath(peer, nlri, false, append(attrs, malformed), time.Now(), false)
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	assert.NotNil(body)
+	assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_METRIC)
+	if assert.NotNil(hook.LastEntry()) {
+		assert.Equal(log.WarnLevel, hook.LastEntry().Level)
+		assert.Contains(hook.LastEntry().Message, "malformed MED attribute")
+	}
+}
+
+func Test_trackIPRouteMultipleTables(t *testing.T) {
+	assert := assert.New(t)
+
+	z := &zebraClient{
+		config: config.ZebraConfig{
+			InstallTableIdList: []uint16{100, 200},
+		},
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	body := &zebra.IPRouteBody{
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 24,
+	}
+
+	// Installed into the main table and both policy tables.
+	assert.Equal([]uint32{0, 100, 200}, z.trackIPRoute(0, body, false))
+	assert.Len(z.installed, 1)
+
+	// Routes in other VRFs are not affected by the table list.
+	assert.Equal([]uint32{5}, z.trackIPRoute(5, body, false))
+	assert.Len(z.installed, 2)
+
+	// Withdraw reaches every table the route was installed into, even
+	// after the table list has been changed.
+	z.config.InstallTableIdList = []uint16{100}
+	assert.Equal([]uint32{0, 100, 200}, z.trackIPRoute(0, body, true))
+	assert.Equal([]uint32{5}, z.trackIPRoute(5, body, true))
+	assert.Len(z.installed, 0)
+}
+
+func Test_newIPRouteBodyIPv6VPNNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	nlri := bgp.NewLabeledVPNIPv6AddrPrefix(64, "2001:db8:1::", *bgp.NewMPLSLabelStack(100), rd)
+	for _, nexthop := range []string{"2001:db8::1", "::ffff:192.0.2.1"} {
+		mpreach := bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{nlri})
+		// The BGP nexthop of an IPv6 VPN route carries an RD.
+		buf, err := mpreach.Serialize()
+		assert.Nil(err)
+		assert.Equal(byte(24), buf[6])
+
+		path := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			mpreach,
+		}, time.Now(), false)
+		body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+		if assert.NotNil(body) {
+			// ...but the one handed over to Zebra does not.
+			assert.Len(body.Nexthops, 1)
+			assert.Equal(net.IPv6len, len(body.Nexthops[0]))
+			assert.True(net.ParseIP(nexthop).Equal(body.Nexthops[0]))
+		}
+	}
+}
+
+func Test_zebraClientDumpVrfs(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+	_, err = s.AddPath("vrf1", []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	w := s.Watch(WatchZebraSync())
+	defer w.Stop()
+	z := &zebraClient{
+		server:  s,
+		watcher: newTestWatcher(),
+	}
+	z.dumpVrfs()
+
+	// the dumped path is queued before the sync event is notified.
+	assert.Len(waitTestWatcherEvents(t, z.watcher, 2), 2)
+	select {
+	case ev := <-w.Event():
+		sync, ok := ev.(*WatchEventZebraSync)
+		if assert.True(ok) {
+			assert.Equal(1, sync.NumVrf)
+			assert.Equal(1, sync.NumPath)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("zebra sync event was not notified")
+	}
+}
+
+type brokenAsPathParam struct {
+	*bgp.As4PathParam
+}
+
+func (a *brokenAsPathParam) Serialize() ([]byte, error) {
+	return nil, fmt.Errorf("broken as path param")
+}
+
+func Test_newIPRouteBodyAsPathError(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			&brokenAsPathParam{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001})},
+		}),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+
+	// ignore (default): installed without AS_PATH silently.
+	body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_ASPATH)
+		assert.Nil(body.Aux)
+	}
+	assert.Nil(hook.LastEntry())
+
+	// log: installed without AS_PATH and the error is logged.
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{
+		AspathErrorAction: config.ZEBRA_ASPATH_ERROR_ACTION_LOG,
+	})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_ASPATH)
+	}
+	if assert.NotNil(hook.LastEntry()) {
+		assert.Equal(log.ErrorLevel, hook.LastEntry().Level)
+	}
+	hook.Reset()
+
+	// skip: not installed.
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{
+		AspathErrorAction: config.ZEBRA_ASPATH_ERROR_ACTION_SKIP,
+	})
+	assert.Nil(body)
+	if assert.NotNil(hook.LastEntry()) {
+		assert.Equal(log.ErrorLevel, hook.LastEntry().Level)
+	}
+}
+
+func Test_calculateDelayMaxDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	assert.Equal(5, m.calculateDelay(500))
+	assert.Equal(16, m.calculateDelay(1500))
+	assert.True(m.calculateDelay(1<<30) > 60)
+
+	m = newNexthopTrackingManager(nil, 5, 60, 0)
+	assert.Equal(5, m.calculateDelay(500))
+	assert.Equal(16, m.calculateDelay(1500))
+	assert.Equal(60, m.calculateDelay(1<<30))
+}
+
+func Test_calculateDelaySuppressThreshold(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	m.suppressThreshold = 2000
+	assert.Equal(5, m.calculateDelay(1500))
+	assert.Equal(24, m.calculateDelay(4500))
+
+	m = newNexthopTrackingManager(nil, 5, 0, 0)
+	m.dampingDelay = 4
+	assert.Equal(5, m.calculateDelay(500))
+	assert.Equal(8, m.calculateDelay(1500))
+}
+
+func Test_nexthopTrackingManagerCharge(t *testing.T) {
+	assert := assert.New(t)
+
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	announce := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	withdraw := announce.Clone(false)
+	withdraw.IsNexthopInvalid = true
+
+	// the same penalty by default.
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	assert.Equal(500, m.charge(pathList{announce}))
+	assert.Equal(500, m.charge(pathList{withdraw}))
+
+	m.penaltyCharge = 200
+	m.withdrawPenaltyCharge = 800
+	assert.Equal(200, m.charge(pathList{announce}))
+	assert.Equal(800, m.charge(pathList{withdraw}))
+	// withdraws alone get suppressed.
+	assert.Equal(5, m.calculateDelay(m.charge(pathList{announce})*4))
+	assert.Equal(16, m.calculateDelay(m.charge(pathList{withdraw})*2))
+}
+
+func Test_newIPRouteBodyDefaultRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}
+
+	// The default route requires a non-zero path identifier.
+	nlri := bgp.NewIPAddrPrefix(0, "0.0.0.0")
+	body, _ := newIPRouteBody(pathList{table.NewPath(peer, nlri, false, attrs, time.Now(), false)}, false, &config.ZebraConfig{})
+	assert.Nil(body)
+
+	nlri = bgp.NewIPAddrPrefix(0, "0.0.0.0")
+	nlri.SetPathLocalIdentifier(10)
+	body, _ = newIPRouteBody(pathList{table.NewPath(peer, nlri, false, attrs, time.Now(), false)}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(uint32(10), body.PathId)
+		assert.Equal(zebra.MESSAGE_PATH_ID, body.Message&zebra.MESSAGE_PATH_ID)
+	}
+
+	// Only IP unicast/VPN families are subject to it.
+	for _, rf := range []bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN} {
+		assert.True(isIPRouteFamily(rf))
+	}
+	for _, rf := range []bgp.RouteFamily{bgp.RF_EVPN, bgp.RF_FS_IPv4_UC, bgp.RF_RTC_UC, bgp.RF_IPv4_MPLS} {
+		assert.False(isIPRouteFamily(rf))
+	}
+}
+
+func Test_nexthopTrackingManagerMaxCoalesceAge(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newNexthopTrackingManager(nil, 5, 0, 2)
+	assert.Equal(2, m.scheduleDelay(500))
+	assert.Equal(2, m.scheduleDelay(1<<30))
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	w := s.Watch(WatchBestPath(false))
+	defer w.Stop()
+
+	m = newNexthopTrackingManager(s, 5, 0, 1)
+	go m.loop(context.Background())
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		// every update raises the penalty.
+		m.scheduleUpdate(pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)})
+	}
+	select {
+	case <-w.Event():
+		assert.True(time.Since(start) < 2*time.Second)
+	case <-time.After(3 * time.Second):
+		t.Fatal("scheduled update was not flushed within max coalesce age")
+	}
+}
+
+func Test_nexthopTrackingManagerZeroDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newNexthopTrackingManager(nil, 0, 0, 0)
+	assert.Equal(0, m.scheduleDelay(500))
+	m.minDelay = 1
+	assert.Equal(1, m.scheduleDelay(500))
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	w := s.Watch(WatchBestPath(false))
+	defer w.Stop()
+
+	m = newNexthopTrackingManager(s, 0, 0, 0)
+	go m.loop(context.Background())
+	// flushed right away unless the penalty of flapping nexthops kicks in.
+	start := time.Now()
+	m.scheduleUpdate(pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	select {
+	case <-w.Event():
+		assert.True(time.Since(start) < time.Second)
+	case <-time.After(3 * time.Second):
+		t.Fatal("update was not flushed immediately")
+	}
+	assert.Equal(16, m.scheduleDelay(1000))
+}
+
+func Test_nexthopTrackingManagerBypass(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(communities []uint32) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+			bgp.NewPathAttributeCommunities(communities),
+		}, time.Now(), false)
+	}
+	bypassed := newPath([]uint32{65000<<16 | 100})
+	tracked := newPath([]uint32{65000<<16 | 200})
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	c, err := table.ParseCommunity("65000:100")
+	assert.Nil(err)
+	m.bypassCommunities[c] = struct{}{}
+
+	// not registered...
+	assert.Equal(pathList{tracked}, m.filterPathToRegister(pathList{bypassed, tracked}))
+	body, _ := newNexthopRegisterBody(pathList{bypassed}, m)
+	assert.Nil(body)
+
+	// ...but still installed.
+	route, _ := newIPRouteBody(pathList{bypassed}, false, &config.ZebraConfig{})
+	if assert.NotNil(route) {
+		assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, route.Nexthops)
+	}
+
+	// invalid communities are rejected before connecting to zebra.
+	sock, msgs, cleanup := listenTestZebra(t)
+	defer cleanup()
+	_, err = newZebraClient(context.Background(), nil, &config.ZebraConfig{
+		Url:                               "unix:" + sock,
+		NexthopTriggerEnable:              true,
+		NexthopTriggerBypassCommunityList: []string{"65000:100", "invalid"},
+	})
+	assert.NotNil(err)
+	select {
+	case m := <-msgs:
+		t.Fatalf("unexpected message: %v", m)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func Test_nexthopTrackingManagerStop(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	for i := 0; i < 10; i++ {
+		// zero delay fires the timer while updates keep coming.
+		m := newNexthopTrackingManager(s, 0, 0, 0)
+		done := make(chan struct{})
+		go func() {
+			m.loop(context.Background())
+			close(done)
+		}()
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < 10; k++ {
+					m.scheduleUpdate(pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+						bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+						bgp.NewPathAttributeNextHop("192.168.0.1"),
+					}, time.Now(), false)})
+				}
+			}()
+		}
+		m.stop()
+		// senders and the timer give up instead of panicking.
+		wg.Wait()
+		m.triggerUpdatePathAfter()
+		<-done
+	}
+}
+
+func Test_flushTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 1, true)
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+
+	// a path originated into the VRF and a VPN path received from a peer
+	// and imported into it.
+	_, err = s.AddPath("vrf1", []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+	peerRd, _ := bgp.ParseRouteDistinguisher("200:1")
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.1.0", *bgp.NewMPLSLabelStack(100), peerRd)
+	_, err = s.AddPath("", []*table.Path{table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+		bgp.NewPathAttributeExtendedCommunities([]bgp.ExtendedCommunityInterface{rt}),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	rib, _, err := s.GetRib("", bgp.RF_IPv4_VPN, nil)
+	assert.Nil(err)
+	var vrfPath, peerPath *table.Path
+	for _, d := range rib.GetDestinations() {
+		for _, p := range d.GetAllKnownPathList() {
+			p = p.Clone(false)
+			p.IsNexthopInvalid = true
+			if NlriRD(p.GetNlri()) == "100:1" {
+				vrfPath = p
+			} else {
+				peerPath = p
+			}
+		}
+	}
+	if !assert.NotNil(vrfPath) || !assert.NotNil(peerPath) {
+		return
+	}
+
+	// the path of the VRF is updated into it in its local form.
+	targets := flushTargets(pathList{vrfPath, peerPath}, s.GetVrf())
+	if assert.Len(targets, 2) {
+		assert.Equal("", targets[0].vrf)
+		assert.Equal(pathList{peerPath}, targets[0].paths)
+		assert.Equal("vrf1", targets[1].vrf)
+		if assert.Len(targets[1].paths, 1) {
+			p := targets[1].paths[0]
+			assert.Equal(bgp.RF_IPv4_UC, p.GetRouteFamily())
+			assert.Equal("10.0.0.0/24", p.GetNlri().String())
+			assert.True(p.IsNexthopInvalid)
+		}
+	}
+}
+
+func Test_nexthopTrackingManagerEmptyTrigger(t *testing.T) {
+	assert := assert.New(t)
+
+	// UpdatePath() would panic on the nil server if it was called.
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	done := make(chan struct{})
+	go func() {
+		m.loop(context.Background())
+		close(done)
+	}()
+	m.isScheduled = true
+	m.triggerUpdatePathAfter()
+	// the trigger channel is unbuffered, so the first trigger has been
+	// handled once the second one is received.
+	m.triggerUpdatePathAfter()
+	m.stop()
+	<-done
+	assert.False(m.isScheduled)
+	assert.Len(m.scheduledPathList, 0)
+}
+
+func Test_nexthopTrackingManagerFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	v4 := &zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: net.ParseIP("192.0.2.1").To4()}
+	mapped := &zebra.RegisteredNexthop{Family: syscall.AF_INET6, Prefix: net.ParseIP("::ffff:192.0.2.1")}
+
+	assert.True(m.registerNexthop(0, v4))
+	assert.True(m.isRegisteredNexthop(v4))
+	assert.False(m.isRegisteredNexthop(mapped))
+	assert.True(m.registerNexthop(0, mapped))
+	assert.False(m.registerNexthop(0, mapped))
+	assert.Len(m.nexthopCache, 2)
+
+	// the IPv6 path is not mistaken for the registered IPv4 nexthop.
+	m.unregisterNexthop(syscall.AF_INET6, mapped.Prefix)
+	assert.True(m.isRegisteredNexthop(v4))
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPv6AddrPrefix(64, "2001:db8::"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("::ffff:192.0.2.1", []bgp.AddrPrefixInterface{bgp.NewIPv6AddrPrefix(64, "2001:db8::")}),
+	}, time.Now(), false)
+	assert.Equal(pathList{path}, m.filterPathToRegister(pathList{path}))
+	body, _ := newNexthopRegisterBody(pathList{path}, m)
+	if assert.NotNil(body) {
+		assert.Equal([]*zebra.RegisteredNexthop{mapped}, body.Nexthops)
+	}
+
+	m.unregisterNexthop(syscall.AF_INET, v4.Prefix)
+	assert.Len(m.nexthopCache, 0)
+}
+
+func Test_nexthopTrackingManagerZone(t *testing.T) {
+	assert := assert.New(t)
+
+	newPath := func(zone string) *table.Path {
+		peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("fe80::1"), Zone: zone}
+		return table.NewPath(peer, bgp.NewIPv6AddrPrefix(64, "2001:db8::"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI("fe80::1", []bgp.AddrPrefixInterface{bgp.NewIPv6AddrPrefix(64, "2001:db8::")}),
+		}, time.Now(), false)
+	}
+	eth0, eth1 := newPath("eth0"), newPath("eth1")
+
+	// the same link-local nexthop is tracked once per interface.
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	for _, path := range []*table.Path{eth0, eth1} {
+		body, _ := newNexthopRegisterBody(pathList{path}, m)
+		if assert.NotNil(body) && assert.Len(body.Nexthops, 1) {
+			assert.Equal("fe80::1", body.Nexthops[0].Prefix.String())
+			assert.Equal(path.GetSource().Zone, body.Nexthops[0].Zone)
+			assert.True(m.registerNexthop(0, body.Nexthops[0]))
+		}
+	}
+	assert.Len(m.nexthopCache, 2)
+	assert.True(m.isRegisteredNexthop(registeredNexthop(eth0)))
+	assert.True(m.isRegisteredNexthop(registeredNexthop(eth1)))
+	assert.Len(m.filterPathToRegister(pathList{eth0, eth1}), 0)
+	assert.Len(m.unregisterAll()[0].Nexthops, 2)
+
+	// Zebra unregisters the nexthop regardless of the interface.
+	for _, path := range []*table.Path{eth0, eth1} {
+		m.registerNexthop(0, registeredNexthop(path))
+	}
+	m.unregisterNexthop(syscall.AF_INET6, net.ParseIP("fe80::1"))
+	assert.Len(m.nexthopCache, 0)
+
+	// unless the zone is ignored.
+	m = newNexthopTrackingManager(nil, 5, 0, 0)
+	m.ignoreZone = true
+	assert.True(m.registerNexthop(0, registeredNexthop(eth0)))
+	assert.False(m.registerNexthop(0, registeredNexthop(eth1)))
+	assert.Len(m.nexthopCache, 1)
+	assert.Len(m.filterPathToRegister(pathList{eth1}), 0)
+}
+
+func Test_nexthopMetricPrecedence(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// the import policy sets MED to 100.
+	def := config.PolicyDefinition{
+		Name: "med",
+		Statements: []config.Statement{{
+			Name: "med",
+			Actions: config.Actions{
+				RouteDisposition: config.ROUTE_DISPOSITION_ACCEPT_ROUTE,
+				BgpActions: config.BgpActions{
+					SetMed: "100",
+				},
+			},
+		}},
+	}
+	policy, err := table.NewPolicy(def)
+	assert.Nil(err)
+	assert.Nil(s.AddPolicy(policy, false))
+	assert.Nil(s.AddPolicyAssignment("", table.POLICY_DIRECTION_IMPORT, []*config.PolicyDefinition{&def}, table.ROUTE_TYPE_ACCEPT))
+
+	_, err = s.AddPath("", pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	bestMed := func() uint32 {
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, nil)
+		assert.Nil(err)
+		for _, dst := range rib.GetDestinations() {
+			med, ok, err := getMed(dst.GetBestPath("", 0))
+			assert.True(ok)
+			assert.Nil(err)
+			return med
+		}
+		t.Fatal("no route")
+		return 0
+	}
+	assert.Equal(uint32(100), bestMed())
+
+	update := func(precedence config.ZebraNexthopMetricPrecedence) {
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, nil)
+		assert.Nil(err)
+		manager := &table.TableManager{
+			Tables: map[bgp.RouteFamily]*table.Table{bgp.RF_IPv4_UC: rib},
+		}
+		m := newNexthopTrackingManager(nil, 5, 0, 0)
+		m.metricPrecedence = precedence
+		paths, _, err := createPathListFromNexthopUpdateMessage(&zebra.NexthopUpdateBody{
+			Family:   uint16(syscall.AF_INET),
+			Prefix:   net.ParseIP("192.168.0.1").To4(),
+			Metric:   20,
+			Nexthops: []*zebra.Nexthop{{Type: zebra.NEXTHOP_IPV4, Addr: net.ParseIP("192.168.0.254").To4()}},
+		}, manager, m)
+		assert.Nil(err)
+		assert.Len(paths, 1)
+		assert.Nil(s.UpdatePath("", paths))
+	}
+
+	// policy (default): the policy reapplied on update wins.
+	update("")
+	assert.Equal(uint32(100), bestMed())
+	update(config.ZEBRA_NEXTHOP_METRIC_PRECEDENCE_POLICY)
+	assert.Equal(uint32(100), bestMed())
+
+	// nexthop: the IGP metric wins.
+	update(config.ZEBRA_NEXTHOP_METRIC_PRECEDENCE_NEXTHOP)
+	assert.Equal(uint32(20), bestMed())
+
+	// and back.
+	update(config.ZEBRA_NEXTHOP_METRIC_PRECEDENCE_POLICY)
+	assert.Equal(uint32(100), bestMed())
+}
+
+func Test_filterPathToRegisterSkipDefaultRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(nlri bgp.AddrPrefixInterface, nexthop string) *table.Path {
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(nexthop),
+		}
+		return table.NewPath(peer, nlri, false, attrs, time.Now(), false)
+	}
+	def := newPath(bgp.NewIPAddrPrefix(0, "0.0.0.0"), "192.168.0.1")
+	other := newPath(bgp.NewIPAddrPrefix(24, "10.0.0.0"), "192.168.0.2")
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	assert.Equal(pathList{def, other}, m.filterPathToRegister(pathList{def, other}))
+
+	m.skipDefaultRoute = true
+	assert.Equal(pathList{other}, m.filterPathToRegister(pathList{def, other}))
+	body, _ := newNexthopRegisterBody(pathList{def}, m)
+	assert.Nil(body)
+}
+
+func Test_zebraClientSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	nhtManager := newNexthopTrackingManager(nil, 5, 0, 0)
+	nhtManager.registerNexthop(0, &zebra.RegisteredNexthop{
+		Family: syscall.AF_INET,
+		Prefix: net.ParseIP("192.168.0.1").To4(),
+	})
+	z := &zebraClient{
+		dead:       make(chan struct{}),
+		tasks:      make(chan func()),
+		nhtManager: nhtManager,
+		config: config.ZebraConfig{
+			Enabled:                   true,
+			Url:                       "unix:/var/run/quagga/zserv.api",
+			Version:                   3,
+			RedistributeRouteTypeList: []config.InstallProtocolType{"connect"},
+			NexthopTriggerEnable:      true,
+			NexthopTriggerDelay:       5,
+		},
+		installed:  map[string][]uint32{"0:ipv4:10.0.0.0/24:0": {0}},
+		reconnects: 2,
+	}
+	// run the tasks the way loop() does.
+	go func() {
+		for {
+			select {
+			case f := <-z.tasks:
+				f()
+			case <-z.dead:
+				return
+			}
+		}
+	}()
+
+	b, err := z.Snapshot()
+	assert.Nil(err)
+	var m map[string]map[string]interface{}
+	assert.Nil(json.Unmarshal(b, &m))
+	assert.Equal("unix:/var/run/quagga/zserv.api", m["config"]["url"])
+	assert.Equal(float64(3), m["config"]["version"])
+	assert.Equal([]interface{}{"connect"}, m["config"]["redistribute-route-type-list"])
+	assert.Equal(true, m["config"]["nexthop-trigger-enable"])
+	assert.Equal(float64(5), m["config"]["nexthop-trigger-delay"])
+	assert.Equal(true, m["state"]["connected"])
+	assert.Equal(float64(2), m["state"]["reconnects"])
+	assert.Equal(float64(1), m["state"]["nexthop-cache-size"])
+	assert.Equal(float64(0), m["state"]["scheduled-path-size"])
+	assert.Equal(float64(1), m["state"]["installed-route-num"])
+
+	z.stop()
+	b, err = z.Snapshot()
+	assert.Nil(err)
+	m = nil
+	assert.Nil(json.Unmarshal(b, &m))
+	assert.Equal(false, m["state"]["connected"])
+	assert.Equal(float64(2), m["state"]["reconnects"])
+	assert.Equal("unix:/var/run/quagga/zserv.api", m["config"]["url"])
+}
+
+func Test_staleIPRouteNexthopFamilyChange(t *testing.T) {
+	assert := assert.New(t)
+
+	z := &zebraClient{
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	v4 := &zebra.IPRouteBody{
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 24,
+		Nexthops:     []net.IP{net.ParseIP("192.168.0.1")},
+	}
+	v6 := &zebra.IPRouteBody{
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 24,
+		Nexthops:     []net.IP{net.ParseIP("2001:db8::1")},
+	}
+
+	assert.Nil(z.staleIPRoute(0, v4))
+	z.trackIPRoute(0, v4, false)
+
+	// Same family, the route is simply replaced.
+	assert.Nil(z.staleIPRoute(0, v4))
+
+	// IPv4 to IPv6: the IPv4 route has to be withdrawn first.
+	assert.Equal(v4, z.staleIPRoute(0, v6))
+	assert.Equal([]uint32{0}, z.trackIPRoute(0, v4, true))
+	z.trackIPRoute(0, v6, false)
+	assert.Equal(v6, z.installedBody[ipRouteKey(0, v6)])
+
+	// Back to IPv4.
+	assert.Equal(v6, z.staleIPRoute(0, v4))
+	z.trackIPRoute(0, v6, true)
+	z.trackIPRoute(0, v4, false)
+	assert.Nil(z.staleIPRoute(0, v4))
+
+	// Other VRFs are tracked separately.
+	assert.Nil(z.staleIPRoute(1, v6))
+
+	z.trackIPRoute(0, v4, true)
+	assert.Len(z.installed, 0)
+	assert.Len(z.installedBody, 0)
+}
+
+func Test_resolveVrfIds(t *testing.T) {
+	assert := assert.New(t)
+
+	rd1, _ := bgp.ParseRouteDistinguisher("100:1")
+	rd2, _ := bgp.ParseRouteDistinguisher("100:2")
+	rt1 := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 1, true)
+	rt2 := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 2, true)
+	vrfs := []*table.Vrf{
+		{Name: "vrf1", Id: 1, Rd: rd1, ImportRt: []bgp.ExtendedCommunityInterface{rt1}},
+		{Name: "vrf2", Id: 2, Rd: rd2, ImportRt: []bgp.ExtendedCommunityInterface{rt2}},
+	}
+
+	newPath := func(rts ...bgp.ExtendedCommunityInterface) *table.Path {
+		nlri := bgp.NewLabeledVPNIPAddrPrefix(0, "0.0.0.0", *bgp.NewMPLSLabelStack(100), rd1)
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+		}
+		if len(rts) > 0 {
+			attrs = append(attrs, bgp.NewPathAttributeExtendedCommunities(rts))
+		}
+		return table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, attrs, time.Now(), false)
+	}
+
+	// The RD points to vrf1 but the RT to vrf2, the RT wins.
+	path := newPath(rt2)
+	ids, byRd := resolveVrfIds(path, vrfs, "")
+	assert.Equal([]uint32{2}, ids)
+	assert.False(byRd)
+	ids, byRd = resolveVrfIds(path, vrfs, config.ZEBRA_VRF_RESOLVE_MODE_RT_ONLY)
+	assert.Equal([]uint32{2}, ids)
+	assert.False(byRd)
+
+	// Every importing VRF is used.
+	path = newPath(rt1, rt2)
+	ids, byRd = resolveVrfIds(path, vrfs, config.ZEBRA_VRF_RESOLVE_MODE_RT_THEN_RD)
+	assert.Equal([]uint32{1, 2}, ids)
+	assert.False(byRd)
+
+	// Without a matching RT, the RD is used as a last resort.
+	path = newPath()
+	ids, byRd = resolveVrfIds(path, vrfs, config.ZEBRA_VRF_RESOLVE_MODE_RT_THEN_RD)
+	assert.Equal([]uint32{1}, ids)
+	assert.True(byRd)
+	ids, byRd = resolveVrfIds(path, vrfs, config.ZEBRA_VRF_RESOLVE_MODE_RT_ONLY)
+	assert.Equal([]uint32{0}, ids)
+	assert.False(byRd)
+}
+
+func Test_createPathFromIPRouteMessageImportFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	newMessage := func(command zebra.API_TYPE, prefix string, plen uint8, nexthop string) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: command,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_STATIC),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_NEXTHOP,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP(prefix),
+				PrefixLength: plen,
+				Nexthops:     []net.IP{net.ParseIP(nexthop)},
+				Api:          command,
+			},
+		}
+	}
+	v4 := newMessage(zebra.IPV4_ROUTE_ADD, "192.168.100.0", 24, "192.168.0.1")
+	v6 := newMessage(zebra.IPV6_ROUTE_ADD, "2001:db8:1::", 64, "2001:db8::1")
+
+	// every family is imported by default.
+	assert.NotNil(createPathFromIPRouteMessage(v4, &config.ZebraConfig{}))
+	assert.NotNil(createPathFromIPRouteMessage(v6, &config.ZebraConfig{}))
+
+	// IPv6 import disabled.
+	c := &config.ZebraConfig{
+		ImportFamilyList: []config.AfiSafiType{config.AFI_SAFI_TYPE_IPV4_UNICAST},
+	}
+	assert.NotNil(createPathFromIPRouteMessage(v4, c))
+	assert.Nil(createPathFromIPRouteMessage(v6, c))
+
+	_, err := newZebraClient(context.Background(), nil, &config.ZebraConfig{
+		Url:              "unix:/dev/null",
+		ImportFamilyList: []config.AfiSafiType{"ipv5-unicast"},
+	})
+	assert.NotNil(err)
+}
+
+func Test_createPathFromIPRouteMessageMalformedNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	newMessage := func(command zebra.API_TYPE, prefix string, nexthop net.IP) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: command,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_STATIC),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_NEXTHOP,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP(prefix),
+				PrefixLength: uint8(24),
+				Nexthops:     []net.IP{nexthop},
+				Api:          command,
+			},
+		}
+	}
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	// Truncated nexthop
+	assert.Nil(createPathFromIPRouteMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.100.0", net.IP{192, 168, 0}), &config.ZebraConfig{}))
+	assert.Equal(log.WarnLevel, hook.LastEntry().Level)
+
+	// IPv6 nexthop for an IPv4 route
+	hook.Reset()
+	assert.Nil(createPathFromIPRouteMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.100.0", net.ParseIP("2001:db8::1")), &config.ZebraConfig{}))
+	assert.Equal(log.WarnLevel, hook.LastEntry().Level)
+
+	// Missing nexthop address
+	hook.Reset()
+	assert.Nil(createPathFromIPRouteMessage(newMessage(zebra.IPV6_ROUTE_ADD, "2001:db8:1::", net.IP{}), &config.ZebraConfig{}))
+	assert.Equal(log.WarnLevel, hook.LastEntry().Level)
+
+	// Well-formed nexthops are still accepted.
+	path := createPathFromIPRouteMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.100.0", net.ParseIP("192.168.0.1")), &config.ZebraConfig{})
+	assert.NotNil(path)
+	assert.Equal("192.168.0.1", path.GetNexthop().String())
+	path = createPathFromIPRouteMessage(newMessage(zebra.IPV6_ROUTE_ADD, "2001:db8:1::", net.ParseIP("2001:db8::1")), &config.ZebraConfig{})
+	assert.NotNil(path)
+	assert.Equal("2001:db8::1", path.GetNexthop().String())
+}
+
+func Test_createPathFromIPRouteMessageIPv6NoNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	newMessage := func(command zebra.API_TYPE) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: command,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_STATIC),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_IFINDEX,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP("2001:db8:1::"),
+				PrefixLength: uint8(64),
+				Ifindexs:     []uint32{1},
+				Api:          command,
+			},
+		}
+	}
+
+	// By default the unspecified address is used as the nexthop, which
+	// yields an attribute that can be serialized.
+	path := createPathFromIPRouteMessage(newMessage(zebra.IPV6_ROUTE_ADD), &config.ZebraConfig{})
+	if assert.NotNil(path) {
+		assert.False(path.IsWithdraw)
+		assert.Equal("::", path.GetNexthop().String())
+		var attr *bgp.PathAttributeMpReachNLRI
+		for _, a := range path.GetPathAttrs() {
+			if mp, ok := a.(*bgp.PathAttributeMpReachNLRI); ok {
+				attr = mp
+			}
+		}
+		if assert.NotNil(attr) {
+			_, err := attr.Serialize()
+			assert.Nil(err)
+			assert.Equal(net.IPv6len, len(attr.Nexthop))
+		}
+	}
+
+	c := &config.ZebraConfig{ImportNilNexthopAction: config.ZEBRA_IMPORT_NIL_NEXTHOP_ACTION_WITHDRAW}
+	path = createPathFromIPRouteMessage(newMessage(zebra.IPV6_ROUTE_ADD), c)
+	if assert.NotNil(path) {
+		assert.True(path.IsWithdraw)
+		assert.Equal("2001:db8:1::/64", path.GetNlri().String())
+	}
+
+	path = createPathFromIPRouteMessage(newMessage(zebra.IPV6_ROUTE_DELETE), c)
+	if assert.NotNil(path) {
+		assert.True(path.IsWithdraw)
+	}
+}
+
+func Test_createPathFromIPRouteMessageEcmp(t *testing.T) {
+	assert := assert.New(t)
+
+	table.UseMultiplePaths.Enabled = true
+	defer func() { table.UseMultiplePaths.Enabled = false }()
+
+	for _, tc := range []struct {
+		command  zebra.API_TYPE
+		prefix   string
+		nexthops []net.IP
+	}{
+		{zebra.IPV4_ROUTE_ADD, "192.168.100.0", []net.IP{net.ParseIP("10.0.0.1").To4(), net.ParseIP("10.0.0.2").To4()}},
+		{zebra.IPV6_ROUTE_ADD, "2001:db8:1::", []net.IP{net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2")}},
+	} {
+		m := &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: tc.command,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_STATIC),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_NEXTHOP,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP(tc.prefix),
+				PrefixLength: uint8(64),
+				Nexthops:     tc.nexthops,
+				Api:          tc.command,
+			},
+		}
+		if tc.command == zebra.IPV4_ROUTE_ADD {
+			m.Body.(*zebra.IPRouteBody).PrefixLength = 24
+		}
+
+		path := createPathFromIPRouteMessage(m, &config.ZebraConfig{})
+		if !assert.NotNil(path) {
+			continue
+		}
+		assert.True(tc.nexthops[0].Equal(path.GetNexthop()))
+		if assert.Len(path.GetEcmpNexthops(), 1) {
+			assert.True(tc.nexthops[1].Equal(path.GetEcmpNexthops()[0]))
+		}
+
+		// both nexthops are sent back to zebra.
+		path.SetIsFromExternal(false)
+		path.SetSource(&table.PeerInfo{})
+		body, isWithdraw := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+		assert.False(isWithdraw)
+		if assert.NotNil(body) && assert.Len(body.Nexthops, 2) {
+			assert.True(tc.nexthops[0].Equal(body.Nexthops[0]))
+			assert.True(tc.nexthops[1].Equal(body.Nexthops[1]))
+		}
+	}
+}
+
+func Test_createPathFromIPRouteMessageIPv6LinkLocalNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	newMessage := func(nexthop string, ifindex uint32) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: zebra.IPV6_ROUTE_ADD,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_CONNECT),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_NEXTHOP | zebra.MESSAGE_IFINDEX,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP("2001:db8:1::"),
+				PrefixLength: uint8(64),
+				Nexthops:     []net.IP{net.ParseIP(nexthop)},
+				Ifindexs:     []uint32{ifindex},
+				Api:          zebra.IPV6_ROUTE_ADD,
+			},
+		}
+	}
+	mpReach := func(path *table.Path) *bgp.PathAttributeMpReachNLRI {
+		for _, a := range path.GetPathAttrs() {
+			if mp, ok := a.(*bgp.PathAttributeMpReachNLRI); ok {
+				return mp
+			}
+		}
+		return nil
+	}
+
+	// the link-local nexthop is carried along with the unspecified
+	// address, which yields a 32 bytes long nexthop.
+	path := createPathFromIPRouteMessage(newMessage("fe80::1", 2), &config.ZebraConfig{})
+	if assert.NotNil(path) {
+		assert.False(path.IsWithdraw)
+		assert.Equal("::", path.GetNexthop().String())
+		attr := mpReach(path)
+		if assert.NotNil(attr) {
+			assert.Equal("fe80::1", attr.LinkLocalNexthop.String())
+			buf, err := attr.Serialize()
+			assert.Nil(err)
+			decoded := &bgp.PathAttributeMpReachNLRI{}
+			if assert.Nil(decoded.DecodeFromBytes(buf)) {
+				assert.Equal("::", decoded.Nexthop.String())
+				assert.Equal("fe80::1", decoded.LinkLocalNexthop.String())
+			}
+		}
+	}
+
+	// without interface, the link-local nexthop is dropped.
+	path = createPathFromIPRouteMessage(newMessage("fe80::1", 0), &config.ZebraConfig{})
+	if assert.NotNil(path) {
+		assert.Equal("::", path.GetNexthop().String())
+		attr := mpReach(path)
+		if assert.NotNil(attr) {
+			assert.Nil(attr.LinkLocalNexthop)
+		}
+	}
+
+	// global nexthops are kept as is.
+	path = createPathFromIPRouteMessage(newMessage("2001:db8::1", 2), &config.ZebraConfig{})
+	if assert.NotNil(path) {
+		assert.Equal("2001:db8::1", path.GetNexthop().String())
+		attr := mpReach(path)
+		if assert.NotNil(attr) {
+			assert.Nil(attr.LinkLocalNexthop)
+		}
+	}
+}
+
+func Test_zebraClientVrfAddedAfterDump(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	z := &zebraClient{
+		server:  s,
+		dead:    make(chan struct{}),
+		ready:   make(chan struct{}),
+		watcher: newTestWatcher(),
+	}
+	close(z.ready)
+	defer z.stop()
+	z.dumpVrfs()
+	assert.Len(z.watcher.realCh, 0)
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+	_, err = s.AddPath("vrf1", []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	// dumpVrf queues both an update and a best path event.
+	waitDump := func() {
+		for i := 0; i < 2; i++ {
+			select {
+			case ev := <-z.watcher.realCh:
+				var paths []*table.Path
+				var vrf map[string]uint32
+				switch msg := ev.(type) {
+				case *WatchEventUpdate:
+					paths, vrf = msg.PathList, msg.Vrf
+				case *WatchEventBestPath:
+					paths, vrf = msg.PathList, msg.Vrf
+				}
+				if assert.Len(paths, 1) {
+					assert.Equal(uint32(1), vrf[paths[0].GetNlri().String()])
+				}
+			case <-time.After(time.Second):
+				t.Fatal("paths of the added vrf were not dumped")
+			}
+		}
+	}
+
+	z.vrfAdded("vrf1", 1, rd)
+	waitDump()
+
+	// dumping again is a no-op.
+	z.vrfAdded("vrf1", 1, rd)
+	z.dumpVrfs()
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(z.watcher.realCh, 0)
+
+	// re-added VRFs are dumped again.
+	z.vrfDeleted("vrf1")
+	z.vrfAdded("vrf1", 1, rd)
+	waitDump()
+}
+
+func Test_zebraClientDumpVrfBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+	const numPath = 5000
+	paths := make([]*table.Path, 0, numPath)
+	for i := 0; i < numPath; i++ {
+		paths = append(paths, table.NewPath(nil, bgp.NewIPAddrPrefix(24, fmt.Sprintf("10.%d.%d.0", i/256, i%256)), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false))
+	}
+	_, err = s.AddPath("vrf1", paths)
+	assert.Nil(err)
+
+	const batchSize = 100
+	z := &zebraClient{
+		server:  s,
+		config:  config.ZebraConfig{DumpBatchSize: batchSize},
+		dead:    make(chan struct{}),
+		ready:   make(chan struct{}),
+		watcher: newTestWatcher(),
+	}
+	close(z.ready)
+	defer z.stop()
+
+	// the events are taken while the paths are dumped, recording the
+	// largest backlog of the watcher.
+	var maxBacklog int
+	sent := make(map[string]bool)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for len(sent) < numPath {
+			select {
+			case ev := <-z.watcher.realCh:
+				if msg, ok := ev.(*WatchEventBestPath); ok {
+					for _, p := range msg.PathList {
+						sent[p.GetNlri().String()] = true
+					}
+				}
+				if n := z.watcher.ch.Len(); n > maxBacklog {
+					maxBacklog = n
+				}
+			case <-time.After(5 * time.Second):
+				return
+			}
+		}
+	}()
+	z.dumpVrfs()
+	<-done
+
+	assert.Len(sent, numPath)
+	// a batch queues an update and a best path event per destination on
+	// top of the backlog left by the previous one.
+	assert.True(maxBacklog <= 3*batchSize, "backlog of %d events", maxBacklog)
+}
+
+func Test_newIPRouteBodyNilNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(nexthop string) *table.Path {
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		}
+		if nexthop != "" {
+			attrs = append(attrs, bgp.NewPathAttributeNextHop(nexthop))
+		}
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, attrs, time.Now(), false)
+	}
+	path := newPath("")
+	assert.Equal(0, len(path.GetNexthop()))
+
+	// install (default): sent without nexthop.
+	for _, action := range []config.ZebraNilNexthopAction{"", config.ZEBRA_NIL_NEXTHOP_ACTION_INSTALL} {
+		body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{NilNexthopAction: action})
+		if assert.NotNil(body) {
+			assert.Len(body.Nexthops, 0)
+			assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_NEXTHOP)
+		}
+	}
+
+	// skip: not sent at all.
+	c := &config.ZebraConfig{NilNexthopAction: config.ZEBRA_NIL_NEXTHOP_ACTION_SKIP}
+	body, _ := newIPRouteBody(pathList{path}, false, c)
+	assert.Nil(body)
+
+	// skip: paths with a nexthop are still sent.
+	body, _ = newIPRouteBody(pathList{path, newPath("192.168.0.1")}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, body.Nexthops)
+	}
+
+	// self route withdraw does not depend on the path nexthop.
+	body, _ = newIPRouteBody(pathList{path}, true, c)
+	assert.NotNil(body)
+}
+
+func Test_unspecifiedNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	v4 := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("0.0.0.0"),
+	}, time.Now(), false)
+	nlri := bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")
+	v6 := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("::", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)
+
+	for _, path := range []*table.Path{v4, v6} {
+		assert.True(isUnspecifiedNexthop(path.GetNexthop()))
+
+		// never registered for nexthop tracking.
+		m := newNexthopTrackingManager(nil, 5, 0, 0)
+		assert.Len(m.filterPathToRegister(pathList{path}), 0)
+
+		// installed without the nexthop like a route without one...
+		body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+		if assert.NotNil(body) {
+			assert.Len(body.Nexthops, 0)
+			assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_NEXTHOP)
+		}
+
+		// ...or skipped along with them.
+		c := &config.ZebraConfig{NilNexthopAction: config.ZEBRA_NIL_NEXTHOP_ACTION_SKIP}
+		body, _ = newIPRouteBody(pathList{path}, false, c)
+		assert.Nil(body)
+	}
+}
+
+func Test_newIPRouteBodyNeighborDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &config.ZebraConfig{
+		NeighborDistanceList: []config.NeighborDistance{
+			{Address: "192.0.2.1", Distance: 15},
+			{Address: "2001:db8::2", Distance: 30},
+		},
+	}
+	newPath := func(peer *table.PeerInfo) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)
+	}
+
+	for _, tt := range []struct {
+		address  string
+		distance uint8
+	}{
+		{"192.0.2.1", 15},
+		{"2001:db8::2", 30},
+		{"192.0.2.3", 0},
+	} {
+		peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP(tt.address)}
+		body, _ := newIPRouteBody(pathList{newPath(peer)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(tt.distance, body.Distance)
+			assert.Equal(tt.distance != 0, body.Message&zebra.MESSAGE_DISTANCE != 0)
+		}
+	}
+}
+
+func Test_newIPRouteBodyIbgpEbgpDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &config.ZebraConfig{
+		EbgpDistance: 20,
+		IbgpDistance: 200,
+		NeighborDistanceList: []config.NeighborDistance{
+			{Address: "192.0.2.1", Distance: 15},
+		},
+	}
+	newPath := func(peer *table.PeerInfo) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)
+	}
+
+	for _, tt := range []struct {
+		peer     *table.PeerInfo
+		distance uint8
+	}{
+		{&table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("192.0.2.2")}, 20},
+		{&table.PeerInfo{AS: 65000, LocalAS: 65000, Address: net.ParseIP("192.0.2.3")}, 200},
+		// neighbor-distance takes precedence.
+		{&table.PeerInfo{AS: 65000, LocalAS: 65000, Address: net.ParseIP("192.0.2.1")}, 15},
+	} {
+		body, _ := newIPRouteBody(pathList{newPath(tt.peer)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(tt.distance, body.Distance)
+			assert.Equal(zebra.MESSAGE_DISTANCE, body.Message&zebra.MESSAGE_DISTANCE)
+		}
+	}
+
+	// unset: left to zebra.
+	c = &config.ZebraConfig{IbgpDistance: 200}
+	body, _ := newIPRouteBody(pathList{newPath(&table.PeerInfo{AS: 65001, LocalAS: 65000})}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(uint8(0), body.Distance)
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_DISTANCE)
+	}
+}
+
+func Test_zebraClientDumpVrfsInvalidNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+	for _, prefix := range []string{"10.0.0.0", "10.0.1.0"} {
+		_, err = s.AddPath("vrf1", []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, prefix), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)})
+		assert.Nil(err)
+	}
+	// the nexthop of 10.0.1.0/24 has been invalidated by nexthop tracking.
+	s.mgmtOperation(func() error {
+		for _, dst := range s.globalRib.Tables[bgp.RF_IPv4_VPN].GetDestinations() {
+			for _, p := range dst.GetAllKnownPathList() {
+				if p.GetNlri().String() == "100:1:10.0.1.0/24" {
+					p.IsNexthopInvalid = true
+				}
+			}
+		}
+		return nil
+	}, false)
+
+	z := &zebraClient{
+		server:  s,
+		watcher: newTestWatcher(),
+	}
+	z.dumpVrfs()
+
+	// only the update and best path events of 10.0.0.0/24 are queued.
+	evs := waitTestWatcherEvents(t, z.watcher, 2)
+	select {
+	case <-z.watcher.realCh:
+		t.Fatal("unexpected event")
+	case <-time.After(100 * time.Millisecond):
+	}
+	for _, ev := range evs {
+		if update, ok := ev.(*WatchEventUpdate); ok && assert.Len(update.PathList, 1) {
+			assert.Equal("10.0.0.0/24", update.PathList[0].GetNlri().String())
+		}
+	}
+}
+
+func Test_zebraClientDumpVrfsSmallChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	numPath := 32
+	for i := 1; i <= 2; i++ {
+		rd, _ := bgp.ParseRouteDistinguisher(fmt.Sprintf("100:%d", i))
+		rt, _ := bgp.ParseRouteTarget(fmt.Sprintf("100:%d", i))
+		name := fmt.Sprintf("vrf%d", i)
+		err = s.AddVrf(name, uint32(i), rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+		assert.Nil(err)
+		for j := 0; j < numPath/2; j++ {
+			_, err = s.AddPath(name, []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, fmt.Sprintf("10.0.%d.0", j)), false, []bgp.PathAttributeInterface{
+				bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+				bgp.NewPathAttributeNextHop("192.168.0.1"),
+			}, time.Now(), false)})
+			assert.Nil(err)
+		}
+	}
+
+	w := s.Watch(WatchChannelSize(1))
+	defer w.Stop()
+	assert.Equal(1, cap(w.realCh))
+	z := &zebraClient{
+		server:  s,
+		watcher: w,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		z.dumpVrfs()
+		close(done)
+	}()
+
+	// consume the events the way loop() does, calling into the server for
+	// each of them.
+	n := 0
+	timeout := time.After(5 * time.Second)
+	for n < numPath*2 {
+		select {
+		case <-w.Event():
+			s.GetVrf()
+			n++
+		case <-timeout:
+			t.Fatalf("dump got stuck after %d events", n)
+		}
+	}
+	select {
+	case <-done:
+	case <-timeout:
+		t.Fatal("dump did not complete")
+	}
+}
+
+func Test_rfListFromNexthopUpdateBody(t *testing.T) {
+	assert := assert.New(t)
+
+	v4 := []bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN}
+	all := []bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv4_VPN, bgp.RF_IPv6_UC, bgp.RF_IPv6_VPN}
+	for _, tt := range []struct {
+		family uint16
+		prefix string
+		rfList []bgp.RouteFamily
+	}{
+		{uint16(syscall.AF_INET), "192.168.0.1", v4},
+		{uint16(syscall.AF_INET6), "2001:db8::1", all},
+		// unknown family
+		{0, "192.168.0.1", v4},
+		{0xffff, "2001:db8::1", all},
+		// family and prefix disagree
+		{uint16(syscall.AF_INET6), "192.168.0.1", all},
+		{0, "", nil},
+	} {
+		body := &zebra.NexthopUpdateBody{
+			Family: tt.family,
+			Prefix: net.ParseIP(tt.prefix),
+		}
+		assert.Equal(tt.rfList, rfListFromNexthopUpdateBody(body), "family %d, prefix %s", tt.family, tt.prefix)
+	}
+}
+
+func Test_createPathListFromNexthopUpdateMessageMultiFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("2001:db8::1")}
+	nlri4 := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	nlri6 := bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")
+	manager := table.NewTableManager([]bgp.RouteFamily{bgp.RF_IPv4_UC, bgp.RF_IPv6_UC})
+	for _, nlri := range []bgp.AddrPrefixInterface{nlri4, nlri6} {
+		// the IPv4 path has an IPv6 nexthop (RFC 5549).
+		manager.Update(table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{nlri}),
+		}, time.Now(), false))
+	}
+
+	body := &zebra.NexthopUpdateBody{
+		Family: uint16(syscall.AF_INET6),
+		Prefix: net.ParseIP("2001:db8::1"),
+	}
+	paths, unregister, err := createPathListFromNexthopUpdateMessage(body, manager, newNexthopTrackingManager(nil, 5, 0, 0))
+	assert.Nil(err)
+	assert.Nil(unregister)
+	if assert.Len(paths, 2) {
+		prefixes := []string{paths[0].GetNlri().String(), paths[1].GetNlri().String()}
+		assert.Contains(prefixes, "10.0.0.0/24")
+		assert.Contains(prefixes, "2001:db8:1::/64")
+		assert.True(paths[0].IsNexthopInvalid)
+		assert.True(paths[1].IsNexthopInvalid)
+	}
+}
+
+// listenTestZebra starts a fake zebra listening on a unix socket which
+// accepts a single connection, and returns the path of the socket along
+// with the messages the fake zebra receives.
+func listenTestZebra(t *testing.T) (string, <-chan *zebra.Message, func()) {
+	return listenTestZebraVersion(t, 2)
+}
+
+// listenTestZebraVersion is listenTestZebra speaking the given message
+// version.
+func listenTestZebraVersion(t *testing.T, version uint8) (string, <-chan *zebra.Message, func()) {
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock := filepath.Join(dir, "zserv.api")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := make(chan *zebra.Message, 64)
+	go func() {
+		defer close(msgs)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		serveTestZebra(conn, version, msgs)
+	}()
+	return sock, msgs, func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// serveTestZebra sends HELLO to the client connected by conn and then
+// writes the messages received from it to msgs until conn is closed.
+func serveTestZebra(conn net.Conn, version uint8, msgs chan<- *zebra.Message) {
+	defer conn.Close()
+	hello := &zebra.Message{
+		Header: zebra.Header{
+			Marker:  zebra.HEADER_MARKER,
+			Version: version,
+			Command: zebra.HELLO,
+		},
+		Body: &zebra.HelloBody{RedistDefault: zebra.ROUTE_BGP},
+	}
+	b, _ := hello.Serialize()
+	if _, err := conn.Write(b); err != nil {
+		return
+	}
+	for {
+		hb := make([]byte, zebra.HeaderSize(version))
+		if _, err := io.ReadFull(conn, hb); err != nil {
+			return
+		}
+		hd := &zebra.Header{}
+		if err := hd.DecodeFromBytes(hb); err != nil {
+			return
+		}
+		data := make([]byte, hd.Len-zebra.HeaderSize(version))
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return
+		}
+		m, err := zebra.ParseMessage(hd, data)
+		if err != nil || m == nil {
+			m = &zebra.Message{Header: *hd}
+		}
+		msgs <- m
+	}
+}
+
+// newTestZebra starts a fake zebra listening on a unix socket and returns
+// a client connected to it along with the messages the fake zebra receives.
+func newTestZebra(t *testing.T) (*zebra.Client, <-chan *zebra.Message, func()) {
+	sock, msgs, cleanup := listenTestZebra(t)
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 2)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return cli, msgs, func() {
+		cli.Close()
+		cleanup()
+	}
+}
+
+// newTestWatcher returns a watcher which is not registered to any server
+// and whose events are read from realCh.
+func newTestWatcher() *Watcher {
+	w := &Watcher{
+		ch:     channels.NewInfiniteChannel(),
+		realCh: make(chan WatchEvent, 8),
+	}
+	go w.loop()
+	return w
+}
+
+// waitTestWatcherEvents returns the next n events queued to w.
+func waitTestWatcherEvents(t *testing.T, w *Watcher, n int) []WatchEvent {
+	evs := make([]WatchEvent, 0, n)
+	timeout := time.After(time.Second)
+	for len(evs) < n {
+		select {
+		case ev := <-w.realCh:
+			evs = append(evs, ev)
+		case <-timeout:
+			t.Fatalf("%d events queued, want %d", len(evs), n)
+		}
+	}
+	return evs
+}
+
+// stopTestZebraClient stops z and waits for its loop() to return, which
+// is when its watcher is stopped.
+func stopTestZebraClient(t *testing.T, s *BgpServer, z *zebraClient) {
+	z.stop()
+	timeout := time.After(time.Second)
+	for {
+		n := 0
+		s.mgmtOperation(func() error {
+			n = len(s.watcherMap[WATCH_EVENT_TYPE_BEST_PATH])
+			return nil
+		}, false)
+		if n == 0 {
+			return
+		}
+		select {
+		case <-timeout:
+			t.Fatal("zebra client did not stop")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// waitZebraMessage returns the next message of the given command received
+// by the fake zebra.
+func waitZebraMessage(t *testing.T, msgs <-chan *zebra.Message, command zebra.API_TYPE) *zebra.Message {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case m, ok := <-msgs:
+			if !ok {
+				t.Fatalf("connection closed while waiting for %s", command)
+			}
+			if m.Header.Command == command {
+				return m
+			}
+		case <-timeout:
+			t.Fatalf("%s was not received", command)
+		}
+	}
+}
+
+func Test_zebraClientVrfRdChanged(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server:        s,
+		client:        cli,
+		dead:          make(chan struct{}),
+		tasks:         make(chan func()),
+		watcher:       newTestWatcher(),
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+		dumpedVrfs:    make(map[string]uint32),
+		vrfRds:        map[string]string{"vrf1": "100:1"},
+	}
+	defer z.stop()
+	// run the tasks the way loop() does.
+	go func() {
+		for {
+			select {
+			case f := <-z.tasks:
+				f()
+			case <-z.dead:
+				return
+			}
+		}
+	}()
+
+	newBody := func(pathId uint32) *zebra.IPRouteBody {
+		return &zebra.IPRouteBody{
+			Type:     zebra.ROUTE_BGP,
+			SAFI:     zebra.SAFI_UNICAST,
+			Message:  zebra.MESSAGE_NEXTHOP | zebra.MESSAGE_PATH_ID,
+			Prefix:   net.ParseIP("0.0.0.0").To4(),
+			Nexthops: []net.IP{net.ParseIP("192.168.0.1").To4()},
+			PathId:   pathId,
+		}
+	}
+	// installed into vrf1 because of the old RD.
+	byRd := newBody(1)
+	z.sendIPRoute(1, byRd, false)
+	z.rdRoutes[ipRouteKey(1, byRd)] = rdRoute{vrfId: 1, rd: "100:1"}
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	// installed into vrf1 because of its RT.
+	byRt := newBody(2)
+	z.sendIPRoute(1, byRt, false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+
+	// vrf1 is re-added with a new RD.
+	rd, _ := bgp.ParseRouteDistinguisher("100:2")
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(0, "0.0.0.0", *bgp.NewMPLSLabelStack(100), rd)
+	_, err = s.AddPath("", []*table.Path{table.NewPath(nil, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.2", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)})
+	assert.Nil(err)
+	z.dumpVrf(&table.Vrf{Name: "vrf1", Id: 1, Rd: rd})
+
+	// the route of the old RD is withdrawn, the other one is kept.
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_DELETE)
+	done := make(chan struct{})
+	z.tasks <- func() {
+		assert.Len(z.rdRoutes, 0)
+		assert.Len(z.installed, 1)
+		assert.Contains(z.installed, ipRouteKey(1, byRt))
+		close(done)
+	}
+	<-done
+
+	// the paths of the new RD are passed to be installed.
+	select {
+	case ev := <-z.watcher.realCh:
+		update, ok := ev.(*WatchEventUpdate)
+		if assert.True(ok) && assert.Len(update.PathList, 1) {
+			assert.Equal("100:2:0.0.0.0/0", update.PathList[0].GetNlri().String())
+			assert.Nil(update.Vrf)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("paths of the new rd were not passed")
+	}
+
+	assert.Equal("100:2", z.vrfRds["vrf1"])
+}
+
+func Test_zebraClientHandleUpdateIPv6DefaultRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server:        s,
+		client:        cli,
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("2001:db8::1")}
+	newPath := func(nlri bgp.AddrPrefixInterface, nexthop string) *table.Path {
+		nlri.SetPathLocalIdentifier(1)
+		return table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{nlri}),
+		}, time.Now(), false)
+	}
+
+	assert.True(isDefaultRoute(bgp.NewIPAddrPrefix(0, "0.0.0.0")))
+	assert.True(isDefaultRoute(bgp.NewIPv6AddrPrefix(0, "::")))
+	assert.False(isDefaultRoute(bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")))
+
+	z.handleUpdate(&WatchEventUpdate{
+		PathList: []*table.Path{
+			newPath(bgp.NewIPv6AddrPrefix(64, "2001:db8:1::"), "2001:db8::1"),
+			newPath(bgp.NewIPv6AddrPrefix(0, "::"), "2001:db8::1"),
+		},
+	})
+
+	m := waitZebraMessage(t, msgs, zebra.IPV6_ROUTE_ADD)
+	if body, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.Equal(uint8(0), body.PrefixLength)
+	}
+	// only the default route is handled.
+	assert.Len(z.installed, 1)
+	for key := range z.installed {
+		assert.Equal("0:ipv6:::/0:1", key)
+	}
+}
+
+func Test_zebraClientDefaultRouteNormalAfiSafi(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server: s,
+		client: cli,
+		config: config.ZebraConfig{
+			DefaultRouteNormalAfiSafiList: []config.AfiSafiType{config.AFI_SAFI_TYPE_IPV4_UNICAST},
+		},
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("192.168.0.1")}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(0, "0.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	v6 := table.NewPath(peer, bgp.NewIPv6AddrPrefix(0, "::"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{bgp.NewIPv6AddrPrefix(0, "::")}),
+	}, time.Now(), false)
+	assert.False(isSpecialDefaultRoute(path, &z.config))
+	assert.True(isSpecialDefaultRoute(v6, &z.config))
+
+	// the IPv4 default route is not installed from post-policy updates...
+	z.handleUpdate(&WatchEventUpdate{PathList: []*table.Path{path}})
+	assert.Len(z.installed, 0)
+
+	// ...but from best path events like any other prefix.
+	z.handleEvent(&WatchEventBestPath{PathList: []*table.Path{path}})
+	m := waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	if body, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.Equal(uint8(0), body.PrefixLength)
+	}
+	assert.Len(z.installed, 1)
+}
+
+func Test_zebraClientIPv6VPNDefaultRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 1, true)
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server:        s,
+		client:        cli,
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+
+	newPath := func(peer *table.PeerInfo, length uint8, prefix string) *table.Path {
+		nlri := bgp.NewLabeledVPNIPv6AddrPrefix(length, prefix, *bgp.NewMPLSLabelStack(100), rd)
+		nlri.SetPathLocalIdentifier(1)
+		return table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{nlri}),
+			bgp.NewPathAttributeExtendedCommunities([]bgp.ExtendedCommunityInterface{rt}),
+		}, time.Now(), false)
+	}
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("2001:db8::1")}
+	def := newPath(peer, 0, "::")
+	other := newPath(peer, 64, "2001:db8:1::")
+	local := newPath(&table.PeerInfo{AS: 1, LocalAS: 1}, 0, "::")
+
+	// detected regardless of the RD in front of the prefix.
+	assert.Equal("100:1:::/0", def.GetNlri().String())
+	assert.True(isDefaultRoute(def.GetNlri()))
+	assert.False(isDefaultRoute(other.GetNlri()))
+	assert.True(local.IsLocal())
+
+	// best path events leave it alone, including the self route withdraw
+	// of local paths.
+	z.handleEvent(&WatchEventBestPath{
+		PathList: []*table.Path{local, def},
+		Vrf:      map[string]uint32{def.GetNlri().String(): 1},
+	})
+	assert.Len(z.installed, 0)
+
+	// installed into the VRF importing it from post-policy updates, while
+	// local default routes are skipped.
+	z.handleUpdate(&WatchEventUpdate{PathList: []*table.Path{local, other, def}})
+	m := waitZebraMessage(t, msgs, zebra.IPV6_ROUTE_ADD)
+	if body, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.Equal(uint8(0), body.PrefixLength)
+		assert.Equal([]net.IP{net.ParseIP("2001:db8::1")}, body.Nexthops)
+	}
+	assert.Equal(map[string][]uint32{"1:ipv6:::/0:1": {1}}, z.installed)
+}
+
+func Test_newIPRouteBodyMaxMetric(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(med uint32) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+			bgp.NewPathAttributeMultiExitDisc(med),
+		}, time.Now(), false)
+	}
+
+	for _, version := range []uint8{2, 3, 4} {
+		// every version carries a 32 bits metric.
+		body, _ := newIPRouteBody(pathList{newPath(0xffffffff)}, false, &config.ZebraConfig{Version: version})
+		if assert.NotNil(body) {
+			assert.Equal(zebra.MaxMetric, body.Metric)
+		}
+
+		c := &config.ZebraConfig{Version: version, MaxMetric: 1000}
+		body, _ = newIPRouteBody(pathList{newPath(1001)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(uint32(1000), body.Metric)
+		}
+		body, _ = newIPRouteBody(pathList{newPath(999)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(uint32(999), body.Metric)
+		}
+	}
+}
+
+func Test_newIPRouteBodySendEmptyAspath(t *testing.T) {
+	assert := assert.New(t)
+
+	newPath := func(peer *table.PeerInfo) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{}),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)
+	}
+	ibgp := newPath(&table.PeerInfo{AS: 65000, LocalAS: 65000})
+	ebgp := newPath(&table.PeerInfo{AS: 65001, LocalAS: 65000})
+
+	// disabled (default): no AS_PATH.
+	body, _ := newIPRouteBody(pathList{ibgp}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_ASPATH)
+	}
+
+	// enabled: an empty AS_PATH is sent for the iBGP route.
+	c := &config.ZebraConfig{SendEmptyAspath: true}
+	body, _ = newIPRouteBody(pathList{ibgp}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_ASPATH, body.Message&zebra.MESSAGE_ASPATH)
+		assert.Len(body.Aux, 0)
+		buf, err := body.Serialize(3)
+		assert.Nil(err)
+		// the AS_PATH length is the last field.
+		assert.Equal([]byte{0, 0, 0, 0}, buf[len(buf)-4:])
+	}
+
+	// enabled: eBGP routes are not affected.
+	body, _ = newIPRouteBody(pathList{ebgp}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_ASPATH)
+	}
+}
+
+func Test_newIPRouteBodyAuxEncoding(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001})}),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+
+	// raw (default): the AS_PATH as is.
+	raw, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if !assert.NotNil(raw) {
+		return
+	}
+	assert.Equal(zebra.MESSAGE_ASPATH, raw.Message&zebra.MESSAGE_ASPATH)
+
+	// tlv: the AS_PATH as an entry.
+	c := &config.ZebraConfig{AuxEncoding: config.ZEBRA_AUX_ENCODING_TLV}
+	body, _ := newIPRouteBody(pathList{path}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_ASPATH, body.Message&zebra.MESSAGE_ASPATH)
+		entries, err := zebra.ParseAux(body.Aux)
+		assert.Nil(err)
+		assert.Equal([]zebra.AuxEntry{{Type: zebra.AUX_TYPE_ASPATH, Value: raw.Aux}}, entries)
+	}
+}
+
+func Test_newIPRouteBodyAsTranslation(t *testing.T) {
+	assert := assert.New(t)
+
+	newPath := func(peer *table.PeerInfo) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)
+	}
+	private := newPath(&table.PeerInfo{AS: 64512, LocalAS: 65000})
+	public := newPath(&table.PeerInfo{AS: 65001, LocalAS: 65000})
+
+	// without translation, the private AS is treated as eBGP.
+	body, _ := newIPRouteBody(pathList{private}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.FLAG(0), body.Flags&zebra.FLAG_IBGP)
+	}
+
+	// translated to the local AS, it is treated as iBGP.
+	c := &config.ZebraConfig{
+		AsTranslationList: []config.AsTranslation{{SourceAs: 64512, TranslatedAs: 65000}},
+	}
+	body, _ = newIPRouteBody(pathList{private}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.FLAG_IBGP|zebra.FLAG_INTERNAL, body.Flags)
+	}
+	body, _ = newIPRouteBody(pathList{public}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.FLAG(0), body.Flags)
+	}
+
+	// and vice versa.
+	c.AsTranslationList = []config.AsTranslation{{SourceAs: 65000, TranslatedAs: 65001}}
+	body, _ = newIPRouteBody(pathList{newPath(&table.PeerInfo{AS: 65000, LocalAS: 65000})}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.FLAG(0), body.Flags)
+	}
+}
+
+func Test_zebraClientLargeVrfId(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 1, true)
+	vrfs := []*table.Vrf{
+		{Name: "vrf1", Id: 70000, Rd: rd, ImportRt: []bgp.ExtendedCommunityInterface{rt}},
+	}
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rd)
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+		bgp.NewPathAttributeExtendedCommunities([]bgp.ExtendedCommunityInterface{rt}),
+	}, time.Now(), false)
+
+	// resolved without truncation.
+	ids, _ := resolveVrfIds(path, vrfs, "")
+	assert.Equal([]uint32{70000}, ids)
+
+	cli, _, cleanup := newTestZebra(t)
+	defer cleanup()
+	z := &zebraClient{
+		client:        cli,
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+	body, _ := newIPRouteBody(pathList{path}, false, &z.config)
+	if !assert.NotNil(body) {
+		return
+	}
+	assert.Equal("70000:ipv4:10.0.0.0/24:0", ipRouteKey(70000, body))
+
+	// the message version cannot carry it, so the route is skipped rather
+	// than installed into VRF 4464.
+	z.handleEvent(&WatchEventBestPath{
+		PathList: []*table.Path{path},
+		Vrf:      map[string]uint32{nlri.String(): 70000},
+	})
+	assert.Len(z.installed, 0)
+	assert.Equal(log.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(uint32(70000), hook.LastEntry().Data["VrfId"])
+}
+
+func Test_newIPRouteBodyLabelRewrite(t *testing.T) {
+	assert := assert.New(t)
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100, 101), rd)
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)
+
+	programmed := func(c *config.ZebraConfig) []byte {
+		c.AuxEncoding = config.ZEBRA_AUX_ENCODING_TLV
+		body, _ := newIPRouteBody(pathList{path}, false, c)
+		if !assert.NotNil(body) {
+			return nil
+		}
+		entries, err := zebra.ParseAux(body.Aux)
+		assert.Nil(err)
+		for _, e := range entries {
+			if e.Type == zebra.AUX_TYPE_LABEL {
+				assert.Equal(zebra.MESSAGE_ASPATH, body.Message&zebra.MESSAGE_ASPATH)
+				return e.Value
+			}
+		}
+		return nil
+	}
+
+	// none (default): as is.
+	assert.Equal([]byte{0, 0, 0, 100, 0, 0, 0, 101}, programmed(&config.ZebraConfig{}))
+
+	// swap: the top label is rewritten.
+	assert.Equal([]byte{0, 0, 0, 200, 0, 0, 0, 101}, programmed(&config.ZebraConfig{
+		LabelRewriteAction: config.ZEBRA_LABEL_REWRITE_ACTION_SWAP,
+		LabelRewriteLabel:  200,
+	}))
+
+	// pop: the top label is removed.
+	assert.Equal([]byte{0, 0, 0, 101}, programmed(&config.ZebraConfig{
+		LabelRewriteAction: config.ZEBRA_LABEL_REWRITE_ACTION_POP,
+	}))
+
+	// the path itself is untouched.
+	assert.Equal([]uint32{100, 101}, nlri.Labels.Labels)
+
+	// popping the last label leaves nothing to program.
+	assert.Len(rewriteLabels([]uint32{100}, &config.ZebraConfig{LabelRewriteAction: config.ZEBRA_LABEL_REWRITE_ACTION_POP}), 0)
+}
+
+func Test_newIPRouteBodyVpnLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	v4 := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rd)
+	v4Path := table.NewPath(peer, v4, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{v4}),
+	}, time.Now(), false)
+	v6 := bgp.NewLabeledVPNIPv6AddrPrefix(64, "2001:db8::", *bgp.NewMPLSLabelStack(200), rd)
+	v6Path := table.NewPath(peer, v6, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{v6}),
+	}, time.Now(), false)
+
+	for _, tt := range []struct {
+		path  *table.Path
+		label uint32
+	}{
+		{v4Path, 100},
+		{v6Path, 200},
+	} {
+		body, _ := newIPRouteBody(pathList{tt.path}, false, &config.ZebraConfig{Version: 4})
+		if assert.NotNil(body) {
+			assert.Equal([]uint32{tt.label}, body.Labels)
+			assert.Equal(zebra.FRR_MESSAGE_LABEL, body.Message&zebra.FRR_MESSAGE_LABEL)
+			assert.Nil(body.Aux)
+		}
+	}
+
+	// older versions cannot carry them...
+	body, _ := newIPRouteBody(pathList{v4Path}, false, &config.ZebraConfig{Version: 3})
+	if assert.NotNil(body) {
+		assert.Len(body.Labels, 0)
+	}
+	// ...and the TLV encoded aux already does.
+	body, _ = newIPRouteBody(pathList{v4Path}, false, &config.ZebraConfig{
+		Version:     4,
+		AuxEncoding: config.ZEBRA_AUX_ENCODING_TLV,
+	})
+	if assert.NotNil(body) {
+		assert.Len(body.Labels, 0)
+		assert.NotNil(body.Aux)
+	}
+}
+
+func Test_zebraClientLoopRecoverPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	cli, _, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server:        s,
+		client:        cli,
+		dead:          make(chan struct{}),
+		tasks:         make(chan func()),
+		ready:         make(chan struct{}),
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+	w := s.Watch(WatchZebraSync())
+	defer w.Stop()
+	done := make(chan struct{})
+	go func() {
+		z.loop()
+		close(done)
+	}()
+	// wait for the initial dump so that only the loop logs afterwards.
+	<-w.Event()
+
+	z.tasks <- func() { panic("injected") }
+
+	// the loop survives and keeps handling.
+	alive := make(chan struct{})
+	select {
+	case z.tasks <- func() { close(alive) }:
+	case <-time.After(time.Second):
+		t.Fatal("loop died after panic")
+	}
+	<-alive
+	assert.Equal(uint32(1), atomic.LoadUint32(&z.panics))
+	found := false
+	for _, e := range hook.AllEntries() {
+		if e.Message == "recovered from panic in zebra client" {
+			assert.Equal(log.ErrorLevel, e.Level)
+			assert.Equal("injected", e.Data["Error"])
+			found = true
+		}
+	}
+	assert.True(found)
+
+	z.stop()
+	<-done
+
+	// with reconnect, handle tells the loop to give up.
+	z.config.PanicAction = config.ZEBRA_PANIC_ACTION_RECONNECT
+	assert.False(z.handle(func() { panic("injected") }))
+	assert.True(z.handle(func() {}))
+	assert.Equal(uint32(2), atomic.LoadUint32(&z.panics))
+}
+
+func Test_zebraClientGracefulRestartReconcile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "installed.json")
+
+	newBody := func(prefix, nexthop string) *zebra.IPRouteBody {
+		return &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_BGP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			Prefix:       net.ParseIP(prefix).To4(),
+			PrefixLength: 24,
+			Nexthops:     []net.IP{net.ParseIP(nexthop).To4()},
+		}
+	}
+	newClient := func(cli *zebra.Client, timeout uint16) *zebraClient {
+		return &zebraClient{
+			client: cli,
+			config: config.ZebraConfig{
+				Version:                         2,
+				InstallStateFile:                file,
+				GracefulRestartReconcile:        true,
+				GracefulRestartReconcileTimeout: timeout,
+			},
+			dead:          make(chan struct{}),
+			tasks:         make(chan func()),
+			installed:     make(map[string][]uint32),
+			installedBody: make(map[string]*zebra.IPRouteBody),
+			rdRoutes:      make(map[string]rdRoute),
+		}
+	}
+
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+	waitZebraMessage(t, msgs, zebra.ROUTER_ID_ADD)
+	// sent returns the commands received by the fake zebra until the
+	// ROUTER_ID_ADD sent as a marker.
+	sent := func() []zebra.API_TYPE {
+		cli.SendRouterIDAdd()
+		commands := []zebra.API_TYPE{}
+		for {
+			select {
+			case m := <-msgs:
+				if m.Header.Command == zebra.ROUTER_ID_ADD {
+					return commands
+				}
+				commands = append(commands, m.Header.Command)
+			case <-time.After(time.Second):
+				t.Fatal("the marker was not received")
+			}
+		}
+	}
+
+	// the routes installed before GoBGP restarts are saved.
+	z := newClient(cli, 0)
+	for _, p := range []string{"10.0.0.0", "10.0.1.0", "10.0.2.0"} {
+		z.sendIPRoute(0, newBody(p, "192.168.0.1"), false)
+	}
+	assert.Equal([]zebra.API_TYPE{zebra.IPV4_ROUTE_ADD, zebra.IPV4_ROUTE_ADD, zebra.IPV4_ROUTE_ADD}, sent())
+	z.saveInstallState()
+	z.stop()
+
+	routes, err := loadInstallState(file)
+	assert.Nil(err)
+	assert.Len(routes, 3)
+
+	// after the restart, the unchanged route is not sent again, the
+	// changed one is.
+	z = newClient(cli, 0)
+	defer z.stop()
+	z.savedRoutes = routes
+	z.sendIPRoute(0, newBody("10.0.0.0", "192.168.0.1"), false)
+	assert.Equal([]zebra.API_TYPE{}, sent())
+	z.sendIPRoute(0, newBody("10.0.1.0", "192.168.0.2"), false)
+	assert.Equal([]zebra.API_TYPE{zebra.IPV4_ROUTE_ADD}, sent())
+
+	// the route not installed again is kept while the peers restart.
+	z.handleEvent(&WatchEventZebraSync{LocalRestarting: true})
+	assert.Equal([]zebra.API_TYPE{}, sent())
+	assert.NotNil(z.savedRoutes)
+
+	z.restartFinished()
+	(<-z.tasks)()
+	assert.Equal([]zebra.API_TYPE{zebra.IPV4_ROUTE_DELETE}, sent())
+	assert.Nil(z.savedRoutes)
+
+	routes, err = loadInstallState(file)
+	assert.Nil(err)
+	assert.Len(routes, 2)
+	assert.NotContains(routes, ipRouteKey(0, newBody("10.0.2.0", "192.168.0.1")))
+	assert.Equal("192.168.0.2", routes[ipRouteKey(0, newBody("10.0.1.0", ""))].Body.Nexthops[0].String())
+
+	// a restart which does not finish in time.
+	z = newClient(cli, 1)
+	defer z.stop()
+	z.savedRoutes = routes
+	z.handleEvent(&WatchEventZebraSync{LocalRestarting: true})
+	select {
+	case f := <-z.tasks:
+		f()
+	case <-time.After(2 * time.Second):
+		t.Fatal("reconciliation did not time out")
+	}
+	assert.Equal([]zebra.API_TYPE{zebra.IPV4_ROUTE_DELETE, zebra.IPV4_ROUTE_DELETE}, sent())
+	assert.Nil(z.savedRoutes)
+}
+
+func Test_zebraClientReconnectInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	z := &zebraClient{}
+	assert.Equal(time.Second, z.reconnectInterval())
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	sock, msgs, cleanup := listenTestZebra(t)
+	defer cleanup()
+
+	z = &zebraClient{
+		server: s,
+		config: config.ZebraConfig{
+			Enabled:           true,
+			Url:               "unix:" + sock,
+			Version:           2,
+			ReconnectInterval: 1,
+		},
+	}
+	assert.Equal(time.Second, z.reconnectInterval())
+
+	start := time.Now()
+	go z.reconnect()
+	select {
+	case <-msgs:
+		// the interval is jittered down to its half.
+		elapsed := time.Since(start)
+		assert.True(elapsed >= 500*time.Millisecond, elapsed)
+		assert.True(elapsed < 3*time.Second, elapsed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reconnection attempt")
+	}
+
+	var nz *zebraClient
+	for nz == nil {
+		s.mgmtOperation(func() error {
+			nz = s.zclient
+			return nil
+		}, false)
+	}
+	nz.stop()
+}
+
+func Test_zebraClientInterfaceSyncTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// the fake zebra never sends any interface.
+	cli, _, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	z := &zebraClient{
+		server: s,
+		client: cli,
+		config: config.ZebraConfig{
+			InterfaceSyncTimeout: 1,
+		},
+		dead:          make(chan struct{}),
+		tasks:         make(chan func()),
+		ready:         make(chan struct{}),
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+	}
+	w := s.Watch(WatchZebraSync())
+	defer w.Stop()
+	start := time.Now()
+	go z.loop()
+	defer z.stop()
+
+	// proceeds after the timeout.
+	select {
+	case <-w.Event():
+		assert.True(time.Since(start) >= time.Second)
+	case <-time.After(3 * time.Second):
+		t.Fatal("zebra client did not proceed after the interface sync timeout")
+	}
+	found := false
+	for _, e := range hook.AllEntries() {
+		if e.Message == "timed out waiting for interfaces from zebra, proceeding without them" {
+			assert.Equal(log.WarnLevel, e.Level)
+			found = true
+		}
+	}
+	assert.True(found)
+}
+
+func Test_newIPRouteBodyEVPN(t *testing.T) {
+	assert := assert.New(t)
+
+	rd := bgp.NewRouteDistinguisherTwoOctetAS(65000, 100)
+	newPath := func(nlri *bgp.EVPNNLRI) *table.Path {
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+		}
+		return table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, attrs, time.Now(), false)
+	}
+
+	// Type-5 without gateway: routed over the VTEP.
+	path := newPath(bgp.NewEVPNIPPrefixRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, 24, "10.0.0.0", "0.0.0.0", 100))
+	body, isWithdraw := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.False(isWithdraw)
+		assert.Equal("10.0.0.0", body.Prefix.String())
+		assert.Equal(net.IPv4len, len(body.Prefix))
+		assert.Equal(uint8(24), body.PrefixLength)
+		assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, body.Nexthops)
+		assert.Equal(zebra.MESSAGE_NEXTHOP, body.Message&zebra.MESSAGE_NEXTHOP)
+	}
+
+	// Type-5 with gateway: routed over the overlay gateway.
+	path = newPath(bgp.NewEVPNIPPrefixRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, 24, "10.0.0.0", "10.0.0.254", 100))
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal([]net.IP{net.ParseIP("10.0.0.254").To4()}, body.Nexthops)
+	}
+
+	// IPv6 type-5.
+	path = newPath(bgp.NewEVPNIPPrefixRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, 64, "2001:db8::", "::", 100))
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal("2001:db8::", body.Prefix.String())
+		assert.Equal(net.IPv6len, len(body.Prefix))
+		assert.Equal(uint8(64), body.PrefixLength)
+		assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, body.Nexthops)
+	}
+
+	// Type-2 with an IP address: host route.
+	path = newPath(bgp.NewEVPNMacIPAdvertisementRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, "aa:bb:cc:dd:ee:ff", "10.0.0.1", []uint32{100}))
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal("10.0.0.1", body.Prefix.String())
+		assert.Equal(uint8(32), body.PrefixLength)
+	}
+
+	// MAC-only type-2: skipped.
+	path = newPath(bgp.NewEVPNMacIPAdvertisementRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, "aa:bb:cc:dd:ee:ff", "", []uint32{100}))
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	assert.Nil(body)
+
+	// Type-3: skipped.
+	path = newPath(bgp.NewEVPNMulticastEthernetTagRoute(rd, 0, "192.168.0.1"))
+	body, _ = newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	assert.Nil(body)
+}
+
+func Test_zebraRouteSourceNeighborAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// the import policy sets MED to 100 on the routes from zebra.
+	ns, err := table.NewNeighborSet(config.NeighborSet{
+		NeighborSetName:  "zebra",
+		NeighborInfoList: []string{"127.0.0.2"},
+	})
+	assert.Nil(err)
+	assert.Nil(s.AddDefinedSet(ns))
+	def := config.PolicyDefinition{
+		Name: "zebra",
+		Statements: []config.Statement{{
+			Name: "zebra",
+			Conditions: config.Conditions{
+				MatchNeighborSet: config.MatchNeighborSet{
+					NeighborSet: "zebra",
+				},
+			},
+			Actions: config.Actions{
+				RouteDisposition: config.ROUTE_DISPOSITION_ACCEPT_ROUTE,
+				BgpActions: config.BgpActions{
+					SetMed: "100",
+				},
+			},
+		}},
+	}
+	policy, err := table.NewPolicy(def)
+	assert.Nil(err)
+	assert.Nil(s.AddPolicy(policy, false))
+	assert.Nil(s.AddPolicyAssignment("", table.POLICY_DIRECTION_IMPORT, []*config.PolicyDefinition{&def}, table.ROUTE_TYPE_ACCEPT))
+
+	update := func(prefix string, c *config.ZebraConfig) uint32 {
+		path := createPathFromIPRouteMessage(&zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: zebra.IPV4_ROUTE_ADD,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_STATIC,
+				Message:      zebra.MESSAGE_NEXTHOP | zebra.MESSAGE_METRIC,
+				SAFI:         zebra.SAFI_UNICAST,
+				Prefix:       net.ParseIP(prefix).To4(),
+				PrefixLength: 24,
+				Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4()},
+				Metric:       10,
+				Api:          zebra.IPV4_ROUTE_ADD,
+			},
+		}, c)
+		assert.NotNil(path)
+		assert.Nil(s.UpdatePath("", pathList{path}))
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, []*table.LookupPrefix{{Prefix: prefix + "/24"}})
+		assert.Nil(err)
+		for _, dst := range rib.GetDestinations() {
+			best := dst.GetBestPath("", 0)
+			assert.True(best.IsLocal())
+			med, _, _ := getMed(best)
+			return med
+		}
+		t.Fatal("no route")
+		return 0
+	}
+
+	// Without the synthetic identity, the neighbor set does not match.
+	assert.Equal(uint32(10), update("10.0.0.0", &config.ZebraConfig{}))
+
+	// With it, the neighbor set matches, while the route stays local.
+	assert.Equal(uint32(100), update("10.0.1.0", &config.ZebraConfig{SourceNeighborAddress: "127.0.0.2"}))
+}
+
+func Test_zebraClientStats(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	_, err = s.GetZebraStats()
+	assert.NotNil(err)
+
+	// NEXTHOP_REGISTER needs version 3.
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	z := &zebraClient{
+		server:        s,
+		client:        cli,
+		dead:          make(chan struct{}),
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+		reconnects:    2,
+	}
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	body, _ := newIPRouteBody(pathList{path}, false, &z.config)
+	z.sendIPRoute(0, body, false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	z.sendIPRoute(0, body, true)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_DELETE)
+
+	z.sendNexthopRegister(0, &zebra.NexthopRegisterBody{
+		Nexthops: []*zebra.RegisteredNexthop{{Family: syscall.AF_INET, Prefix: net.ParseIP("192.168.0.1").To4()}},
+	}, false)
+	waitZebraMessage(t, msgs, zebra.NEXTHOP_REGISTER)
+
+	z.handleMessage(&zebra.Message{
+		Header: zebra.Header{Command: zebra.NEXTHOP_UPDATE},
+		Body:   &zebra.NexthopUpdateBody{Family: uint16(syscall.AF_INET), Prefix: net.ParseIP("192.168.0.1").To4()},
+	})
+
+	assert.Equal(ZebraClientStats{
+		IPRoutesSent:           1,
+		IPRoutesWithdrawn:      1,
+		NexthopRegistersSent:   1,
+		NexthopUpdatesReceived: 1,
+		ReconnectCount:         2,
+	}, z.Stats())
+
+	s.mgmtOperation(func() error {
+		s.zclient = z
+		return nil
+	}, true)
+	stats, err := s.GetZebraStats()
+	assert.Nil(err)
+	assert.Equal(uint64(1), stats.IPRoutesSent)
+	s.mgmtOperation(func() error {
+		s.zclient = nil
+		return nil
+	}, true)
+}
+
+func Test_zebraClientReconnectBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	z := &zebraClient{}
+	for i, d := range []time.Duration{1, 2, 4, 8, 16, 30, 30, 30} {
+		assert.Equal(d*time.Second, z.reconnectBackoff(i), i)
+	}
+	assert.Equal(30*time.Second, z.reconnectBackoff(1000))
+
+	z.config.ReconnectInterval = 3
+	z.config.ReconnectMaxInterval = 10
+	for i, d := range []time.Duration{3, 6, 10, 10} {
+		assert.Equal(d*time.Second, z.reconnectBackoff(i), i)
+	}
+
+	// the maximum never goes below the initial interval.
+	z.config.ReconnectMaxInterval = 1
+	assert.Equal(3*time.Second, z.reconnectBackoff(2))
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+
+	// StartZebraClient keeps failing against a missing socket until the
+	// client is stopped.
+	dir, err := ioutil.TempDir("", "zebra")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	z = &zebraClient{
+		server: s,
+		dead:   make(chan struct{}),
+		config: config.ZebraConfig{
+			Enabled:           true,
+			Url:               "unix:" + filepath.Join(dir, "zserv.api"),
+			Version:           2,
+			ReconnectInterval: 1,
+		},
+	}
+	done := make(chan struct{})
+	go func() {
+		z.reconnect()
+		close(done)
+	}()
+	time.Sleep(1500 * time.Millisecond)
+	z.stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reconnect did not stop with the client")
+	}
+
+	// same when the server is stopped.
+	z.dead = make(chan struct{})
+	defer z.stop()
+	assert.Nil(s.Stop())
+	done = make(chan struct{})
+	go func() {
+		z.reconnect()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reconnect did not stop with the server")
+	}
+}
+
+func Test_nexthopTrackingManagerVrfPath(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:100")
+	rts := []bgp.ExtendedCommunityInterface{rt}
+	assert.Nil(s.AddVrf("vrf1", 1, rd, rts, rts))
+	_, err = s.AddPath("vrf1", pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	rib, _, err := s.GetRib("", bgp.RF_IPv4_VPN, nil)
+	assert.Nil(err)
+	manager := &table.TableManager{
+		Tables: map[bgp.RouteFamily]*table.Table{bgp.RF_IPv4_VPN: rib},
+	}
+	m := newNexthopTrackingManager(s, 0, 0, 0)
+	go m.loop(context.Background())
+	defer m.stop()
+
+	// the nexthop becomes unreachable.
+	paths, _, err := createPathListFromNexthopUpdateMessage(&zebra.NexthopUpdateBody{
+		Family: uint16(syscall.AF_INET),
+		Prefix: net.ParseIP("192.168.0.1").To4(),
+	}, manager, m)
+	assert.Nil(err)
+	assert.Len(paths, 1)
+	m.scheduleUpdate(paths)
+
+	// the flush reaches the VRF, with its route targets untouched.
+	invalid := func() bool {
+		rib, err := s.GetVrfRib("vrf1", bgp.RF_IPv4_UC, nil)
+		assert.Nil(err)
+		for _, dst := range rib.GetDestinations() {
+			for _, p := range dst.GetAllKnownPathList() {
+				return p.IsNexthopInvalid
+			}
+		}
+		return false
+	}
+	deadline := time.Now().Add(time.Second)
+	for !invalid() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(invalid())
+	rib, _, err = s.GetRib("", bgp.RF_IPv4_VPN, nil)
+	assert.Nil(err)
+	for _, dst := range rib.GetDestinations() {
+		for _, p := range dst.GetAllKnownPathList() {
+			assert.Equal(rts, p.GetExtCommunities())
+		}
+	}
+}
+
+func Test_zebraClientImportDedup(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	newMessage := func(command zebra.API_TYPE, nexthop string, metric uint32) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: command,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_STATIC,
+				Message:      zebra.MESSAGE_NEXTHOP | zebra.MESSAGE_METRIC,
+				SAFI:         zebra.SAFI_UNICAST,
+				Prefix:       net.ParseIP("10.0.0.0").To4(),
+				PrefixLength: 24,
+				Nexthops:     []net.IP{net.ParseIP(nexthop).To4()},
+				Metric:       metric,
+				Api:          command,
+			},
+		}
+	}
+	w := s.Watch(WatchBestPath(false))
+	defer w.Stop()
+	changed := func() bool {
+		select {
+		case <-w.Event():
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+
+	for _, dedup := range []bool{false, true} {
+		z := &zebraClient{
+			server:   s,
+			config:   config.ZebraConfig{ImportDedup: dedup},
+			imported: make(map[string]importedRoute),
+		}
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.0.1", 10))
+		assert.True(changed())
+
+		// the same route again: imported again unless deduplicated.
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.0.1", 10))
+		assert.Equal(!dedup, changed(), "dedup: %t", dedup)
+
+		// a changed metric or nexthop is imported.
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.0.1", 20))
+		assert.True(changed())
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.0.2", 20))
+		assert.True(changed())
+
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, nil)
+		assert.Nil(err)
+		for _, dst := range rib.GetDestinations() {
+			assert.Len(dst.GetAllKnownPathList(), 1)
+		}
+
+		// and it can be added again once withdrawn.
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_DELETE, "192.168.0.2", 20))
+		assert.True(changed())
+		assert.Len(z.imported, 0)
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_ADD, "192.168.0.2", 20))
+		assert.True(changed())
+		z.handleMessage(newMessage(zebra.IPV4_ROUTE_DELETE, "192.168.0.2", 20))
+		assert.True(changed())
+	}
+}
+
+func Test_zebraClientInterfaceDown(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// a path learned from a directly connected peer.
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("192.168.0.1")}
+	_, err = s.AddPath("", pathList{table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+
+	valid := func() bool {
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, nil)
+		assert.Nil(err)
+		for _, dst := range rib.GetDestinations() {
+			for _, p := range dst.GetAllKnownPathList() {
+				return !p.IsNexthopInvalid
+			}
+		}
+		return false
+	}
+	newMessage := func(command zebra.API_TYPE, body zebra.Body) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{Marker: zebra.HEADER_MARKER, Version: 2, Command: command},
+			Body:   body,
+		}
+	}
+	ifc := &zebra.InterfaceUpdateBody{Name: "eth0", Index: 2, Flags: syscall.IFF_UP}
+	addr := &zebra.InterfaceAddressUpdateBody{Index: 2, Prefix: net.ParseIP("192.168.0.254").To4(), Length: 24}
+
+	for _, enabled := range []bool{false, true} {
+		z := &zebraClient{
+			server:     s,
+			config:     config.ZebraConfig{InvalidateOnInterfaceDown: enabled},
+			interfaces: make(map[uint32]*zebraInterface),
+		}
+		z.handleMessage(newMessage(zebra.INTERFACE_ADD, ifc))
+		z.handleMessage(newMessage(zebra.INTERFACE_ADDRESS_ADD, addr))
+		assert.True(valid())
+
+		z.handleMessage(newMessage(zebra.INTERFACE_DOWN, ifc))
+		assert.Equal(!enabled, valid(), "enabled: %t", enabled)
+
+		z.handleMessage(newMessage(zebra.INTERFACE_UP, ifc))
+		assert.True(valid())
+	}
+}
+
+func Test_zebraClientCrossVrfNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	newMessage := func(vrfId uint32, command zebra.API_TYPE, body zebra.Body) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{Marker: zebra.HEADER_MARKER, Version: 3, VrfId: vrfId, Command: command},
+			Body:   body,
+		}
+	}
+	newBody := func(nexthop string) *zebra.IPRouteBody {
+		return &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_BGP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			Prefix:       net.ParseIP("10.0.0.0").To4(),
+			PrefixLength: 24,
+			Nexthops:     []net.IP{net.ParseIP(nexthop).To4()},
+		}
+	}
+
+	for _, action := range []config.ZebraCrossVrfNexthopAction{
+		config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_NONE,
+		config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE,
+		config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT,
+	} {
+		conn := newFakeZebraConn(3)
+		z := &zebraClient{
+			client:        conn,
+			config:        config.ZebraConfig{CrossVrfNexthopAction: action},
+			installed:     make(map[string][]uint32),
+			installedBody: make(map[string]*zebra.IPRouteBody),
+			interfaces:    make(map[uint32]*zebraInterface),
+		}
+		// eth1 belongs to vrf 1 and eth2 to vrf 2.
+		for _, ifc := range []struct {
+			vrfId  uint32
+			index  uint32
+			prefix string
+		}{
+			{1, 11, "192.168.1.254"},
+			{2, 12, "192.168.2.254"},
+		} {
+			z.handleMessage(newMessage(ifc.vrfId, zebra.INTERFACE_ADD, &zebra.InterfaceUpdateBody{Name: fmt.Sprintf("eth%d", ifc.vrfId), Index: ifc.index, Flags: syscall.IFF_UP}))
+			z.handleMessage(newMessage(ifc.vrfId, zebra.INTERFACE_ADDRESS_ADD, &zebra.InterfaceAddressUpdateBody{Index: ifc.index, Prefix: net.ParseIP(ifc.prefix).To4(), Length: 24}))
+		}
+
+		// the nexthop is in the same vrf.
+		z.sendIPRoute(1, newBody("192.168.1.1"), false)
+		c := waitFakeZebraCall(t, conn, "SendIPRoute")
+		assert.Len(c.body.(*zebra.IPRouteBody).Ifindexs, 0, "action: %s", action)
+
+		// the nexthop is in vrf 2.
+		z.sendIPRoute(1, newBody("192.168.2.1"), false)
+		switch action {
+		case config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_NONE:
+			// the interfaces are not tracked.
+			c := waitFakeZebraCall(t, conn, "SendIPRoute")
+			assert.False(c.isWithdraw)
+			assert.Len(c.body.(*zebra.IPRouteBody).Ifindexs, 0)
+			assert.Len(z.interfaces, 0)
+		case config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_RESOLVE:
+			c := waitFakeZebraCall(t, conn, "SendIPRoute")
+			body := c.body.(*zebra.IPRouteBody)
+			assert.False(c.isWithdraw)
+			assert.Equal([]uint32{12}, body.Ifindexs)
+			assert.Equal(zebra.MESSAGE_IFINDEX, body.Message&zebra.MESSAGE_IFINDEX)
+			assert.Equal("192.168.2.1", body.Nexthops[0].String())
+		case config.ZEBRA_CROSS_VRF_NEXTHOP_ACTION_REJECT:
+			// the route installed with the nexthop of vrf 1 is withdrawn.
+			c := waitFakeZebraCall(t, conn, "SendIPRoute")
+			assert.True(c.isWithdraw)
+			assert.Len(z.installed, 0)
+		}
+	}
+}
+
+func Test_newIPRouteBodyTag(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(communities ...uint32) *table.Path {
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}
+		if len(communities) > 0 {
+			attrs = append(attrs, bgp.NewPathAttributeCommunities(communities))
+		}
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, attrs, time.Now(), false)
+	}
+
+	// untagged.
+	body, _ := newIPRouteBody(pathList{newPath()}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(uint32(0), body.Tag)
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_TAG)
+	}
+
+	// tagged.
+	c := &config.ZebraConfig{
+		Tag: 100,
+		CommunityTagList: []config.CommunityTag{
+			{Community: "65000:1", Tag: 200},
+			{Community: "no-export", Tag: 300},
+		},
+	}
+	for _, tt := range []struct {
+		communities []uint32
+		tag         uint32
+	}{
+		{nil, 100},
+		{[]uint32{65000<<16 | 2}, 100},
+		{[]uint32{65000<<16 | 1}, 200},
+		{[]uint32{uint32(bgp.COMMUNITY_NO_EXPORT)}, 300},
+		// the first entry of the list wins.
+		{[]uint32{uint32(bgp.COMMUNITY_NO_EXPORT), 65000<<16 | 1}, 200},
+	} {
+		body, _ = newIPRouteBody(pathList{newPath(tt.communities...)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(tt.tag, body.Tag)
+			assert.Equal(zebra.MESSAGE_TAG, body.Message&zebra.MESSAGE_TAG)
+		}
+	}
+	c.Version = 4
+	body, _ = newIPRouteBody(pathList{newPath()}, false, c)
+	if assert.NotNil(body) {
+		assert.Equal(zebra.FRR_MESSAGE_TAG, body.Message&zebra.FRR_MESSAGE_TAG)
+	}
+
+	// withdraws carry the tag the route was installed with.
+	cli, msgs, cleanup := newTestZebra(t)
+	defer cleanup()
+	z := &zebraClient{
+		client:        cli,
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	c.Version = 2
+	body, _ = newIPRouteBody(pathList{newPath(65000<<16 | 1)}, false, c)
+	z.sendIPRoute(0, body, false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	withdraw, _ := newIPRouteBody(pathList{newPath()}, false, c)
+	b := z.withInstalledTag(0, withdraw)
+	assert.Equal(uint32(200), b.Tag)
+	assert.Equal(zebra.MESSAGE_TAG, b.Message&zebra.MESSAGE_TAG)
+	assert.Equal(uint32(100), withdraw.Tag)
+	z.sendIPRoute(0, withdraw, true)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_DELETE)
+	assert.Len(z.installedBody, 0)
+
+	// untagged routes are withdrawn untagged.
+	c = &config.ZebraConfig{}
+	body, _ = newIPRouteBody(pathList{newPath()}, false, c)
+	z.sendIPRoute(0, body, false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	b = z.withInstalledTag(0, body)
+	assert.Equal(uint32(0), b.Tag)
+	assert.Equal(zebra.MESSAGE_FLAG(0), b.Message&zebra.MESSAGE_TAG)
+}
+
+func Test_zebraClientNexthopRegisterRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	m := newNexthopTrackingManager(nil, 1, 0, 0)
+	z := &zebraClient{
+		client:         cli,
+		nhtManager:     m,
+		failedNexthops: make(map[string]failedNexthop),
+	}
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	nexthop := net.ParseIP("192.168.0.1")
+
+	body, _ := newNexthopRegisterBody(pathList{path}, m)
+	assert.NotNil(body)
+	assert.False(m.isRegisteredNexthop(&zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: nexthop}))
+
+	// NEXTHOP_REGISTER fails to be sent with version 2.
+	cli.Version = 2
+	z.sendNexthopRegister(0, body, false)
+	assert.False(m.isRegisteredNexthop(&zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: nexthop}))
+	assert.Len(z.failedNexthops, 1)
+	z.retryNexthopRegisters()
+	assert.False(m.isRegisteredNexthop(&zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: nexthop}))
+	assert.Len(z.failedNexthops, 1)
+
+	// the nexthop is still registered by the next paths.
+	body, _ = newNexthopRegisterBody(pathList{path}, m)
+	assert.NotNil(body)
+
+	cli.Version = 3
+	z.retryNexthopRegisters()
+	m2 := waitZebraMessage(t, msgs, zebra.NEXTHOP_REGISTER)
+	if b, ok := m2.Body.(*zebra.NexthopRegisterBody); ok {
+		assert.Equal(nexthop.To4(), b.Nexthops[0].Prefix.To4())
+	}
+	assert.True(m.isRegisteredNexthop(&zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: nexthop}))
+	assert.Len(z.failedNexthops, 0)
+	assert.Equal(uint64(1), z.Stats().NexthopRegistersSent)
+
+	body, _ = newNexthopRegisterBody(pathList{path}, m)
+	assert.Nil(body)
+}
+
+func Test_newIPRouteBodyBlackholeReject(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(communities ...uint32) *table.Path {
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}
+		if len(communities) > 0 {
+			attrs = append(attrs, bgp.NewPathAttributeCommunities(communities))
+		}
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, attrs, time.Now(), false)
+	}
+	c := &config.ZebraConfig{
+		BlackholeCommunityList: []string{"65000:666"},
+		RejectCommunityList:    []string{"65000:667"},
+	}
+
+	for _, tt := range []struct {
+		communities []uint32
+		flags       zebra.FLAG
+		nexthops    int
+		nexthopType zebra.NEXTHOP_FLAG
+		frrType     zebra.NEXTHOP_FLAG
+	}{
+		{nil, 0, 1, zebra.NEXTHOP_IPV4, zebra.FRR_NEXTHOP_IPV4},
+		{[]uint32{65000<<16 | 666}, zebra.FLAG_BLACKHOLE, 0, zebra.NEXTHOP_BLACKHOLE, zebra.FRR_NEXTHOP_BLACKHOLE},
+		{[]uint32{65000<<16 | 667}, zebra.FLAG_REJECT, 1, zebra.NEXTHOP_IPV4, zebra.FRR_NEXTHOP_IPV4},
+		// blackhole takes precedence.
+		{[]uint32{65000<<16 | 667, 65000<<16 | 666}, zebra.FLAG_BLACKHOLE, 0, zebra.NEXTHOP_BLACKHOLE, zebra.FRR_NEXTHOP_BLACKHOLE},
+	} {
+		body, _ := newIPRouteBody(pathList{newPath(tt.communities...)}, false, c)
+		if !assert.NotNil(body) {
+			continue
+		}
+		assert.Equal(tt.flags, body.Flags&(zebra.FLAG_BLACKHOLE|zebra.FLAG_REJECT))
+		assert.Len(body.Nexthops, tt.nexthops)
+		assert.Equal(zebra.MESSAGE_NEXTHOP, body.Message&zebra.MESSAGE_NEXTHOP)
+
+		// the nexthop follows the header and the prefix, whose length
+		// is 1 + 3 bytes.
+		buf, err := body.Serialize(2)
+		if assert.Nil(err) {
+			assert.Equal([]byte{1, uint8(tt.nexthopType)}, buf[9:11])
+		}
+		buf, err = body.Serialize(4)
+		if assert.Nil(err) {
+			assert.Equal([]byte{1, uint8(tt.frrType)}, buf[14:16])
+		}
+	}
+}
+
+func Test_newIPRouteBodyCommunityFlagPrecedence(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(communities ...uint32) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+			bgp.NewPathAttributeCommunities(communities),
+		}, time.Now(), false)
+	}
+	const (
+		blackhole = 65000<<16 | 666
+		reject    = 65000<<16 | 667
+		install   = 65000<<16 | 668
+	)
+
+	for _, tt := range []struct {
+		precedence  config.ZebraCommunityFlagPrecedence
+		communities []uint32
+		flags       zebra.FLAG
+	}{
+		// the reject communities take precedence by default.
+		{"", []uint32{bgp.COMMUNITY_REGION_BACKUP, install}, zebra.FLAG_REJECT},
+		{"", []uint32{reject, install}, zebra.FLAG_REJECT},
+		{config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_REJECT, []uint32{bgp.COMMUNITY_REGION_BACKUP, install}, zebra.FLAG_REJECT},
+		{config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL, []uint32{bgp.COMMUNITY_REGION_BACKUP, install}, 0},
+		{config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL, []uint32{reject, install}, 0},
+		{config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL, []uint32{bgp.COMMUNITY_REGION_BACKUP}, zebra.FLAG_REJECT},
+		// blackhole is not a reject.
+		{config.ZEBRA_COMMUNITY_FLAG_PRECEDENCE_INSTALL, []uint32{blackhole, install}, zebra.FLAG_BLACKHOLE},
+	} {
+		c := &config.ZebraConfig{
+			BlackholeCommunityList:  []string{"65000:666"},
+			RejectCommunityList:     []string{"65000:667"},
+			InstallCommunityList:    []string{"65000:668"},
+			CommunityFlagPrecedence: tt.precedence,
+		}
+		body, _ := newIPRouteBody(pathList{newPath(tt.communities...)}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(tt.flags, body.Flags&(zebra.FLAG_BLACKHOLE|zebra.FLAG_REJECT), "%s %v", tt.precedence, tt.communities)
+		}
+	}
+}
+
+func Test_zebraClientStateEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	w := s.Watch(WatchZebraState())
+	defer w.Stop()
+
+	sock, _, cleanup := listenTestZebra(t)
+	defer cleanup()
+
+	// the connection has been lost as detected by loop().
+	z := &zebraClient{
+		server: s,
+		dead:   make(chan struct{}),
+		config: config.ZebraConfig{
+			Enabled:           true,
+			Url:               "unix:" + sock,
+			Version:           2,
+			ReconnectInterval: 1,
+		},
+	}
+	z.disconnect()
+
+	states := make([]ZebraState, 0, 3)
+	timeout := time.After(5 * time.Second)
+	for len(states) < 3 {
+		select {
+		case ev := <-w.Event():
+			if e, ok := ev.(*WatchEventZebraState); ok {
+				states = append(states, e.State)
+			}
+		case <-timeout:
+			t.Fatalf("missing state events: %v", states)
+		}
+	}
+	assert.Equal([]ZebraState{ZEBRA_STATE_DOWN, ZEBRA_STATE_RECONNECTING, ZEBRA_STATE_UP}, states)
+
+	var nz *zebraClient
+	s.mgmtOperation(func() error {
+		nz = s.zclient
+		return nil
+	}, false)
+	if assert.NotNil(nz) {
+		nz.stop()
+	}
+}
+
+func Test_zebraClientDumpOrderedWithUpdates(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+	addPath := func(nexthop string) {
+		_, err := s.AddPath("vrf1", []*table.Path{table.NewPath(nil, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(nexthop),
+		}, time.Now(), false)})
+		assert.Nil(err)
+	}
+	addPath("192.168.0.1")
+
+	w := s.Watch(WatchBestPath(false))
+	defer w.Stop()
+	z := &zebraClient{
+		server:  s,
+		dead:    make(chan struct{}),
+		watcher: w,
+	}
+	defer z.stop()
+
+	// the dump races with the updates of the path.
+	done := make(chan struct{})
+	go func() {
+		z.dumpVrf(&table.Vrf{Name: "vrf1", Id: 1, Rd: rd})
+		close(done)
+	}()
+	for i := 2; i <= 20; i++ {
+		addPath(fmt.Sprintf("192.168.0.%d", i))
+	}
+	<-done
+
+	// the last event processed carries the last nexthop.
+	var nexthop string
+	dumped := false
+	for {
+		select {
+		case ev := <-w.Event():
+			msg, ok := ev.(*WatchEventBestPath)
+			if !ok {
+				continue
+			}
+			if msg.Vrf != nil {
+				dumped = true
+			}
+			for _, p := range msg.PathList {
+				if NlriPrefix(p.GetNlri()) == "10.0.0.0/24" {
+					nexthop = p.GetNexthop().String()
+				}
+			}
+			continue
+		case <-time.After(200 * time.Millisecond):
+		}
+		break
+	}
+	assert.True(dumped)
+	assert.Equal("192.168.0.20", nexthop)
+}
+
+func Test_zebraVersions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]uint8{2}, zebraVersions(2))
+	assert.Equal([]uint8{3}, zebraVersions(3))
+	assert.Equal([]uint8{4, 3}, zebraVersions(4))
+	assert.Equal([]uint8{6, 5, 4, 3}, zebraVersions(6))
+	assert.Equal([]uint8{6, 5, 4, 3}, zebraVersions(7))
+}
+
+func Test_newZebraClientVersion6(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 6)
+	defer cleanup()
+
+	z, err := newZebraClient(context.Background(), s, &config.ZebraConfig{
+		Enabled: true,
+		Url:     "unix:" + sock,
+		Version: 6,
+	})
+	if !assert.Nil(err) {
+		return
+	}
+	defer stopTestZebraClient(t, s, z)
+	assert.Equal(uint8(6), z.client.MessageVersion())
+	assert.Equal(uint8(6), z.config.Version)
+
+	m := waitZebraMessage(t, msgs, zebra.FRR_ZAPI5_ROUTER_ID_ADD)
+	assert.Equal(uint8(6), m.Header.Version)
+
+	// the routes are sent with the renumbered commands and the version 6
+	// body, whose nexthops carry the VRF id.
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	path := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+		bgp.NewPathAttributeMultiExitDisc(10),
+	}, time.Now(), false)
+	body, isWithdraw := newIPRouteBody(pathList{path}, false, &z.config)
+	if !assert.NotNil(body) {
+		return
+	}
+	z.sendIPRoute(0, body, isWithdraw)
+	m = waitZebraMessage(t, msgs, zebra.FRR_ZAPI5_ROUTE_ADD)
+	data, err := m.Body.Serialize(m.Header.Version)
+	if !assert.Nil(err) {
+		return
+	}
+	decoded := &zebra.IPRouteBody{Api: zebra.FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD}
+	if !assert.Nil(decoded.DecodeFromBytes(data, 6)) {
+		return
+	}
+	assert.Equal(zebra.ROUTE_BGP, decoded.Type)
+	assert.Equal(bgp.RF_IPv4_UC, decoded.RouteFamily())
+	assert.Equal("10.0.0.0", decoded.Prefix.String())
+	assert.Equal(uint8(24), decoded.PrefixLength)
+	assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, decoded.Nexthops)
+	assert.Equal(uint32(10), decoded.Metric)
+}
+
+func Test_newZebraClientVersionFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// the fake zebra only speaks version 4 and hangs up on the others.
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "zserv.api")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	versions := make(chan uint8, 8)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 4)
+			if _, err := io.ReadFull(conn, buf); err != nil {
+				conn.Close()
+				continue
+			}
+			versions <- buf[3]
+			if buf[3] != 4 {
+				conn.Close()
+				continue
+			}
+			hello := &zebra.Message{
+				Header: zebra.Header{
+					Marker:  zebra.FRR_HEADER_MARKER,
+					Version: 4,
+					Command: zebra.FRR_HELLO,
+				},
+				Body: &zebra.HelloBody{RedistDefault: zebra.ROUTE_BGP},
+			}
+			b, _ := hello.Serialize()
+			conn.Write(b)
+			go io.Copy(ioutil.Discard, conn)
+		}
+	}()
+
+	z, err := newZebraClient(context.Background(), s, &config.ZebraConfig{
+		Enabled: true,
+		Url:     "unix:" + sock,
+		Version: 6,
+	})
+	if !assert.Nil(err) {
+		return
+	}
+	defer stopTestZebraClient(t, s, z)
+	assert.Equal(uint8(4), z.client.MessageVersion())
+	assert.Equal(uint8(4), z.config.Version)
+	for _, v := range []uint8{6, 5, 4} {
+		assert.Equal(v, <-versions)
+	}
+}
+
+func Test_newIPRouteBodyAsSet(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001, 65002}),
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SET, []uint32{65003, 65004}),
+		}),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	aux := func(params ...bgp.AsPathParamInterface) []byte {
+		buf, _ := bgp.NewPathAttributeAsPath(params).Serialize()
+		return buf[3:]
+	}
+	seq := bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001, 65002})
+
+	for _, tt := range []struct {
+		action config.ZebraAspathAsSetAction
+		aux    []byte
+	}{
+		{"", aux(seq, bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SET, []uint32{65003, 65004}))},
+		{config.ZEBRA_ASPATH_AS_SET_ACTION_KEEP, aux(seq, bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SET, []uint32{65003, 65004}))},
+		{config.ZEBRA_ASPATH_AS_SET_ACTION_FLATTEN, aux(seq, bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65003, 65004}))},
+		{config.ZEBRA_ASPATH_AS_SET_ACTION_OMIT, aux(seq)},
+	} {
+		body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{AspathAsSetAction: tt.action})
+		if assert.NotNil(body, tt.action) {
+			assert.Equal(zebra.MESSAGE_ASPATH, body.Message&zebra.MESSAGE_ASPATH, tt.action)
+			assert.Equal(tt.aux, body.Aux, tt.action)
+		}
+	}
+
+	// the path itself is left untouched.
+	assert.Equal("65001 65002 {65003,65004}", path.GetAsString())
+}
+
+func Test_zebraClientWithdrawInstalled(t *testing.T) {
+	assert := assert.New(t)
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	z := &zebraClient{
+		client: cli,
+		config: config.ZebraConfig{
+			InstallTableIdList: []uint16{100},
+			WithdrawOnShutdown: true,
+		},
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	newBody := func(prefix string) *zebra.IPRouteBody {
+		return &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_BGP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			Prefix:       net.ParseIP(prefix).To4(),
+			PrefixLength: 24,
+			Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4()},
+		}
+	}
+	// installed into the default VRF and table 100.
+	z.sendIPRoute(zebra.VRF_DEFAULT, newBody("10.0.0.0"), false)
+	// installed into vrf1 only.
+	z.sendIPRoute(1, newBody("10.0.1.0"), false)
+	for i := 0; i < 3; i++ {
+		waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	}
+
+	z.shutdown()
+	withdrawn := map[uint32]int{}
+	for i := 0; i < 3; i++ {
+		m := waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_DELETE)
+		withdrawn[m.Header.VrfId]++
+	}
+	assert.Equal(map[uint32]int{zebra.VRF_DEFAULT: 1, 100: 1, 1: 1}, withdrawn)
+	assert.Len(z.installed, 0)
+	assert.Len(z.installedBody, 0)
+	assert.Equal(uint64(3), z.Stats().IPRoutesWithdrawn)
+}
+
+func Test_zebraClientShutdownTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "zserv.api")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	// zebra sends HELLO and then stops reading.
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		hello := &zebra.Message{
+			Header: zebra.Header{
+				Marker:  zebra.HEADER_MARKER,
+				Version: 3,
+				Command: zebra.HELLO,
+			},
+			Body: &zebra.HelloBody{RedistDefault: zebra.ROUTE_BGP},
+		}
+		b, _ := hello.Serialize()
+		conn.Write(b)
+		accepted <- conn
+	}()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := <-accepted
+	defer conn.Close()
+
+	z := &zebraClient{
+		client: cli,
+		config: config.ZebraConfig{
+			WithdrawOnShutdown:   true,
+			ShutdownDrainTimeout: 1,
+		},
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	// more withdraws than the socket can buffer.
+	for i := 0; i < 100000; i++ {
+		body := &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_BGP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			Prefix:       net.IPv4(10, byte(i>>16), byte(i>>8), byte(i)).To4(),
+			PrefixLength: 32,
+			Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4()},
+		}
+		key := ipRouteKey(zebra.VRF_DEFAULT, body)
+		z.installed[key] = []uint32{zebra.VRF_DEFAULT}
+		z.installedBody[key] = body
+	}
+
+	start := time.Now()
+	z.shutdown()
+	assert.True(time.Since(start) < 2*time.Second)
+
+	// the connection has been closed without every withdraw written.
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := io.Copy(ioutil.Discard, conn)
+	assert.Nil(err)
+	assert.True(n > 0)
+	assert.True(z.Stats().IPRoutesWithdrawn < 100000)
+}
+
+func Test_zebraClientReconnectUpdatedConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	oldSock, oldMsgs, oldCleanup := listenTestZebra(t)
+	defer oldCleanup()
+	newSock, newMsgs, newCleanup := listenTestZebra(t)
+	defer newCleanup()
+
+	err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + oldSock,
+		Version:           2,
+		ReconnectInterval: 1,
+	})
+	assert.Nil(err)
+	waitZebraMessage(t, oldMsgs, zebra.HELLO)
+
+	err = s.UpdateZebraConfig(&config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + newSock,
+		Version:           2,
+		ReconnectInterval: 1,
+	})
+	assert.Nil(err)
+
+	var z *zebraClient
+	s.mgmtOperation(func() error {
+		z = s.zclient
+		return nil
+	}, false)
+	// the connection is still the one using the old config.
+	assert.Equal("unix:"+oldSock, z.config.Url)
+
+	// lose the connection, the client reconnects using the new config.
+	z.client.Close()
+	waitZebraMessage(t, newMsgs, zebra.HELLO)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		var nz *zebraClient
+		s.mgmtOperation(func() error {
+			nz = s.zclient
+			return nil
+		}, false)
+		if nz != nil && nz != z {
+			assert.Equal("unix:"+newSock, nz.config.Url)
+			stopTestZebraClient(t, s, nz)
+			return
+		}
+		select {
+		case <-timeout:
+			t.Fatal("zebra client did not reconnect")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func Test_zebraClientUnregisterNexthopsOnShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	m := newNexthopTrackingManager(nil, 1, 0, 0)
+	z := &zebraClient{
+		client:         cli,
+		nhtManager:     m,
+		failedNexthops: make(map[string]failedNexthop),
+	}
+	v4 := &zebra.RegisteredNexthop{Family: syscall.AF_INET, Prefix: net.ParseIP("192.168.0.1").To4()}
+	v6 := &zebra.RegisteredNexthop{Family: syscall.AF_INET6, Prefix: net.ParseIP("2001:db8::1")}
+	z.sendNexthopRegister(0, &zebra.NexthopRegisterBody{Nexthops: []*zebra.RegisteredNexthop{v4, v6}}, false)
+	z.sendNexthopRegister(1, &zebra.NexthopRegisterBody{Nexthops: []*zebra.RegisteredNexthop{v4}}, false)
+	for i := 0; i < 2; i++ {
+		waitZebraMessage(t, msgs, zebra.NEXTHOP_REGISTER)
+	}
+	assert.Len(m.nexthopCache, 2)
+
+	z.shutdown()
+	vrfIds := map[uint32]int{}
+	for i := 0; i < 2; i++ {
+		msg := waitZebraMessage(t, msgs, zebra.NEXTHOP_UNREGISTER)
+		vrfIds[msg.Header.VrfId]++
+	}
+	assert.Equal(map[uint32]int{0: 1, 1: 1}, vrfIds)
+	assert.Len(m.nexthopCache, 0)
+
+	// the family of each nexthop is kept to build the unregister set.
+	m.registerNexthop(0, v6)
+	m.registerNexthop(0, v4)
+	m.registerNexthop(1, v4)
+	bodies := m.unregisterAll()
+	assert.Equal(map[uint32]*zebra.NexthopRegisterBody{
+		0: {Nexthops: []*zebra.RegisteredNexthop{v6, v4}},
+		1: {Nexthops: []*zebra.RegisteredNexthop{v4}},
+	}, bodies)
+	assert.Len(m.nexthopCache, 0)
+}
+
+func Test_zebraClientReconnectVersionChange(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt, _ := bgp.ParseRouteTarget("100:1")
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+
+	oldSock, oldMsgs, oldCleanup := listenTestZebraVersion(t, 3)
+	defer oldCleanup()
+	newSock, newMsgs, newCleanup := listenTestZebraVersion(t, 4)
+	defer newCleanup()
+
+	err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + oldSock,
+		Version:           3,
+		ReconnectInterval: 1,
+		Tag:               100,
+	})
+	assert.Nil(err)
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("192.168.0.1")}
+	_, err = s.AddPath("vrf1", []*table.Path{table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)})
+	assert.Nil(err)
+	m := waitZebraMessage(t, oldMsgs, zebra.IPV4_ROUTE_ADD)
+	assert.Equal(uint8(3), m.Header.Version)
+	if b, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.NotEqual(zebra.MESSAGE_FLAG(0), b.Message&zebra.MESSAGE_TAG)
+	}
+
+	// Zebra comes back speaking version 4.
+	err = s.UpdateZebraConfig(&config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + newSock,
+		Version:           4,
+		ReconnectInterval: 1,
+		Tag:               100,
+	})
+	assert.Nil(err)
+	var z *zebraClient
+	s.mgmtOperation(func() error {
+		z = s.zclient
+		return nil
+	}, false)
+	z.client.Close()
+
+	// the route is sent again, encoded for version 4.
+	m = waitZebraMessage(t, newMsgs, zebra.FRR_IPV4_ROUTE_ADD)
+	assert.Equal(uint8(4), m.Header.Version)
+	if b, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.NotEqual(zebra.MESSAGE_FLAG(0), b.Message&zebra.FRR_MESSAGE_TAG)
+		assert.Equal(uint32(100), b.Tag)
+	}
+
+	var nz *zebraClient
+	s.mgmtOperation(func() error {
+		nz = s.zclient
+		return nil
+	}, false)
+	if assert.NotNil(nz) {
+		assert.Equal(uint8(4), nz.config.Version)
+		stopTestZebraClient(t, s, nz)
+	}
+}
+
+func Test_zebraClientEventOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	table.UseMultiplePaths.Enabled = true
+	defer func() { table.UseMultiplePaths.Enabled = false }()
+
+	cli, _, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("192.168.0.1")}
+	nlri := bgp.NewIPAddrPrefix(0, "0.0.0.0")
+	nlri.SetPathLocalIdentifier(1)
+	path := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	update := &WatchEventUpdate{
+		PathList: []*table.Path{path},
+		Vrf:      map[string]uint32{nlri.String(): 0},
+	}
+	// a stale best path event withdrawing the default route.
+	bestPath := &WatchEventBestPath{
+		MultiPathList: [][]*table.Path{{path.Clone(true)}},
+	}
+
+	for _, events := range [][]WatchEvent{
+		{update, bestPath},
+		{bestPath, update},
+	} {
+		z := &zebraClient{
+			client:        cli,
+			installed:     make(map[string][]uint32),
+			installedBody: make(map[string]*zebra.IPRouteBody),
+			rdRoutes:      make(map[string]rdRoute),
+		}
+		for _, ev := range events {
+			z.handleEvent(ev)
+		}
+		// the default route is only handled from update events.
+		assert.Equal(map[string][]uint32{"0:ipv4:0.0.0.0/0:1": {0}}, z.installed)
+	}
+}
+
+func Test_zebraClientEventOrderNonDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	cli, _, cleanup := newTestZebra(t)
+	defer cleanup()
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000, Address: net.ParseIP("192.168.0.1")}
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	newPath := func(nexthop string) *table.Path {
+		return table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(nexthop),
+		}, time.Now(), false)
+	}
+	best := newPath("192.168.0.1")
+	// a post-policy update of a path which is not the best one.
+	other := newPath("192.168.0.2")
+
+	for _, multiPath := range []bool{false, true} {
+		table.UseMultiplePaths.Enabled = multiPath
+		vrfId := uint32(1)
+		bestPath := &WatchEventBestPath{
+			PathList: []*table.Path{best},
+			Vrf:      map[string]uint32{nlri.String(): vrfId},
+		}
+		if multiPath {
+			vrfId = 0
+			bestPath = &WatchEventBestPath{
+				MultiPathList: [][]*table.Path{{best}},
+			}
+		}
+		update := &WatchEventUpdate{
+			PathList: []*table.Path{other},
+			Vrf:      map[string]uint32{nlri.String(): vrfId},
+		}
+		withdraw := &WatchEventUpdate{
+			PathList: []*table.Path{other.Clone(true)},
+			Vrf:      map[string]uint32{nlri.String(): vrfId},
+		}
+
+		for _, events := range [][]WatchEvent{
+			{bestPath, update, withdraw},
+			{update, bestPath, withdraw},
+			{update, withdraw, bestPath},
+			{withdraw, update, bestPath},
+		} {
+			z := &zebraClient{
+				client:        cli,
+				installed:     make(map[string][]uint32),
+				installedBody: make(map[string]*zebra.IPRouteBody),
+				rdRoutes:      make(map[string]rdRoute),
+			}
+			for _, ev := range events {
+				z.handleEvent(ev)
+			}
+			// the route is only handled from best path events.
+			key := fmt.Sprintf("%d:ipv4:10.0.0.0/24:0", vrfId)
+			assert.Equal(map[string][]uint32{key: {vrfId}}, z.installed)
+			if assert.Contains(z.installedBody, key) {
+				assert.Equal([]net.IP{net.ParseIP("192.168.0.1").To4()}, z.installedBody[key].Nexthops)
+			}
+		}
+	}
+	table.UseMultiplePaths.Enabled = false
+}
+
+func Test_newIPRouteBodyMtu(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	path := table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+
+	// no MTU by default.
+	body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(uint32(0), body.Mtu)
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_MTU)
+	}
+
+	for _, tt := range []struct {
+		version uint8
+		flag    zebra.MESSAGE_FLAG
+	}{
+		{2, zebra.MESSAGE_MTU},
+		{4, zebra.FRR_MESSAGE_MTU},
+	} {
+		c := &config.ZebraConfig{Version: tt.version, Mtu: 1400}
+		body, _ = newIPRouteBody(pathList{path}, false, c)
+		if assert.NotNil(body) {
+			assert.Equal(uint32(1400), body.Mtu)
+			assert.Equal(tt.flag, body.Message&tt.flag)
+			buf, err := body.Serialize(tt.version)
+			assert.Nil(err)
+			assert.Equal([]byte{0, 0, 0x05, 0x78}, buf[len(buf)-4:])
+		}
+
+		// withdraws do not carry it.
+		body, isWithdraw := newIPRouteBody(pathList{path.Clone(true)}, false, c)
+		if assert.NotNil(body) {
+			assert.True(isWithdraw)
+			assert.Equal(uint32(0), body.Mtu)
+			assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&tt.flag)
+		}
+	}
+}
+
+func Test_zebraClientContextCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	// the nexthop tracking manager alone.
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.loop(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("nexthop tracking manager did not exit")
+	}
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	sock, _, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = s.StartZebraClient(ctx, &config.ZebraConfig{
+		Enabled:              true,
+		Url:                  "unix:" + sock,
+		Version:              3,
+		NexthopTriggerEnable: true,
+		NexthopTriggerDelay:  5,
+	})
+	assert.Nil(err)
+	var z *zebraClient
+	s.mgmtOperation(func() error {
+		z = s.zclient
+		return nil
+	}, false)
+	<-z.ready
+
+	cancel()
+	// loop() stops the client, which stops the nexthop tracking manager.
+	for _, ch := range []chan struct{}{z.dead, z.nhtManager.dead} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("zebra client did not exit")
+		}
+	}
+	stopTestZebraClient(t, s, z)
+}
+
+func Test_maxMetricUnreachable(t *testing.T) {
+	assert := assert.New(t)
+
+	// export
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	newPath := func(med uint32) *table.Path {
+		return table.NewPath(peer, bgp.NewIPAddrPrefix(24, "10.0.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+			bgp.NewPathAttributeMultiExitDisc(med),
+		}, time.Now(), false)
+	}
+	c := &config.ZebraConfig{Version: 2}
+	body, isWithdraw := newIPRouteBody(pathList{newPath(math.MaxUint32)}, false, c)
+	if assert.NotNil(body) {
+		assert.False(isWithdraw)
+		assert.Equal(uint32(math.MaxUint32), body.Metric)
+	}
+	c.MaxMetricUnreachable = true
+	body, isWithdraw = newIPRouteBody(pathList{newPath(math.MaxUint32)}, false, c)
+	if assert.NotNil(body) {
+		assert.True(isWithdraw)
+	}
+	body, isWithdraw = newIPRouteBody(pathList{newPath(math.MaxUint32 - 1)}, false, c)
+	if assert.NotNil(body) {
+		assert.False(isWithdraw)
+		assert.Equal(uint32(math.MaxUint32-1), body.Metric)
+	}
+
+	// import
+	newMessage := func(metric uint32) *zebra.Message {
+		return &zebra.Message{
+			Header: zebra.Header{
+				Len:     zebra.HeaderSize(2),
+				Marker:  zebra.HEADER_MARKER,
+				Version: 2,
+				Command: zebra.IPV4_ROUTE_ADD,
+			},
+			Body: &zebra.IPRouteBody{
+				Type:         zebra.ROUTE_TYPE(zebra.ROUTE_STATIC),
+				Flags:        zebra.FLAG(zebra.FLAG_SELECTED),
+				Message:      zebra.MESSAGE_NEXTHOP | zebra.MESSAGE_METRIC,
+				SAFI:         zebra.SAFI(zebra.SAFI_UNICAST),
+				Prefix:       net.ParseIP("192.168.100.0"),
+				PrefixLength: 24,
+				Nexthops:     []net.IP{net.ParseIP("192.168.0.1")},
+				Metric:       metric,
+				Api:          zebra.IPV4_ROUTE_ADD,
+			},
+		}
+	}
+	path := createPathFromIPRouteMessage(newMessage(math.MaxUint32), &config.ZebraConfig{})
+	if assert.NotNil(path) {
+		assert.False(path.IsWithdraw)
+	}
+	path = createPathFromIPRouteMessage(newMessage(math.MaxUint32), c)
+	if assert.NotNil(path) {
+		assert.True(path.IsWithdraw)
+	}
+	path = createPathFromIPRouteMessage(newMessage(100), c)
+	if assert.NotNil(path) {
+		assert.False(path.IsWithdraw)
+	}
+}
+
+func Test_zebraClientVrfRegisterRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	sock, msgs, cleanup := listenTestZebra(t)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	closedSock, _, closedCleanup := listenTestZebra(t)
+	defer closedCleanup()
+	closed, err := zebra.NewClient("unix", closedSock, zebra.ROUTE_BGP, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	z := &zebraClient{
+		client:           closed,
+		vrfRegistrations: make(map[uint32]vrfRegistration),
+	}
+	// fails to be sent over the closed client.
+	z.SendVrfRegister(1)
+	assert.Equal(map[uint32]vrfRegistration{1: {registered: true}}, z.vrfRegistrations)
+	z.retryVrfRegisters()
+	assert.Equal(map[uint32]vrfRegistration{1: {registered: true}}, z.vrfRegistrations)
+
+	z.client = cli
+	z.retryVrfRegisters()
+	waitZebraMessage(t, msgs, zebra.VRF_REGISTER)
+	assert.Equal(map[uint32]vrfRegistration{1: {registered: true, sent: true}}, z.vrfRegistrations)
+
+	// a failed unregister is retried as well, unless registered back.
+	z.client = closed
+	z.SendVrfUnregister(1)
+	assert.Equal(map[uint32]vrfRegistration{1: {}}, z.vrfRegistrations)
+	z.client = cli
+	z.retryVrfRegisters()
+	waitZebraMessage(t, msgs, zebra.VRF_UNREGISTER)
+	assert.Len(z.vrfRegistrations, 0)
+}
+
+func Test_zebraClientNexthopUpdatePaths(t *testing.T) {
+	assert := assert.New(t)
+	log.SetLevel(log.DebugLevel)
+	defer log.SetLevel(log.InfoLevel)
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	for _, tt := range []struct {
+		prefix  string
+		nexthop string
+	}{
+		{"10.0.0.0", "192.168.0.1"},
+		{"10.0.1.0", "192.168.0.1"},
+		{"10.0.2.0", "192.168.0.2"},
+	} {
+		_, err := s.AddPath("", pathList{table.NewPath(nil, bgp.NewIPAddrPrefix(24, tt.prefix), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(tt.nexthop),
+		}, time.Now(), false)})
+		assert.Nil(err)
+	}
+
+	m := newNexthopTrackingManager(nil, 5, 0, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.loop(ctx)
+	defer m.stop()
+	z := &zebraClient{server: s, nhtManager: m}
+
+	pathsEntry := func() *log.Entry {
+		entries := hook.AllEntries()
+		for i := len(entries) - 1; i >= 0; i-- {
+			if e := entries[i]; e.Message == "paths bound to the updated nexthop" {
+				return e
+			}
+		}
+		return nil
+	}
+
+	z.handleMessage(&zebra.Message{
+		Header: zebra.Header{Command: zebra.NEXTHOP_UPDATE},
+		Body:   &zebra.NexthopUpdateBody{Family: uint16(syscall.AF_INET), Prefix: net.ParseIP("192.168.0.1").To4()},
+	})
+	if e := pathsEntry(); assert.NotNil(e) {
+		assert.Equal(2, e.Data["Paths"])
+		assert.Equal(true, e.Data["Invalid"])
+		assert.Equal(false, e.Data["Unregister"])
+	}
+	assert.Equal(uint64(2), z.Stats().NexthopUpdatePaths)
+
+	z.handleMessage(&zebra.Message{
+		Header: zebra.Header{Command: zebra.NEXTHOP_UPDATE},
+		Body: &zebra.NexthopUpdateBody{
+			Family:   uint16(syscall.AF_INET),
+			Prefix:   net.ParseIP("192.168.0.2").To4(),
+			Nexthops: []*zebra.Nexthop{{Type: zebra.NEXTHOP_IPV4, Addr: net.ParseIP("192.168.0.254").To4()}},
+		},
+	})
+	if e := pathsEntry(); assert.NotNil(e) {
+		assert.Equal(1, e.Data["Paths"])
+		assert.Equal(false, e.Data["Invalid"])
+	}
+	assert.Equal(uint64(3), z.Stats().NexthopUpdatePaths)
+
+	// no path is bound to the nexthop, which gets unregistered.
+	rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, nil)
+	assert.Nil(err)
+	manager := &table.TableManager{
+		Tables: map[bgp.RouteFamily]*table.Table{bgp.RF_IPv4_UC: rib},
+	}
+	paths, unregister, err := createPathListFromNexthopUpdateMessage(&zebra.NexthopUpdateBody{
+		Family: uint16(syscall.AF_INET),
+		Prefix: net.ParseIP("192.168.0.3").To4(),
+	}, manager, m)
+	assert.Nil(err)
+	assert.Len(paths, 0)
+	assert.NotNil(unregister)
+	if e := pathsEntry(); assert.NotNil(e) {
+		assert.Equal(0, e.Data["Paths"])
+		assert.Equal(true, e.Data["Unregister"])
+	}
+}
+
+func Test_zebraClientNexthopLabelResolution(t *testing.T) {
+	assert := assert.New(t)
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 5)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	z := &zebraClient{
+		client:        cli,
+		config:        config.ZebraConfig{Version: 5, NexthopLabelResolution: true},
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+		rdRoutes:      make(map[string]rdRoute),
+		nexthopLabels: make(map[string][]uint32),
+	}
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rd)
+	path := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)
+	installedLabels := func() []uint32 {
+		body, _ := newIPRouteBody(pathList{path}, false, &z.config)
+		if !assert.NotNil(body) {
+			return nil
+		}
+		z.sendIPRoute(1, body, false)
+		waitZebraMessage(t, msgs, zebra.FRR_ZAPI5_ROUTE_ADD)
+		return z.installedBody[ipRouteKey(1, body)].Labels
+	}
+	update := func(labels []uint32) {
+		body := &zebra.NexthopUpdateBody{
+			Family: uint16(syscall.AF_INET),
+			Prefix: net.ParseIP("192.168.0.1").To4(),
+		}
+		if labels != nil {
+			body.Nexthops = []*zebra.Nexthop{{
+				Type:   zebra.FRR_NEXTHOP_IPV4,
+				Addr:   net.ParseIP("10.1.1.1").To4(),
+				Labels: labels,
+			}}
+		}
+		z.handleMessage(&zebra.Message{
+			Header: zebra.Header{Version: 5, Command: zebra.FRR_ZAPI5_NEXTHOP_UPDATE},
+			Body:   body,
+		})
+	}
+
+	// the nexthop is not resolved with labels yet.
+	assert.Equal([]uint32{100}, installedLabels())
+
+	// the underlay labels are pushed on top of the VPN label.
+	update([]uint32{16001, 16002})
+	assert.Equal([]uint32{16001, 16002, 100}, installedLabels())
+	assert.Equal([]uint32{100}, labelStack(path))
+
+	// the nexthop is no longer resolved with labels.
+	update(nil)
+	assert.Equal([]uint32{100}, installedLabels())
+
+	// disabled.
+	update([]uint32{16001})
+	z.config.NexthopLabelResolution = false
+	assert.Equal([]uint32{100}, installedLabels())
+}
+
+func Test_newIPRouteBodySelfRouteWithdraw(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	v4 := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	v4Path := table.NewPath(peer, v4, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	v6 := bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")
+	v6Path := table.NewPath(peer, v6, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{v6}),
+	}, time.Now(), false)
+
+	for _, tt := range []struct {
+		nexthops []string
+		v4       net.IP
+		v6       net.IP
+	}{
+		// loopback by default
+		{nil, net.ParseIP("127.0.0.1").To4(), net.ParseIP("::1")},
+		{[]string{"10.255.0.1", "2001:db8::ff"}, net.ParseIP("10.255.0.1").To4(), net.ParseIP("2001:db8::ff")},
+		// the family without address keeps the loopback
+		{[]string{"2001:db8::ff"}, net.ParseIP("127.0.0.1").To4(), net.ParseIP("2001:db8::ff")},
+	} {
+		c := &config.ZebraConfig{SelfRouteWithdrawNexthopList: tt.nexthops}
+		body, _ := newIPRouteBody(pathList{v4Path}, true, c)
+		if assert.NotNil(body) {
+			assert.Equal([]net.IP{tt.v4}, body.Nexthops)
+		}
+		body, _ = newIPRouteBody(pathList{v6Path}, true, c)
+		if assert.NotNil(body) {
+			assert.Equal([]net.IP{tt.v6}, body.Nexthops)
+		}
+	}
+
+	// blackhole
+	c := &config.ZebraConfig{SelfRouteWithdrawBlackhole: true}
+	for _, path := range []*table.Path{v4Path, v6Path} {
+		body, _ := newIPRouteBody(pathList{path}, true, c)
+		if assert.NotNil(body) {
+			assert.Len(body.Nexthops, 0)
+			assert.Equal(zebra.FLAG_BLACKHOLE, body.Flags&zebra.FLAG_BLACKHOLE)
+			assert.Equal(zebra.MESSAGE_NEXTHOP, body.Message&zebra.MESSAGE_NEXTHOP)
+		}
+		// only for the self route withdraws
+		body, _ = newIPRouteBody(pathList{path}, false, c)
+		if assert.NotNil(body) {
+			assert.Len(body.Nexthops, 1)
+			assert.Equal(zebra.FLAG(0), body.Flags&zebra.FLAG_BLACKHOLE)
+		}
+	}
+
+	_, err := newZebraClient(context.Background(), nil, &config.ZebraConfig{
+		Url:                          "unix:/dev/null",
+		SelfRouteWithdrawNexthopList: []string{"192.0.2.256"},
+	})
+	assert.NotNil(err)
+}
+
+func Test_newIPRouteBodyInvalidNexthop(t *testing.T) {
+	assert := assert.New(t)
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 65000}
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	// an IPv4 route whose only nexthop is an IPv6 address.
+	path := table.NewPath(peer, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("2001:db8::1", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)
+
+	// skipped by default.
+	for _, action := range []config.ZebraInvalidNexthopAction{"", config.ZEBRA_INVALID_NEXTHOP_ACTION_SKIP} {
+		hook.Reset()
+		body, _ := newIPRouteBody(pathList{path}, false, &config.ZebraConfig{InvalidNexthopAction: action})
+		assert.Nil(body)
+		if assert.NotNil(hook.LastEntry()) {
+			assert.Equal(log.WarnLevel, hook.LastEntry().Level)
+			assert.Equal("skipping route without valid nexthop", hook.LastEntry().Message)
+		}
+	}
+
+	c := &config.ZebraConfig{InvalidNexthopAction: config.ZEBRA_INVALID_NEXTHOP_ACTION_BLACKHOLE}
+	body, isWithdraw := newIPRouteBody(pathList{path}, false, c)
+	if assert.NotNil(body) {
+		assert.False(isWithdraw)
+		assert.Len(body.Nexthops, 0)
+		assert.Equal(zebra.FLAG_BLACKHOLE, body.Flags&zebra.FLAG_BLACKHOLE)
+		assert.Equal(zebra.MESSAGE_NEXTHOP, body.Message&zebra.MESSAGE_NEXTHOP)
+	}
+
+	// withdraws are sent anyway.
+	body, isWithdraw = newIPRouteBody(pathList{path.Clone(true)}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.True(isWithdraw)
+	}
+
+	// the route is installed with its valid nexthops only.
+	other := table.NewPath(&table.PeerInfo{AS: 65002, LocalAS: 65000}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.2"),
+	}, time.Now(), false)
+	body, _ = newIPRouteBody(pathList{path, other}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal([]net.IP{net.ParseIP("192.168.0.2").To4()}, body.Nexthops)
+		assert.Equal(zebra.FLAG(0), body.Flags&zebra.FLAG_BLACKHOLE)
+	}
+}
+
+func Test_zebraClientInstallState(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "installed.json")
+
+	routes, err := loadInstallState(file)
+	assert.Nil(err)
+	assert.Nil(routes)
+
+	// VRF ids are carried in the header since version 3.
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	cli, err := zebra.NewClient("unix", sock, zebra.ROUTE_BGP, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	newClient := func() *zebraClient {
+		return &zebraClient{
+			client:        cli,
+			config:        config.ZebraConfig{Version: 3, InstallStateFile: file},
+			installed:     make(map[string][]uint32),
+			installedBody: make(map[string]*zebra.IPRouteBody),
+			rdRoutes:      make(map[string]rdRoute),
+		}
+	}
+	newBody := func(prefix string) *zebra.IPRouteBody {
+		return &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_BGP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			Prefix:       net.ParseIP(prefix).To4(),
+			PrefixLength: 24,
+			Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4()},
+		}
+	}
+
+	// the first run installs two routes and saves them.
+	z := newClient()
+	z.sendIPRoute(0, newBody("10.0.0.0"), false)
+	z.sendIPRoute(1, newBody("10.0.1.0"), false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	assert.True(z.installStateDirty)
+	z.saveInstallState()
+	assert.False(z.installStateDirty)
+
+	routes, err = loadInstallState(file)
+	assert.Nil(err)
+	assert.Equal(map[string]savedRoute{
+		ipRouteKey(0, newBody("10.0.0.0")): {VrfIds: []uint32{0}, Body: newBody("10.0.0.0")},
+		ipRouteKey(1, newBody("10.0.1.0")): {VrfIds: []uint32{1}, Body: newBody("10.0.1.0")},
+	}, routes)
+
+	// after a restart, only the first one is installed again and the
+	// other one is withdrawn once the initial sync is done.
+	z = newClient()
+	z.savedRoutes = routes
+	z.sendIPRoute(0, newBody("10.0.0.0"), false)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	z.handleEvent(&WatchEventZebraSync{})
+	m := waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_DELETE)
+	assert.Equal(uint32(1), m.Header.VrfId)
+	if b, ok := m.Body.(*zebra.IPRouteBody); ok {
+		assert.Equal("10.0.1.0", b.Prefix.String())
+	}
+	assert.Nil(z.savedRoutes)
+	assert.Equal(uint64(1), z.Stats().IPRoutesWithdrawn)
+
+	routes, err = loadInstallState(file)
+	assert.Nil(err)
+	assert.Len(routes, 1)
+	assert.Contains(routes, ipRouteKey(0, newBody("10.0.0.0")))
+
+	// a broken file is reported.
+	assert.Nil(ioutil.WriteFile(file, []byte("{"), 0600))
+	_, err = loadInstallState(file)
+	assert.NotNil(err)
+}
+
+func Test_zebraClientUnsupportedFamily(t *testing.T) {
+	assert := assert.New(t)
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	nlri := bgp.NewFlowSpecIPv4Unicast([]bgp.FlowSpecComponentInterface{
+		bgp.NewFlowSpecDestinationPrefix(bgp.NewIPAddrPrefix(24, "10.0.0.0")),
+	})
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("0.0.0.0", []bgp.AddrPrefixInterface{nlri}),
+	}, time.Now(), false)
+	ev := &WatchEventBestPath{PathList: []*table.Path{path}}
+
+	z := &zebraClient{
+		config: config.ZebraConfig{
+			UnsupportedFamilyAction: config.ZEBRA_UNSUPPORTED_FAMILY_ACTION_IGNORE,
+		},
+	}
+	z.handleEvent(ev)
+	assert.Len(hook.AllEntries(), 0)
+	assert.Nil(z.Stats().UnsupportedFamilyPaths)
+
+	// logged and counted by default, warning once in a while.
+	z = &zebraClient{}
+	z.handleEvent(ev)
+	z.handleEvent(ev)
+	assert.Equal(map[string]uint64{bgp.RF_FS_IPv4_UC.String(): 2}, z.Stats().UnsupportedFamilyPaths)
+	warnings := func() []*log.Entry {
+		var l []*log.Entry
+		for _, e := range hook.AllEntries() {
+			if e.Level == log.WarnLevel {
+				l = append(l, e)
+			}
+		}
+		return l
+	}
+	entries := warnings()
+	if assert.Len(entries, 1) {
+		assert.Equal("not installing path of unsupported address family or route type", entries[0].Message)
+		assert.Equal(bgp.RF_FS_IPv4_UC, entries[0].Data["Family"])
+		assert.Equal(nlri.String(), entries[0].Data["Key"])
+	}
+
+	// so are the EVPN routes without IP prefix.
+	mac := bgp.NewEVPNNLRI(bgp.EVPN_ROUTE_TYPE_MAC_IP_ADVERTISEMENT, &bgp.EVPNMacIPAdvertisementRoute{
+		RD:               bgp.NewRouteDistinguisherTwoOctetAS(65000, 1),
+		ESI:              bgp.EthernetSegmentIdentifier{Type: bgp.ESI_ARBITRARY},
+		MacAddressLength: 48,
+		MacAddress:       net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+		Labels:           []uint32{10},
+	})
+	evpn := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 65000}, mac, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{mac}),
+	}, time.Now(), false)
+	z.handleEvent(&WatchEventBestPath{PathList: []*table.Path{evpn}})
+	assert.Equal(map[string]uint64{bgp.RF_FS_IPv4_UC.String(): 2, bgp.RF_EVPN.String(): 1}, z.Stats().UnsupportedFamilyPaths)
+	assert.Len(warnings(), 2)
+}
+
+// fakeZebraCall is a call to the methods of fakeZebraConn sending messages.
+type fakeZebraCall struct {
+	method     string
+	vrfId      uint32
+	body       zebra.Body
+	isWithdraw bool
+	routeType  zebra.ROUTE_TYPE
+}
+
+// fakeZebraConn is a zebraConn recording the calls made to it into calls,
+// which lets loop() be run without Zebra. The messages written to incoming
+// are received by the client.
+type fakeZebraConn struct {
+	version   uint8
+	incoming  chan *zebra.Message
+	calls     chan fakeZebraCall
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeZebraConn(version uint8) *fakeZebraConn {
+	return &fakeZebraConn{
+		version:  version,
+		incoming: make(chan *zebra.Message, 8),
+		calls:    make(chan fakeZebraCall, 1024),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (f *fakeZebraConn) record(c fakeZebraCall) error {
+	select {
+	case <-f.closed:
+		return fmt.Errorf("failed to send %s: client closed", c.method)
+	default:
+	}
+	f.calls <- c
+	return nil
+}
+
+func (f *fakeZebraConn) Receive() chan *zebra.Message {
+	return f.incoming
+}
+
+func (f *fakeZebraConn) MessageVersion() uint8 {
+	return f.version
+}
+
+func (f *fakeZebraConn) SendIPRoute(vrfId uint32, body *zebra.IPRouteBody, isWithdraw bool) error {
+	return f.record(fakeZebraCall{method: "SendIPRoute", vrfId: vrfId, body: body, isWithdraw: isWithdraw})
+}
+
+func (f *fakeZebraConn) SendNexthopRegister(vrfId uint32, body *zebra.NexthopRegisterBody, isWithdraw bool) error {
+	return f.record(fakeZebraCall{method: "SendNexthopRegister", vrfId: vrfId, body: body, isWithdraw: isWithdraw})
+}
+
+func (f *fakeZebraConn) SendCommand(command zebra.API_TYPE, vrfId uint32, body zebra.Body) error {
+	return f.record(fakeZebraCall{method: command.String(), vrfId: vrfId, body: body})
+}
+
+func (f *fakeZebraConn) SendRedistribute(t zebra.ROUTE_TYPE, vrfId uint32) error {
+	return f.record(fakeZebraCall{method: "SendRedistribute", vrfId: vrfId, routeType: t})
+}
+
+func (f *fakeZebraConn) SendRedistributeDelete(t zebra.ROUTE_TYPE, vrfId uint32) error {
+	return f.record(fakeZebraCall{method: "SendRedistributeDelete", vrfId: vrfId, routeType: t})
+}
+
+func (f *fakeZebraConn) SendInterfaceAdd() error {
+	return f.record(fakeZebraCall{method: "SendInterfaceAdd"})
+}
+
+func (f *fakeZebraConn) Close() error {
+	f.closeOnce.Do(func() { close(f.closed) })
+	return nil
+}
+
+func (f *fakeZebraConn) Abort() error {
+	return f.Close()
+}
+
+// waitFakeZebraCall returns the next call to the given method, skipping
+// the calls to the other ones.
+func waitFakeZebraCall(t *testing.T, f *fakeZebraConn, method string) fakeZebraCall {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case c := <-f.calls:
+			if c.method == method {
+				return c
+			}
+		case <-timeout:
+			t.Fatalf("%s was not called", method)
+		}
+	}
+}
+
+// startFakeZebraClient runs loop() of a client connected to conn. The
+// returned channel is closed once loop() has returned.
+func startFakeZebraClient(s *BgpServer, conn *fakeZebraConn, c config.ZebraConfig) (*zebraClient, <-chan struct{}) {
+	z := &zebraClient{
+		server:           s,
+		client:           conn,
+		config:           c,
+		dead:             make(chan struct{}),
+		tasks:            make(chan func()),
+		ready:            make(chan struct{}),
+		installed:        make(map[string][]uint32),
+		installedBody:    make(map[string]*zebra.IPRouteBody),
+		rdRoutes:         make(map[string]rdRoute),
+		imported:         make(map[string]importedRoute),
+		interfaces:       make(map[uint32]*zebraInterface),
+		failedNexthops:   make(map[string]failedNexthop),
+		nexthopLabels:    make(map[string][]uint32),
+		vrfRegistrations: make(map[uint32]vrfRegistration),
+	}
+	if c.NexthopTriggerEnable {
+		z.nhtManager = newNexthopTrackingManager(s, int(c.NexthopTriggerDelay), 0, 0)
+	}
+	exited := make(chan struct{})
+	go func() {
+		z.loop()
+		close(exited)
+	}()
+	<-z.ready
+	return z, exited
+}
+
+func Test_zebraClientLoopFake(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	conn := newFakeZebraConn(3)
+	z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 3})
+
+	// the best path of a peer is installed, then withdrawn.
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	_, err = s.AddPath("", []*table.Path{path})
+	assert.Nil(err)
+	c := waitFakeZebraCall(t, conn, "SendIPRoute")
+	assert.False(c.isWithdraw)
+	if b, ok := c.body.(*zebra.IPRouteBody); assert.True(ok) {
+		assert.Equal("10.0.0.0", b.Prefix.String())
+		assert.Equal("192.168.0.1", b.Nexthops[0].String())
+	}
+	assert.Nil(s.DeletePath(nil, bgp.RF_IPv4_UC, "", []*table.Path{path.Clone(true)}))
+	c = waitFakeZebraCall(t, conn, "SendIPRoute")
+	assert.True(c.isWithdraw)
+
+	// the routes redistributed by Zebra are added to the RIB.
+	conn.incoming <- &zebra.Message{
+		Header: zebra.Header{
+			Marker:  zebra.HEADER_MARKER,
+			Version: 3,
+			Command: zebra.IPV4_ROUTE_ADD,
+		},
+		Body: &zebra.IPRouteBody{
+			Type:         zebra.ROUTE_STATIC,
+			Message:      zebra.MESSAGE_NEXTHOP,
+			SAFI:         zebra.SAFI_UNICAST,
+			Prefix:       net.ParseIP("10.1.0.0").To4(),
+			PrefixLength: 16,
+			Nexthops:     []net.IP{net.ParseIP("192.168.0.2").To4()},
+			Api:          zebra.IPV4_ROUTE_ADD,
+		},
+	}
+	// and nexthop updates are counted.
+	conn.incoming <- &zebra.Message{
+		Header: zebra.Header{
+			Marker:  zebra.HEADER_MARKER,
+			Version: 3,
+			Command: zebra.NEXTHOP_UPDATE,
+		},
+		Body: &zebra.NexthopUpdateBody{
+			Family: syscall.AF_INET,
+			Prefix: net.ParseIP("192.168.0.1").To4(),
+		},
+	}
+	found := false
+	for i := 0; i < 100 && !found; i++ {
+		rib, _, err := s.GetRib("", bgp.RF_IPv4_UC, []*table.LookupPrefix{{Prefix: "10.1.0.0/16"}})
+		assert.Nil(err)
+		found = len(rib.GetDestinations()) > 0 && z.Stats().NexthopUpdatesReceived == 1
+		if !found {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	assert.True(found)
+
+	z.stop()
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("loop() did not return")
+	}
+	select {
+	case <-conn.closed:
+	default:
+		t.Fatal("connection not closed")
+	}
+}
+
+func Test_zebraClientVpnRouteFromBestPath(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	rd, _ := bgp.ParseRouteDistinguisher("100:1")
+	rt := bgp.NewTwoOctetAsSpecificExtended(bgp.EC_SUBTYPE_ROUTE_TARGET, 100, 1, true)
+	err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+	assert.Nil(err)
+
+	w := s.Watch(WatchZebraSync())
+	defer w.Stop()
+	conn := newFakeZebraConn(3)
+	z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 3})
+	defer func() {
+		z.stop()
+		<-exited
+	}()
+	// the VRF is dumped before the path is added, not to install it twice.
+	select {
+	case <-w.Event():
+	case <-time.After(time.Second):
+		t.Fatal("zebra sync event was not notified")
+	}
+
+	nlri := bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rd)
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeMpReachNLRI("192.168.0.1", []bgp.AddrPrefixInterface{nlri}),
+		bgp.NewPathAttributeExtendedCommunities([]bgp.ExtendedCommunityInterface{rt}),
+	}, time.Now(), false)
+
+	// post-policy updates leave the non default routes alone.
+	done := make(chan struct{})
+	z.tasks <- func() {
+		z.handleUpdate(&WatchEventUpdate{PathList: []*table.Path{path}})
+		assert.Len(z.installed, 0)
+		close(done)
+	}
+	<-done
+
+	// they are installed once into the VRF importing them from best path
+	// events.
+	_, err = s.AddPath("", []*table.Path{path})
+	assert.Nil(err)
+	c := waitFakeZebraCall(t, conn, "SendIPRoute")
+	assert.False(c.isWithdraw)
+	assert.Equal(uint32(1), c.vrfId)
+	if b, ok := c.body.(*zebra.IPRouteBody); assert.True(ok) {
+		assert.Equal("10.0.0.0", b.Prefix.String())
+		assert.Equal(uint8(24), b.PrefixLength)
+	}
+	select {
+	case c := <-conn.calls:
+		t.Fatalf("unexpected call: %v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func Test_zebraClientAdditionalUrl(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	sock, msgs, cleanup := listenTestZebra(t)
+	defer cleanup()
+	// the additional zebra accepts the client again when it reconnects.
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	otherSock := filepath.Join(dir, "zserv.api")
+	l, err := net.Listen("unix", otherSock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	otherMsgs := make(chan *zebra.Message, 64)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestZebra(conn, 2, otherMsgs)
+		}
+	}()
+
+	otherUrl := "unix:" + otherSock
+	err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + sock,
+		AdditionalUrlList: []string{otherUrl},
+		Version:           2,
+		ReconnectInterval: 1,
+	})
+	assert.Nil(err)
+	waitZebraMessage(t, msgs, zebra.HELLO)
+	waitZebraMessage(t, otherMsgs, zebra.HELLO)
+
+	clients := func() (*zebraClient, *zebraClient) {
+		var z, other *zebraClient
+		s.mgmtOperation(func() error {
+			z = s.zclient
+			other = s.zebraEndpoints[otherUrl]
+			return nil
+		}, false)
+		return z, other
+	}
+	z, other := clients()
+	if !assert.NotNil(z) || !assert.NotNil(other) {
+		return
+	}
+	assert.Equal("unix:"+sock, z.config.Url)
+	assert.Equal(otherUrl, other.config.Url)
+	<-z.ready
+	<-other.ready
+
+	addPath := func(prefix string) {
+		nlri := bgp.NewIPAddrPrefix(24, prefix)
+		_, err := s.AddPath("", []*table.Path{table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, nlri, false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)})
+		assert.Nil(err)
+	}
+	// programmed into both, each tracking its own routes.
+	addPath("10.0.0.0")
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	waitZebraMessage(t, otherMsgs, zebra.IPV4_ROUTE_ADD)
+	for _, c := range []*zebraClient{z, other} {
+		done := make(chan struct{})
+		c.tasks <- func() {
+			assert.Equal(map[string][]uint32{"0:ipv4:10.0.0.0/24:0": {0}}, c.installed)
+			close(done)
+		}
+		<-done
+	}
+
+	// the additional zebra is lost and reconnected to alone.
+	other.client.Close()
+	waitZebraMessage(t, otherMsgs, zebra.HELLO)
+	timeout := time.After(5 * time.Second)
+	for {
+		nz, nother := clients()
+		assert.Equal(z, nz)
+		if nother != nil && nother != other {
+			other = nother
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatal("additional zebra client did not reconnect")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	assert.Equal(otherUrl, other.config.Url)
+	assert.Equal(uint32(0), atomic.LoadUint32(&z.reconnects))
+	<-other.ready
+
+	addPath("10.0.1.0")
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	waitZebraMessage(t, otherMsgs, zebra.IPV4_ROUTE_ADD)
+	for _, c := range []*zebraClient{z, other} {
+		done := make(chan struct{})
+		c.tasks <- func() {
+			assert.Contains(c.installed, "0:ipv4:10.0.1.0/24:0")
+			close(done)
+		}
+		<-done
+	}
+
+	z.stop()
+	stopTestZebraClient(t, s, other)
+}
+
+func Test_zebraClientDropEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	sock, msgs, cleanup := listenTestZebra(t)
+	defer cleanup()
+	sockA, msgsA, cleanupA := listenTestZebra(t)
+	defer cleanupA()
+	sockB, msgsB, cleanupB := listenTestZebra(t)
+	defer cleanupB()
+
+	urlA, urlB := "unix:"+sockA, "unix:"+sockB
+	err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+		Enabled:           true,
+		Url:               "unix:" + sock,
+		AdditionalUrlList: []string{urlA, urlB},
+		Version:           2,
+		ReconnectInterval: 1,
+	})
+	assert.Nil(err)
+	waitZebraMessage(t, msgs, zebra.HELLO)
+	waitZebraMessage(t, msgsA, zebra.HELLO)
+	waitZebraMessage(t, msgsB, zebra.HELLO)
+
+	clients := func() (*zebraClient, *zebraClient, *zebraClient) {
+		var z, a, b *zebraClient
+		s.mgmtOperation(func() error {
+			z = s.zclient
+			a = s.zebraEndpoints[urlA]
+			b = s.zebraEndpoints[urlB]
+			return nil
+		}, false)
+		return z, a, b
+	}
+	z, a, b := clients()
+	if !assert.NotNil(z) || !assert.NotNil(a) || !assert.NotNil(b) {
+		return
+	}
+	<-a.ready
+
+	// the server goroutine keeps walking the clients while one of them
+	// drops its endpoint, which cannot be reconnected to.
+	stop := make(chan struct{})
+	walked := make(chan struct{})
+	go func() {
+		defer close(walked)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			s.mgmtOperation(func() error {
+				s.zebraClients()
+				return nil
+			}, false)
+		}
+	}()
+	cleanupA()
+	a.client.Close()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		nz, na, nb := clients()
+		assert.Equal(z, nz)
+		assert.Equal(b, nb)
+		if na == nil {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatal("dropped zebra endpoint was not removed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	close(stop)
+	<-walked
+
+	a.stop()
+	z.stop()
+	stopTestZebraClient(t, s, b)
+}
+
+func Test_NlriRDPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	rds := []bgp.RouteDistinguisherInterface{
+		bgp.NewRouteDistinguisherTwoOctetAS(100, 1),
+		bgp.NewRouteDistinguisherIPAddressAS("10.0.0.1", 1),
+		bgp.NewRouteDistinguisherFourOctetAS(65536, 1),
+	}
+	for _, tt := range []struct {
+		rd     string
+		nlri   bgp.AddrPrefixInterface
+		prefix string
+	}{
+		{"100:1", bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rds[0]), "10.0.0.0/24"},
+		{"10.0.0.1:1", bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rds[1]), "10.0.0.0/24"},
+		{"1.0:1", bgp.NewLabeledVPNIPAddrPrefix(24, "10.0.0.0", *bgp.NewMPLSLabelStack(100), rds[2]), "10.0.0.0/24"},
+		{"100:1", bgp.NewLabeledVPNIPv6AddrPrefix(64, "2001:db8::", *bgp.NewMPLSLabelStack(100), rds[0]), "2001:db8::/64"},
+		{"10.0.0.1:1", bgp.NewLabeledVPNIPv6AddrPrefix(64, "2001:db8::", *bgp.NewMPLSLabelStack(100), rds[1]), "2001:db8::/64"},
+		{"1.0:1", bgp.NewLabeledVPNIPv6AddrPrefix(64, "2001:db8::", *bgp.NewMPLSLabelStack(100), rds[2]), "2001:db8::/64"},
+		{"100:1", bgp.NewLabeledVPNIPv6AddrPrefix(0, "::", *bgp.NewMPLSLabelStack(100), rds[0]), "::/0"},
+		{"", bgp.NewIPAddrPrefix(24, "10.0.0.0"), "10.0.0.0/24"},
+		{"", bgp.NewIPv6AddrPrefix(64, "2001:db8::"), "2001:db8::/64"},
+	} {
+		assert.Equal(tt.rd, NlriRD(tt.nlri), tt.nlri.String())
+		assert.Equal(tt.prefix, NlriPrefix(tt.nlri), tt.nlri.String())
+	}
+}
+
+func Test_zebraClientMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	// disabled by default.
+	assert.NotNil(s.WriteZebraMetrics(ioutil.Discard))
+
+	sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+	defer cleanup()
+	err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+		Enabled:              true,
+		Url:                  "unix:" + sock,
+		Version:              3,
+		NexthopTriggerEnable: true,
+		NexthopTriggerDelay:  5,
+		MetricsEnabled:       true,
+	})
+	assert.Nil(err)
+	waitZebraMessage(t, msgs, zebra.HELLO)
+	var z *zebraClient
+	s.mgmtOperation(func() error {
+		z = s.zclient
+		return nil
+	}, false)
+	<-z.ready
+	defer stopTestZebraClient(t, s, z)
+
+	metrics := func() string {
+		var b bytes.Buffer
+		assert.Nil(s.WriteZebraMetrics(&b))
+		return b.String()
+	}
+	waitMetric := func(line string) {
+		timeout := time.After(time.Second)
+		for !strings.Contains(metrics(), line+"\n") {
+			select {
+			case <-timeout:
+				t.Fatalf("%s not found in:\n%s", line, metrics())
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	waitMetric("gobgp_zebra_connected 1")
+	waitMetric(`gobgp_zebra_messages_total{direction="sent",type="INTERFACE_ADD"} 1`)
+	waitMetric(`gobgp_zebra_messages_total{direction="received",type="HELLO"} 1`)
+	waitMetric("gobgp_zebra_registered_nexthops 0")
+
+	nlri := bgp.NewIPAddrPrefix(24, "10.0.0.0")
+	path := table.NewPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, nlri, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+	}, time.Now(), false)
+	_, err = s.AddPath("", []*table.Path{path})
+	assert.Nil(err)
+	waitZebraMessage(t, msgs, zebra.IPV4_ROUTE_ADD)
+	waitMetric(`gobgp_zebra_messages_total{direction="sent",type="IPV4_ROUTE_ADD"} 1`)
+	waitMetric(`gobgp_zebra_messages_total{direction="sent",type="NEXTHOP_REGISTER"} 1`)
+	waitMetric("gobgp_zebra_registered_nexthops 1")
+
+	// the nexthop tracking updates are scheduled and charged.
+	z.nhtManager.scheduleUpdate(pathList{path})
+	waitMetric("gobgp_zebra_scheduled_paths 1")
+	waitMetric("gobgp_zebra_nexthop_penalty 500")
+}
+
+func Test_zebraClientNilSource(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	newPath := func(source *table.PeerInfo, prefix string) *table.Path {
+		return table.NewPath(source, bgp.NewIPAddrPrefix(24, prefix), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop("192.168.0.1"),
+		}, time.Now(), false)
+	}
+
+	// the flags do not depend on a source to be computed.
+	c := &config.ZebraConfig{Version: 3, IbgpDistance: 200}
+	for i := 0; i < 2; i++ {
+		body, isWithdraw := newIPRouteBody(pathList{newPath(nil, "10.0.0.0")}, false, c)
+		if assert.NotNil(body) {
+			assert.False(isWithdraw)
+			assert.Equal(zebra.FLAG(0), body.Flags)
+			assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_DISTANCE)
+		}
+	}
+
+	for _, tt := range []struct {
+		action     config.ZebraNilSourceAction
+		sent       bool
+		isWithdraw bool
+	}{
+		{"", true, true},
+		{config.ZEBRA_NIL_SOURCE_ACTION_LOCAL, true, true},
+		{config.ZEBRA_NIL_SOURCE_ACTION_INSTALL, true, false},
+		{config.ZEBRA_NIL_SOURCE_ACTION_IGNORE, false, false},
+	} {
+		conn := newFakeZebraConn(3)
+		z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 3, NilSourceAction: tt.action})
+
+		// a path of a peer follows, so that the path without source
+		// is known to have been handled once its route is sent.
+		z.SendPaths([]*table.Path{newPath(nil, "10.0.0.0")}, nil)
+		z.SendPaths([]*table.Path{newPath(&table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}, "10.1.0.0")}, nil)
+		if tt.sent {
+			call := waitFakeZebraCall(t, conn, "SendIPRoute")
+			if b, ok := call.body.(*zebra.IPRouteBody); assert.True(ok, tt.action) {
+				assert.Equal("10.0.0.0", b.Prefix.String(), tt.action)
+				assert.Equal(tt.isWithdraw, call.isWithdraw, tt.action)
+			}
+		}
+		call := waitFakeZebraCall(t, conn, "SendIPRoute")
+		if b, ok := call.body.(*zebra.IPRouteBody); assert.True(ok, tt.action) {
+			assert.Equal("10.1.0.0", b.Prefix.String(), tt.action)
+		}
+
+		z.stop()
+		<-exited
+	}
+}
+
+func Test_newIPRouteBodyIfindex(t *testing.T) {
+	assert := assert.New(t)
+
+	var lo *net.Interface
+	l, err := net.Interfaces()
+	assert.Nil(err)
+	for i := range l {
+		if l[i].Flags&net.FlagLoopback != 0 {
+			lo = &l[i]
+		}
+	}
+	if lo == nil {
+		t.Skip("no loopback interface")
+	}
+
+	// an unnumbered session over the loopback interface.
+	peer := &table.PeerInfo{
+		AS:      65001,
+		LocalAS: 65000,
+		Address: net.ParseIP("fe80::1"),
+		Zone:    lo.Name,
+	}
+	newPath := func(source *table.PeerInfo, nexthop string) *table.Path {
+		return table.NewPath(source, bgp.NewIPv6AddrPrefix(64, "2001:db8:1::"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")}),
+		}, time.Now(), false)
+	}
+
+	body, _ := newIPRouteBody(pathList{newPath(peer, "fe80::1")}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_IFINDEX, body.Message&zebra.MESSAGE_IFINDEX)
+		assert.Equal([]uint32{uint32(lo.Index)}, body.Ifindexs)
+		assert.Equal("fe80::1", body.Nexthops[0].String())
+		// the nexthop and its interface are sent as two nexthops which
+		// zebra pairs.
+		b, err := body.Serialize(3)
+		assert.Nil(err)
+		assert.Equal([]byte{2, byte(zebra.NEXTHOP_IPV6)}, b[5+1+8:5+1+8+2])
+	}
+
+	// a global nexthop is left without interface.
+	body, _ = newIPRouteBody(pathList{newPath(peer, "2001:db8::1")}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_IFINDEX)
+		assert.Len(body.Ifindexs, 0)
+	}
+
+	// as is a link-local nexthop of an unknown interface.
+	peer.Zone = "nonexistent0"
+	body, _ = newIPRouteBody(pathList{newPath(peer, "fe80::1")}, false, &config.ZebraConfig{})
+	if assert.NotNil(body) {
+		assert.Equal(zebra.MESSAGE_FLAG(0), body.Message&zebra.MESSAGE_IFINDEX)
+		assert.Len(body.Ifindexs, 0)
+	}
+}
+
+func Test_newIPRouteBodyNexthopSelf(t *testing.T) {
+	assert := assert.New(t)
+
+	peer := &table.PeerInfo{
+		AS:           65001,
+		LocalAS:      65000,
+		LocalID:      net.ParseIP("1.1.1.1"),
+		Address:      net.ParseIP("10.0.0.1"),
+		LocalAddress: net.ParseIP("10.0.0.2"),
+	}
+	peer6 := &table.PeerInfo{
+		AS:           65001,
+		LocalAS:      65000,
+		LocalID:      net.ParseIP("1.1.1.1"),
+		Address:      net.ParseIP("2001:db8::1"),
+		LocalAddress: net.ParseIP("2001:db8::2"),
+	}
+	newPath := func(source *table.PeerInfo, nexthop string) *table.Path {
+		return table.NewPath(source, bgp.NewIPAddrPrefix(24, "10.10.0.0"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(nexthop),
+		}, time.Now(), false)
+	}
+	newPath6 := func(source *table.PeerInfo, nexthop string) *table.Path {
+		return table.NewPath(source, bgp.NewIPv6AddrPrefix(64, "2001:db8:1::"), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeMpReachNLRI(nexthop, []bgp.AddrPrefixInterface{bgp.NewIPv6AddrPrefix(64, "2001:db8:1::")}),
+		}, time.Now(), false)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		self     bool
+		paths    pathList
+		nexthops []string
+	}{
+		{"original", false, pathList{newPath(peer, "192.168.0.1")}, []string{"192.168.0.1"}},
+		{"original multipath", false, pathList{newPath(peer, "192.168.0.1"), newPath(peer, "192.168.0.2")}, []string{"192.168.0.1", "192.168.0.2"}},
+		{"original v6", false, pathList{newPath6(peer6, "2001:db8::10")}, []string{"2001:db8::10"}},
+		// the local address of the session is used.
+		{"self", true, pathList{newPath(peer, "192.168.0.1")}, []string{"10.0.0.2"}},
+		{"self multipath", true, pathList{newPath(peer, "192.168.0.1"), newPath(peer, "192.168.0.2")}, []string{"10.0.0.2"}},
+		{"self v6", true, pathList{newPath6(peer6, "2001:db8::10")}, []string{"2001:db8::2"}},
+		// or else the router id for IPv4 routes.
+		{"self router id", true, pathList{newPath(peer6, "192.168.0.1")}, []string{"1.1.1.1"}},
+		// the routes without local address keep their nexthops.
+		{"self v6 without address", true, pathList{newPath6(peer, "2001:db8::10")}, []string{"2001:db8::10"}},
+		{"self without source", true, pathList{newPath(nil, "192.168.0.1")}, []string{"192.168.0.1"}},
+	} {
+		body, isWithdraw := newIPRouteBody(tt.paths, false, &config.ZebraConfig{NexthopSelf: tt.self})
+		if assert.NotNil(body, tt.name) {
+			assert.False(isWithdraw, tt.name)
+			nexthops := make([]string, 0, len(body.Nexthops))
+			for _, nh := range body.Nexthops {
+				nexthops = append(nexthops, nh.String())
+			}
+			assert.Equal(tt.nexthops, nexthops, tt.name)
+		}
+	}
+
+	// the routes withdrawn for local paths keep the nexthop they are
+	// withdrawn with.
+	local := newPath(&table.PeerInfo{LocalID: net.ParseIP("1.1.1.1")}, "0.0.0.0")
+	body, _ := newIPRouteBody(pathList{local}, true, &config.ZebraConfig{NexthopSelf: true})
+	if assert.NotNil(body) && assert.Len(body.Nexthops, 1) {
+		assert.Equal("127.0.0.1", body.Nexthops[0].String())
+	}
+}
+
+func Test_zebraClientNexthops(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	_, err = s.GetZebraNexthops()
+	assert.NotNil(err)
+
+	conn := newFakeZebraConn(3)
+	z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 3, NexthopTriggerEnable: true, NexthopTriggerDelay: 5})
+	s.mgmtOperation(func() error {
+		s.zclient = z
+		return nil
+	}, true)
+	defer func() {
+		s.mgmtOperation(func() error {
+			s.zclient = nil
+			return nil
+		}, true)
+		z.stop()
+		<-exited
+	}()
+
+	l, err := s.GetZebraNexthops()
+	assert.Nil(err)
+	assert.Len(l, 0)
+
+	// the best paths of three prefixes share two nexthops.
+	peer := &table.PeerInfo{AS: 65001, LocalAS: 1, Address: net.ParseIP("10.0.0.1")}
+	for i, nexthop := range []string{"192.168.0.2", "192.168.0.1", "192.168.0.2"} {
+		_, err = s.AddPath("", []*table.Path{table.NewPath(peer, bgp.NewIPAddrPrefix(24, fmt.Sprintf("10.%d.0.0", i)), false, []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+			bgp.NewPathAttributeNextHop(nexthop),
+		}, time.Now(), false)})
+		assert.Nil(err)
+	}
+	waitFakeZebraCall(t, conn, "SendNexthopRegister")
+	waitFakeZebraCall(t, conn, "SendNexthopRegister")
+
+	// the registrations are cached once the best paths are processed.
+	var expected []ZebraNexthop
+	for _, nexthop := range []string{"192.168.0.1", "192.168.0.2"} {
+		expected = append(expected, ZebraNexthop{
+			Address:    net.ParseIP(nexthop).To4(),
+			Family:     "ipv4",
+			VrfIds:     []uint32{0},
+			Registered: true,
+		})
+	}
+	timeout := time.After(time.Second)
+	for {
+		l, err = s.GetZebraNexthops()
+		assert.Nil(err)
+		if len(l) == len(expected) {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("nexthops not registered: %v", l)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	assert.Equal(expected, l)
+}
+
+func Test_ipRouteKeyFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	// both prefixes are printed as 10.0.0.0.
+	v4 := &zebra.IPRouteBody{Prefix: net.ParseIP("10.0.0.0").To4(), PrefixLength: 24}
+	v6 := &zebra.IPRouteBody{Prefix: net.ParseIP("::ffff:10.0.0.0"), PrefixLength: 24}
+	assert.Equal(v4.Prefix.String(), v6.Prefix.String())
+	assert.NotEqual(ipRouteKey(0, v4), ipRouteKey(0, v6))
+
+	z := &zebraClient{
+		installed:     make(map[string][]uint32),
+		installedBody: make(map[string]*zebra.IPRouteBody),
+	}
+	z.trackIPRoute(0, v4, false)
+	z.trackIPRoute(0, v6, false)
+	assert.Len(z.installed, 2)
+	z.trackIPRoute(0, v4, true)
+	assert.Equal(map[string]*zebra.IPRouteBody{ipRouteKey(0, v6): v6}, z.installedBody)
+	z.trackIPRoute(0, v4, false)
+
+	// the family is kept across a restart.
+	dir, err := ioutil.TempDir("", "zebra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "installed.json")
+	z.config.InstallStateFile = file
+	z.saveInstallState()
+	routes, err := loadInstallState(file)
+	assert.Nil(err)
+	assert.Equal(map[string]savedRoute{
+		ipRouteKey(0, v4): {VrfIds: []uint32{0}, Body: v4},
+		ipRouteKey(0, v6): {VrfIds: []uint32{0}, Body: v6},
+	}, routes)
+
+	// the routes saved before the key included the family are keyed
+	// again.
+	assert.Nil(ioutil.WriteFile(file, []byte(`{"1:10.0.0.0/24:0":{"vrf-ids":[1],"body":{"Prefix":"10.0.0.0","PrefixLength":24}}}`), 0600))
+	routes, err = loadInstallState(file)
+	assert.Nil(err)
+	if assert.Contains(routes, "1:ipv4:10.0.0.0/24:0") {
+		assert.Equal(v4.Prefix, routes["1:ipv4:10.0.0.0/24:0"].Body.Prefix)
+	}
+}
+
+func Test_zebraClientRouterIdChanged(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	conn := newFakeZebraConn(4)
+	z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 4})
+	s.mgmtOperation(func() error {
+		s.zclient = z
+		return nil
+	}, true)
+	defer func() {
+		s.mgmtOperation(func() error {
+			s.zclient = nil
+			return nil
+		}, true)
+		z.stop()
+		<-exited
+	}()
+
+	assert.NotNil(s.UpdateRouterId("::1"))
+	assert.Nil(s.UpdateRouterId("2.2.2.2"))
+	c := waitFakeZebraCall(t, conn, zebra.FRR_ROUTER_ID_ADD.String())
+	assert.Equal(uint32(zebra.VRF_DEFAULT), c.vrfId)
+	s.mgmtOperation(func() error {
+		assert.Equal("2.2.2.2", s.bgpConfig.Global.Config.RouterId)
+		return nil
+	}, true)
+
+	// the same router-id is not sent again.
+	assert.Nil(s.UpdateRouterId("2.2.2.2"))
+	timeout := time.After(100 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case c := <-conn.calls:
+			assert.NotEqual(zebra.FRR_ROUTER_ID_ADD.String(), c.method)
+		case <-timeout:
+			done = true
+		}
+	}
+	assert.Nil(s.UpdateRouterId("3.3.3.3"))
+	waitFakeZebraCall(t, conn, zebra.FRR_ROUTER_ID_ADD.String())
+}
+
+func Test_zebraClientRedistribute(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	assert.NotNil(s.AddZebraRedistribute("connect"))
+
+	conn := newFakeZebraConn(3)
+	z, exited := startFakeZebraClient(s, conn, config.ZebraConfig{Version: 3})
+	s.mgmtOperation(func() error {
+		s.zclient = z
+		s.zebraConfig = &config.ZebraConfig{Version: 3}
+		return nil
+	}, true)
+	defer func() {
+		s.mgmtOperation(func() error {
+			s.zclient = nil
+			return nil
+		}, true)
+		z.stop()
+		<-exited
+	}()
+	redistributed := func() []config.InstallProtocolType {
+		var l []config.InstallProtocolType
+		s.mgmtOperation(func() error {
+			l = s.zebraConfig.RedistributeRouteTypeList
+			return nil
+		}, true)
+		return l
+	}
+
+	assert.NotNil(s.AddZebraRedistribute("unknown"))
+	assert.Nil(s.AddZebraRedistribute("connect"))
+	c := waitFakeZebraCall(t, conn, "SendRedistribute")
+	assert.Equal(zebra.ROUTE_CONNECT, c.routeType)
+	assert.Equal(uint32(zebra.VRF_DEFAULT), c.vrfId)
+	assert.Equal([]config.InstallProtocolType{"connect"}, redistributed())
+	// already redistributed.
+	assert.NotNil(s.AddZebraRedistribute("connect"))
+
+	assert.Nil(s.DeleteZebraRedistribute("connect"))
+	c = waitFakeZebraCall(t, conn, "SendRedistributeDelete")
+	assert.Equal(zebra.ROUTE_CONNECT, c.routeType)
+	assert.Equal(uint32(zebra.VRF_DEFAULT), c.vrfId)
+	assert.Len(redistributed(), 0)
+	// not redistributed anymore.
+	assert.NotNil(s.DeleteZebraRedistribute("connect"))
+
+	// only the route types not redistributed yet are added for "all".
+	assert.Nil(s.AddZebraRedistribute("static"))
+	waitFakeZebraCall(t, conn, "SendRedistribute")
+	assert.Nil(s.AddZebraRedistribute("all"))
+	types, _ := zebra.RouteTypesFromString("all", 3)
+	for i := 0; i < len(types)-1; i++ {
+		c := waitFakeZebraCall(t, conn, "SendRedistribute")
+		assert.NotEqual(zebra.ROUTE_STATIC, c.routeType)
+	}
+	assert.Equal([]config.InstallProtocolType{"static", "all"}, redistributed())
+}
+
+func Test_zebraClientRedistributeRollback(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.Start(&config.Global{
+		Config: config.GlobalConfig{
+			As:       1,
+			RouterId: "1.1.1.1",
+			Port:     -1,
+		},
+	})
+	assert.Nil(err)
+	defer s.Stop()
+
+	connA := newFakeZebraConn(3)
+	a, exitedA := startFakeZebraClient(s, connA, config.ZebraConfig{Version: 3})
+	connB := newFakeZebraConn(3)
+	b, exitedB := startFakeZebraClient(s, connB, config.ZebraConfig{Version: 3})
+	s.mgmtOperation(func() error {
+		s.zclient = a
+		s.zebraEndpoints = map[string]*zebraClient{"unix:/tmp/b": b}
+		s.zebraConfig = &config.ZebraConfig{Version: 3}
+		return nil
+	}, true)
+	defer func() {
+		s.mgmtOperation(func() error {
+			s.zclient = nil
+			s.zebraEndpoints = nil
+			return nil
+		}, true)
+		a.stop()
+		b.stop()
+		<-exitedA
+		<-exitedB
+	}()
+	redistributed := func() []config.InstallProtocolType {
+		var l []config.InstallProtocolType
+		s.mgmtOperation(func() error {
+			l = s.zebraConfig.RedistributeRouteTypeList
+			return nil
+		}, true)
+		return l
+	}
+	noCall := func(conn *fakeZebraConn) {
+		select {
+		case c := <-conn.calls:
+			t.Errorf("unexpected call %s", c.method)
+		default:
+		}
+	}
+
+	// rejected up front as b already redistributes the static routes.
+	b.redistributedMu.Lock()
+	b.redistributed = map[zebra.ROUTE_TYPE]bool{zebra.ROUTE_STATIC: true}
+	b.redistributedMu.Unlock()
+	assert.NotNil(s.AddZebraRedistribute("static"))
+	noCall(connA)
+	noCall(connB)
+	assert.Len(redistributed(), 0)
+
+	assert.Nil(s.AddZebraRedistribute("connect"))
+	waitFakeZebraCall(t, connA, "SendRedistribute")
+	waitFakeZebraCall(t, connB, "SendRedistribute")
+
+	// a is rolled back when sending to b fails.
+	connB.Close()
+	assert.NotNil(s.AddZebraRedistribute("kernel"))
+	c := waitFakeZebraCall(t, connA, "SendRedistribute")
+	assert.Equal(zebra.ROUTE_KERNEL, c.routeType)
+	c = waitFakeZebraCall(t, connA, "SendRedistributeDelete")
+	assert.Equal(zebra.ROUTE_KERNEL, c.routeType)
+	noCall(connA)
+	assert.Equal([]config.InstallProtocolType{"connect"}, redistributed())
+
+	assert.NotNil(s.DeleteZebraRedistribute("connect"))
+	c = waitFakeZebraCall(t, connA, "SendRedistributeDelete")
+	assert.Equal(zebra.ROUTE_CONNECT, c.routeType)
+	c = waitFakeZebraCall(t, connA, "SendRedistribute")
+	assert.Equal(zebra.ROUTE_CONNECT, c.routeType)
+	noCall(connA)
+	assert.Equal([]config.InstallProtocolType{"connect"}, redistributed())
+
+	for _, z := range []*zebraClient{a, b} {
+		z.redistributedMu.Lock()
+		assert.False(z.redistributed[zebra.ROUTE_KERNEL])
+		assert.True(z.redistributed[zebra.ROUTE_CONNECT])
+		z.redistributedMu.Unlock()
+	}
+}
+
+func Test_zebraClientRedistributeAllVrfs(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, all := range []bool{false, true} {
+		s := NewBgpServer()
+		go s.Serve()
+		err := s.Start(&config.Global{
+			Config: config.GlobalConfig{
+				As:       1,
+				RouterId: "1.1.1.1",
+				Port:     -1,
+			},
+		})
+		assert.Nil(err)
+
+		rd, _ := bgp.ParseRouteDistinguisher("100:100")
+		rt, _ := bgp.ParseRouteTarget("100:100")
+		err = s.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+		assert.Nil(err)
+
+		sock, msgs, cleanup := listenTestZebraVersion(t, 3)
+		err = s.StartZebraClient(context.Background(), &config.ZebraConfig{
+			Enabled:                   true,
+			Url:                       "unix:" + sock,
+			Version:                   3,
+			RedistributeRouteTypeList: []config.InstallProtocolType{"connect"},
+			RedistributeAllVrfs:       all,
+		})
+		assert.Nil(err)
+
+		// the subscriptions are sent once the client is connected.
+		vrfIds := []uint32{}
+		for {
+			m := waitZebraMessage(t, msgs, zebra.REDISTRIBUTE_ADD)
+			assert.Equal([]byte{byte(zebra.ROUTE_CONNECT)}, m.Body.(*zebra.UnknownBody).Data)
+			vrfIds = append(vrfIds, m.Header.VrfId)
+			if !all || len(vrfIds) == 2 {
+				break
+			}
+		}
+		if all {
+			assert.Equal([]uint32{0, 1}, vrfIds)
+		} else {
+			assert.Equal([]uint32{0}, vrfIds)
+		}
+
+		// a VRF added afterwards is subscribed to as well, and
+		// unsubscribed from once deleted.
+		err = s.AddVrf("vrf2", 2, rd, []bgp.ExtendedCommunityInterface{rt}, []bgp.ExtendedCommunityInterface{rt})
+		assert.Nil(err)
+		err = s.DeleteVrf("vrf2")
+		assert.Nil(err)
+		if all {
+			m := waitZebraMessage(t, msgs, zebra.REDISTRIBUTE_ADD)
+			assert.Equal(uint32(2), m.Header.VrfId)
+			m = waitZebraMessage(t, msgs, zebra.REDISTRIBUTE_DELETE)
+			assert.Equal(uint32(2), m.Header.VrfId)
+		} else {
+			// only the VRF is registered and unregistered.
+			timeout := time.After(time.Second)
+			for done := false; !done; {
+				select {
+				case m := <-msgs:
+					assert.NotEqual(zebra.REDISTRIBUTE_ADD, m.Header.Command)
+					assert.NotEqual(zebra.REDISTRIBUTE_DELETE, m.Header.Command)
+					done = m.Header.Command == zebra.VRF_UNREGISTER
+				case <-timeout:
+					t.Fatal("VRF_UNREGISTER was not received")
+				}
+			}
+		}
+
+		var z *zebraClient
+		s.mgmtOperation(func() error {
+			z = s.zclient
+			return nil
+		}, false)
+		stopTestZebraClient(t, s, z)
+		s.Stop()
+		cleanup()
+	}
+}
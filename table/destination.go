@@ -117,6 +117,9 @@ type PeerInfo struct {
 	RouteReflectorClusterID net.IP
 	MultihopTtl             uint8
 	Confederation           bool
+	// Zone is the zone of Address, i.e. the interface of a link-local
+	// neighbor.
+	Zone string
 }
 
 func (lhs *PeerInfo) Equal(rhs *PeerInfo) bool {
@@ -159,6 +162,7 @@ func NewPeerInfo(g *config.Global, p *config.Neighbor) *PeerInfo {
 		LocalID:                 net.ParseIP(g.Config.RouterId).To4(),
 		RouteReflectorClient:    p.RouteReflector.Config.RouteReflectorClient,
 		Address:                 naddr.IP,
+		Zone:                    naddr.Zone,
 		RouteReflectorClusterID: id,
 		MultihopTtl:             p.EbgpMultihop.Config.MultihopTtl,
 		Confederation:           p.IsConfederationMember(g),
@@ -110,6 +110,8 @@ type originInfo struct {
 	isFromExternal     bool
 	eor                bool
 	stale              bool
+	neighborAddress    net.IP
+	ecmpNexthops       []net.IP
 }
 
 type RpkiValidationReasonType string
@@ -158,7 +160,10 @@ type Path struct {
 	dels       []bgp.BGPAttrType
 	// For BGP Nexthop Tracking, this field shows if nexthop is invalidated by IGP.
 	IsNexthopInvalid bool
-	aslooped         bool
+	// For BGP Nexthop Tracking, this field shows if MED holds the IGP metric
+	// of the nexthop, which takes precedence over import policies.
+	IsNexthopMetric bool
+	aslooped        bool
 	// doesn't exist in the adj
 	dropped bool
 }
@@ -349,6 +354,7 @@ func (path *Path) Clone(isWithdraw bool) *Path {
 		parent:           path,
 		IsWithdraw:       isWithdraw,
 		IsNexthopInvalid: path.IsNexthopInvalid,
+		IsNexthopMetric:  path.IsNexthopMetric,
 		attrsHash:        path.attrsHash,
 	}
 }
@@ -404,6 +410,32 @@ func (path *Path) GetSource() *PeerInfo {
 	return path.OriginInfo().source
 }
 
+// SetNeighborAddress sets the address which neighbor conditions match
+// against when the path has no source address, e.g. because it was
+// redistributed from zebra. Unlike the source address, it does not make
+// the path non-local.
+func (path *Path) SetNeighborAddress(addr net.IP) {
+	path.OriginInfo().neighborAddress = addr
+}
+
+func (path *Path) GetNeighborAddress() net.IP {
+	if addr := path.GetSource().Address; addr != nil {
+		return addr
+	}
+	return path.OriginInfo().neighborAddress
+}
+
+// SetEcmpNexthops sets the nexthops the path is reachable through besides
+// its nexthop, e.g. because it was redistributed from zebra as an ECMP
+// route.
+func (path *Path) SetEcmpNexthops(nexthops []net.IP) {
+	path.OriginInfo().ecmpNexthops = nexthops
+}
+
+func (path *Path) GetEcmpNexthops() []net.IP {
+	return path.OriginInfo().ecmpNexthops
+}
+
 func (path *Path) MarkStale(s bool) {
 	path.OriginInfo().stale = s
 }
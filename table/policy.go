@@ -1541,7 +1541,7 @@ func (c *NeighborCondition) Evaluate(path *Path, options *PolicyOptions) bool {
 		return true
 	}
 
-	neighbor := path.GetSource().Address
+	neighbor := path.GetNeighborAddress()
 	if options != nil && options.Info != nil && options.Info.Address != nil {
 		neighbor = options.Info.Address
 	}
@@ -16,12 +16,16 @@
 package zebra
 
 import (
+	"bufio"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -103,15 +107,38 @@ const (
 
 const VRF_DEFAULT = 0
 
+// MaxVersion is the latest message version supported. The Zebra of FRR 4
+// and 5 speaks version 5 and the one of FRR 6 and later version 6, which
+// renumber the commands (FRR_ZAPI5_*) and send the routes of both address
+// families with FRR_ZAPI5_ROUTE_ADD/DELETE. Version 6 adds the VRF id of
+// each nexthop of the routes.
+const MaxVersion = 6
+
 func HeaderSize(version uint8) uint16 {
 	switch version {
 	case 3, 4:
 		return 8
+	case 5, 6:
+		return 10
 	default:
 		return 6
 	}
 }
 
+// MaxMetric is the largest route metric. Every supported message version
+// encodes the metric in 4 bytes, so it does not depend on the version.
+const MaxMetric uint32 = math.MaxUint32
+
+// MaxVrfId returns the largest VRF id the header of the given message
+// version can carry. Versions before 5 encode it in 2 bytes, while the ids
+// are 4 bytes wide in Zebra itself.
+func MaxVrfId(version uint8) uint32 {
+	if version >= 5 {
+		return math.MaxUint32
+	}
+	return math.MaxUint16
+}
+
 func (t INTERFACE_STATUS) String() string {
 	ss := make([]string, 0, 3)
 	if t&INTERFACE_ACTIVE > 0 {
@@ -280,6 +307,59 @@ const (
 	FRR_PW_STATUS_UPDATE
 )
 
+// For FRRouting version 4 and later, which speaks message versions 5 and 6.
+const (
+	FRR_ZAPI5_INTERFACE_ADD API_TYPE = iota
+	FRR_ZAPI5_INTERFACE_DELETE
+	FRR_ZAPI5_INTERFACE_ADDRESS_ADD
+	FRR_ZAPI5_INTERFACE_ADDRESS_DELETE
+	FRR_ZAPI5_INTERFACE_UP
+	FRR_ZAPI5_INTERFACE_DOWN
+	FRR_ZAPI5_INTERFACE_SET_MASTER
+	FRR_ZAPI5_ROUTE_ADD
+	FRR_ZAPI5_ROUTE_DELETE
+	FRR_ZAPI5_ROUTE_NOTIFY_OWNER
+	FRR_ZAPI5_IPV4_ROUTE_ADD
+	FRR_ZAPI5_IPV4_ROUTE_DELETE
+	FRR_ZAPI5_IPV6_ROUTE_ADD
+	FRR_ZAPI5_IPV6_ROUTE_DELETE
+	FRR_ZAPI5_REDISTRIBUTE_ADD
+	FRR_ZAPI5_REDISTRIBUTE_DELETE
+	FRR_ZAPI5_REDISTRIBUTE_DEFAULT_ADD
+	FRR_ZAPI5_REDISTRIBUTE_DEFAULT_DELETE
+	FRR_ZAPI5_ROUTER_ID_ADD
+	FRR_ZAPI5_ROUTER_ID_DELETE
+	FRR_ZAPI5_ROUTER_ID_UPDATE
+	FRR_ZAPI5_HELLO
+	FRR_ZAPI5_CAPABILITIES
+	FRR_ZAPI5_NEXTHOP_REGISTER
+	FRR_ZAPI5_NEXTHOP_UNREGISTER
+	FRR_ZAPI5_NEXTHOP_UPDATE
+	FRR_ZAPI5_INTERFACE_NBR_ADDRESS_ADD
+	FRR_ZAPI5_INTERFACE_NBR_ADDRESS_DELETE
+	FRR_ZAPI5_INTERFACE_BFD_DEST_UPDATE
+	FRR_ZAPI5_IMPORT_ROUTE_REGISTER
+	FRR_ZAPI5_IMPORT_ROUTE_UNREGISTER
+	FRR_ZAPI5_IMPORT_CHECK_UPDATE
+	FRR_ZAPI5_IPV4_ROUTE_IPV6_NEXTHOP_ADD
+	FRR_ZAPI5_BFD_DEST_REGISTER
+	FRR_ZAPI5_BFD_DEST_DEREGISTER
+	FRR_ZAPI5_BFD_DEST_UPDATE
+	FRR_ZAPI5_BFD_DEST_REPLAY
+	FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD
+	FRR_ZAPI5_REDISTRIBUTE_ROUTE_DEL
+	FRR_ZAPI5_VRF_UNREGISTER
+	FRR_ZAPI5_VRF_ADD
+	FRR_ZAPI5_VRF_DELETE
+	FRR_ZAPI5_VRF_LABEL
+	FRR_ZAPI5_INTERFACE_VRF_UPDATE
+	FRR_ZAPI5_BFD_CLIENT_REGISTER
+	FRR_ZAPI5_INTERFACE_ENABLE_RADV
+	FRR_ZAPI5_INTERFACE_DISABLE_RADV
+	FRR_ZAPI5_IPV4_NEXTHOP_LOOKUP_MRIB
+	FRR_ZAPI5_INTERFACE_LINK_PARAMS
+)
+
 // Route Types.
 //go:generate stringer -type=ROUTE_TYPE
 type ROUTE_TYPE uint8
@@ -329,6 +409,36 @@ const (
 	FRR_ROUTE_MAX
 )
 
+// For FRRouting version 4 and later, which speaks message versions 5 and 6.
+const (
+	FRR_ZAPI5_ROUTE_SYSTEM ROUTE_TYPE = iota
+	FRR_ZAPI5_ROUTE_KERNEL
+	FRR_ZAPI5_ROUTE_CONNECT
+	FRR_ZAPI5_ROUTE_STATIC
+	FRR_ZAPI5_ROUTE_RIP
+	FRR_ZAPI5_ROUTE_RIPNG
+	FRR_ZAPI5_ROUTE_OSPF
+	FRR_ZAPI5_ROUTE_OSPF6
+	FRR_ZAPI5_ROUTE_ISIS
+	FRR_ZAPI5_ROUTE_BGP
+	FRR_ZAPI5_ROUTE_PIM
+	FRR_ZAPI5_ROUTE_EIGRP
+	FRR_ZAPI5_ROUTE_NHRP
+	FRR_ZAPI5_ROUTE_HSLS
+	FRR_ZAPI5_ROUTE_OLSR
+	FRR_ZAPI5_ROUTE_TABLE
+	FRR_ZAPI5_ROUTE_LDP
+	FRR_ZAPI5_ROUTE_VNC
+	FRR_ZAPI5_ROUTE_VNC_DIRECT
+	FRR_ZAPI5_ROUTE_VNC_DIRECT_RH
+	FRR_ZAPI5_ROUTE_BGP_DIRECT
+	FRR_ZAPI5_ROUTE_BGP_DIRECT_EXT
+	FRR_ZAPI5_ROUTE_BABEL
+	FRR_ZAPI5_ROUTE_SHARP
+	FRR_ZAPI5_ROUTE_ALL
+	FRR_ZAPI5_ROUTE_MAX
+)
+
 var routeTypeValueMap = map[string]ROUTE_TYPE{
 	"system":             ROUTE_SYSTEM,
 	"kernel":             ROUTE_KERNEL,
@@ -355,6 +465,37 @@ var routeTypeValueMap = map[string]ROUTE_TYPE{
 	"all":                FRR_ROUTE_ALL,
 }
 
+// frrZapi5RouteTypeValueMap is routeTypeValueMap for message versions 5
+// and 6, in which the route types are renumbered.
+var frrZapi5RouteTypeValueMap = map[string]ROUTE_TYPE{
+	"system":             FRR_ZAPI5_ROUTE_SYSTEM,
+	"kernel":             FRR_ZAPI5_ROUTE_KERNEL,
+	"connect":            FRR_ZAPI5_ROUTE_CONNECT,
+	"directly-connected": FRR_ZAPI5_ROUTE_CONNECT,
+	"static":             FRR_ZAPI5_ROUTE_STATIC,
+	"rip":                FRR_ZAPI5_ROUTE_RIP,
+	"ripng":              FRR_ZAPI5_ROUTE_RIPNG,
+	"ospf":               FRR_ZAPI5_ROUTE_OSPF,
+	"ospf3":              FRR_ZAPI5_ROUTE_OSPF6,
+	"isis":               FRR_ZAPI5_ROUTE_ISIS,
+	"bgp":                FRR_ZAPI5_ROUTE_BGP,
+	"pim":                FRR_ZAPI5_ROUTE_PIM,
+	"eigrp":              FRR_ZAPI5_ROUTE_EIGRP,
+	"nhrp":               FRR_ZAPI5_ROUTE_NHRP,
+	"hsls":               FRR_ZAPI5_ROUTE_HSLS,
+	"olsr":               FRR_ZAPI5_ROUTE_OLSR,
+	"table":              FRR_ZAPI5_ROUTE_TABLE,
+	"ldp":                FRR_ZAPI5_ROUTE_LDP,
+	"vnc":                FRR_ZAPI5_ROUTE_VNC,
+	"vnc-direct":         FRR_ZAPI5_ROUTE_VNC_DIRECT,
+	"vnc-direct-rh":      FRR_ZAPI5_ROUTE_VNC_DIRECT_RH,
+	"bgp-direct":         FRR_ZAPI5_ROUTE_BGP_DIRECT,
+	"bgp-direct-ext":     FRR_ZAPI5_ROUTE_BGP_DIRECT_EXT,
+	"babel":              FRR_ZAPI5_ROUTE_BABEL,
+	"sharp":              FRR_ZAPI5_ROUTE_SHARP,
+	"all":                FRR_ZAPI5_ROUTE_ALL,
+}
+
 func RouteTypeFromString(typ string) (ROUTE_TYPE, error) {
 	t, ok := routeTypeValueMap[typ]
 	if ok {
@@ -363,6 +504,39 @@ func RouteTypeFromString(typ string) (ROUTE_TYPE, error) {
 	return t, fmt.Errorf("unknown route type: %s", typ)
 }
 
+// RouteTypesFromString is like RouteTypeFromString but expands "all" into
+// every route type supported by the given message version. ROUTE_BGP is
+// not included to avoid redistributing our own routes back to us.
+func RouteTypesFromString(typ string, version uint8) ([]ROUTE_TYPE, error) {
+	if version >= 5 && typ != "all" {
+		t, ok := frrZapi5RouteTypeValueMap[typ]
+		if !ok {
+			return nil, fmt.Errorf("unknown route type: %s", typ)
+		}
+		return []ROUTE_TYPE{t}, nil
+	}
+	if typ != "all" {
+		t, err := RouteTypeFromString(typ)
+		if err != nil {
+			return nil, err
+		}
+		return []ROUTE_TYPE{t}, nil
+	}
+	max := ROUTE_MAX
+	if version >= 5 {
+		max = FRR_ZAPI5_ROUTE_ALL
+	} else if version >= 4 {
+		max = FRR_ROUTE_ALL
+	}
+	types := make([]ROUTE_TYPE, 0, int(max))
+	for t := ROUTE_SYSTEM; t < max; t++ {
+		if t != ROUTE_BGP {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
 // API Message Flags.
 type MESSAGE_FLAG uint8
 
@@ -410,8 +584,91 @@ const (
 	FRR_MESSAGE_TAG      MESSAGE_FLAG = 0x10
 	FRR_MESSAGE_MTU      MESSAGE_FLAG = 0x20
 	//FRR_MESSAGE_SRCPFX   MESSAGE_FLAG = 0x40
+	// Shares its bit with MESSAGE_ASPATH, which is not sent along with the
+	// labels.
+	FRR_MESSAGE_LABEL MESSAGE_FLAG = 0x80
+)
+
+// For FRRouting version 4 and later, which speaks message versions 5 and 6.
+// IPRouteBody keeps the FRR_MESSAGE_* flags in its Message field for every
+// FRRouting version and maps them to these on the wire.
+const (
+	FRR_ZAPI5_MESSAGE_NEXTHOP  MESSAGE_FLAG = 0x01
+	FRR_ZAPI5_MESSAGE_DISTANCE MESSAGE_FLAG = 0x02
+	FRR_ZAPI5_MESSAGE_METRIC   MESSAGE_FLAG = 0x04
+	FRR_ZAPI5_MESSAGE_TAG      MESSAGE_FLAG = 0x08
+	FRR_ZAPI5_MESSAGE_MTU      MESSAGE_FLAG = 0x10
+	FRR_ZAPI5_MESSAGE_SRCPFX   MESSAGE_FLAG = 0x20
+	FRR_ZAPI5_MESSAGE_LABEL    MESSAGE_FLAG = 0x40
+)
+
+// frrZapi5MessageFlags maps the FRR_MESSAGE_* flags to the
+// FRR_ZAPI5_MESSAGE_* ones.
+var frrZapi5MessageFlags = []struct {
+	flag, zapi5 MESSAGE_FLAG
+}{
+	{FRR_MESSAGE_NEXTHOP, FRR_ZAPI5_MESSAGE_NEXTHOP},
+	{FRR_MESSAGE_DISTANCE, FRR_ZAPI5_MESSAGE_DISTANCE},
+	{FRR_MESSAGE_METRIC, FRR_ZAPI5_MESSAGE_METRIC},
+	{FRR_MESSAGE_TAG, FRR_ZAPI5_MESSAGE_TAG},
+	{FRR_MESSAGE_MTU, FRR_ZAPI5_MESSAGE_MTU},
+	{FRR_MESSAGE_LABEL, FRR_ZAPI5_MESSAGE_LABEL},
+}
+
+// Types of the entries carried in the Aux field of IPRouteBody when it is
+// encoded by SerializeAux.
+type AUX_TYPE uint8
+
+const (
+	AUX_TYPE_ASPATH   AUX_TYPE = 0x01
+	AUX_TYPE_METADATA AUX_TYPE = 0x02
+	// MPLS label stack, 4 bytes per label from the top.
+	AUX_TYPE_LABEL AUX_TYPE = 0x03
 )
 
+// AuxEntry is an entry of the Aux field of IPRouteBody.
+type AuxEntry struct {
+	Type  AUX_TYPE
+	Value []byte
+}
+
+// SerializeAux encodes the given entries into the Aux field of IPRouteBody
+// as a sequence of type(1), length(2) and value.
+func SerializeAux(entries []AuxEntry) ([]byte, error) {
+	buf := make([]byte, 0)
+	for _, e := range entries {
+		if len(e.Value) > math.MaxUint16 {
+			return nil, fmt.Errorf("aux entry %d is too long: %d", e.Type, len(e.Value))
+		}
+		bbuf := make([]byte, 3)
+		bbuf[0] = uint8(e.Type)
+		binary.BigEndian.PutUint16(bbuf[1:], uint16(len(e.Value)))
+		buf = append(buf, bbuf...)
+		buf = append(buf, e.Value...)
+	}
+	return buf, nil
+}
+
+// ParseAux decodes the Aux field of IPRouteBody encoded by SerializeAux.
+func ParseAux(data []byte) ([]AuxEntry, error) {
+	entries := make([]AuxEntry, 0)
+	for len(data) > 0 {
+		if len(data) < 3 {
+			return nil, fmt.Errorf("aux entry header is truncated")
+		}
+		l := int(binary.BigEndian.Uint16(data[1:3]))
+		if len(data[3:]) < l {
+			return nil, fmt.Errorf("aux entry %d is truncated", data[0])
+		}
+		entries = append(entries, AuxEntry{
+			Type:  AUX_TYPE(data[0]),
+			Value: data[3 : 3+l],
+		})
+		data = data[3+l:]
+	}
+	return entries, nil
+}
+
 // Message Flags
 type FLAG uint64
 
@@ -428,6 +685,31 @@ const (
 	FLAG_FIB_OVERRIDE FLAG = 0x200
 )
 
+// For FRRouting version 4 and later, which speaks message versions 5 and 6.
+// IPRouteBody keeps the FLAG_* flags in its Flags field and maps them to
+// these on the wire. FLAG_BLACKHOLE and FLAG_REJECT have no counterpart:
+// the route is sent with a blackhole nexthop instead.
+const (
+	FRR_ZAPI5_FLAG_ALLOW_RECURSION FLAG = 0x01
+	FRR_ZAPI5_FLAG_SELFROUTE       FLAG = 0x02
+	FRR_ZAPI5_FLAG_IBGP            FLAG = 0x04
+	FRR_ZAPI5_FLAG_SELECTED        FLAG = 0x08
+	FRR_ZAPI5_FLAG_FIB_OVERRIDE    FLAG = 0x10
+)
+
+// frrZapi5Flags maps the FLAG_* flags to the FRR_ZAPI5_FLAG_* ones. The
+// routes of iBGP and multihop eBGP peers, flagged FLAG_INTERNAL, are the
+// ones whose nexthops are resolved recursively.
+var frrZapi5Flags = []struct {
+	flag, zapi5 FLAG
+}{
+	{FLAG_INTERNAL, FRR_ZAPI5_FLAG_ALLOW_RECURSION},
+	{FLAG_SELFROUTE, FRR_ZAPI5_FLAG_SELFROUTE},
+	{FLAG_IBGP, FRR_ZAPI5_FLAG_IBGP},
+	{FLAG_SELECTED, FRR_ZAPI5_FLAG_SELECTED},
+	{FLAG_FIB_OVERRIDE, FRR_ZAPI5_FLAG_FIB_OVERRIDE},
+}
+
 func (t FLAG) String() string {
 	var ss []string
 	if t&FLAG_INTERNAL > 0 {
@@ -492,6 +774,17 @@ const (
 	FRR_NEXTHOP_BLACKHOLE
 )
 
+// Blackhole types of the FRR_NEXTHOP_BLACKHOLE nexthops since message
+// version 5.
+type BLACKHOLE_TYPE uint8
+
+const (
+	BLACKHOLE_UNSPEC BLACKHOLE_TYPE = iota
+	BLACKHOLE_NULL
+	BLACKHOLE_REJECT
+	BLACKHOLE_ADMINPROHIB
+)
+
 // Interface PTM Enable Configuration.
 //go:generate stringer -type=PTM_ENABLE
 type PTM_ENABLE uint8
@@ -518,19 +811,39 @@ type Client struct {
 	redistDefault ROUTE_TYPE
 	conn          net.Conn
 	Version       uint8
+	writeBufSize  int
+	flushInterval time.Duration
+	writerDone    chan struct{}
+	// closeOnce closes outgoing, which both Close() and the writer do.
+	closeOnce sync.Once
+	// aborted is closed by Abort() to make the writer and the senders
+	// give up.
+	aborted   chan struct{}
+	abortOnce sync.Once
 }
 
 func NewClient(network, address string, typ ROUTE_TYPE, version uint8) (*Client, error) {
+	return NewBufferedClient(network, address, typ, version, 0, 0)
+}
+
+// NewBufferedClient is the same as NewClient except that outgoing messages
+// are coalesced into a write buffer of bufSize bytes. The buffer is flushed
+// when it gets full and every flushInterval, or as soon as no more messages
+// are queued if flushInterval is zero. bufSize zero disables buffering.
+func NewBufferedClient(network, address string, typ ROUTE_TYPE, version uint8, bufSize int, flushInterval time.Duration) (*Client, error) {
 	conn, err := net.Dial(network, address)
 	if err != nil {
 		return nil, err
 	}
 	outgoing := make(chan *Message)
+	if bufSize > 0 {
+		outgoing = make(chan *Message, 128)
+	}
 	incoming := make(chan *Message, 64)
 	if version < 2 {
 		version = 2
-	} else if version > 4 {
-		version = 4
+	} else if version > MaxVersion {
+		version = MaxVersion
 	}
 
 	c := &Client{
@@ -539,33 +852,13 @@ func NewClient(network, address string, typ ROUTE_TYPE, version uint8) (*Client,
 		redistDefault: typ,
 		conn:          conn,
 		Version:       version,
+		writeBufSize:  bufSize,
+		flushInterval: flushInterval,
+		writerDone:    make(chan struct{}),
+		aborted:       make(chan struct{}),
 	}
 
-	go func() {
-		for {
-			m, more := <-outgoing
-			if more {
-				b, err := m.Serialize()
-				if err != nil {
-					log.WithFields(log.Fields{
-						"Topic": "Zebra",
-					}).Warnf("failed to serialize: %s", m)
-					continue
-				}
-
-				_, err = conn.Write(b)
-				if err != nil {
-					log.WithFields(log.Fields{
-						"Topic": "Zebra",
-					}).Errorf("failed to write: %s", err)
-					close(outgoing)
-				}
-			} else {
-				log.Debug("finish outgoing loop")
-				return
-			}
-		}
-	}()
+	go c.writeLoop()
 
 	// Send HELLO/ROUTER_ID_ADD messages to negotiate the Zebra message version.
 	c.SendHello()
@@ -615,12 +908,17 @@ func NewClient(network, address string, typ ROUTE_TYPE, version uint8) (*Client,
 		return m, nil
 	}
 
-	// Try to receive the first message from Zebra.
+	// Try to receive the first message from Zebra, which has to speak
+	// the same version.
 	if m, err := receiveSingleMsg(); err != nil {
 		c.Close()
 		// Return error explicitly in order to retry connection.
 		return nil, err
 	} else if m != nil {
+		if m.Header.Version != version {
+			c.Close()
+			return nil, fmt.Errorf("zebra speaks message version %d instead of %d", m.Header.Version, version)
+		}
 		incoming <- m
 	}
 
@@ -639,6 +937,97 @@ func NewClient(network, address string, typ ROUTE_TYPE, version uint8) (*Client,
 	return c, nil
 }
 
+func (c *Client) writeLoop() {
+	defer close(c.writerDone)
+
+	var w io.Writer = c.conn
+	var bw *bufio.Writer
+	var tick <-chan time.Time
+	if c.writeBufSize > 0 {
+		bw = bufio.NewWriterSize(c.conn, c.writeBufSize)
+		w = bw
+		if c.flushInterval > 0 {
+			t := time.NewTicker(c.flushInterval)
+			defer t.Stop()
+			tick = t.C
+		}
+	}
+
+	flush := func() error {
+		if bw != nil {
+			return bw.Flush()
+		}
+		return nil
+	}
+	write := func(m *Message) error {
+		b, err := m.Serialize()
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+			}).Warnf("failed to serialize: %s", m)
+			return nil
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	finish := func() {
+		if err := flush(); err != nil {
+			log.WithFields(log.Fields{
+				"Topic": "Zebra",
+			}).Errorf("failed to flush: %s", err)
+		}
+		log.Debug("finish outgoing loop")
+	}
+
+	for {
+		select {
+		case m, more := <-c.outgoing:
+			if !more {
+				finish()
+				return
+			}
+			err := write(m)
+			if err == nil && bw != nil && tick == nil {
+				// Write out whatever is queued now, then flush.
+			drain:
+				for err == nil {
+					select {
+					case m, more = <-c.outgoing:
+						if !more {
+							finish()
+							return
+						}
+						err = write(m)
+					default:
+						break drain
+					}
+				}
+				if err == nil {
+					err = flush()
+				}
+			}
+			if err != nil {
+				if c.isAborted() {
+					return
+				}
+				log.WithFields(log.Fields{
+					"Topic": "Zebra",
+				}).Errorf("failed to write: %s", err)
+				c.closeOutgoing()
+			}
+		case <-c.aborted:
+			return
+		case <-tick:
+			if err := flush(); err != nil {
+				log.WithFields(log.Fields{
+					"Topic": "Zebra",
+				}).Errorf("failed to write: %s", err)
+				c.closeOutgoing()
+			}
+		}
+	}
+}
+
 func readAll(conn net.Conn, length int) ([]byte, error) {
 	buf := make([]byte, length)
 	_, err := io.ReadFull(conn, buf)
@@ -649,12 +1038,24 @@ func (c *Client) Receive() chan *Message {
 	return c.incoming
 }
 
+// MessageVersion returns the Zebra message version negotiated by the
+// client.
+func (c *Client) MessageVersion() uint8 {
+	return c.Version
+}
+
 func (c *Client) Send(m *Message) {
+	c.send(m)
+}
+
+// send queues m to be written and fails if the client has been closed.
+func (c *Client) send(m *Message) (err error) {
 	defer func() {
-		if err := recover(); err != nil {
+		if r := recover(); r != nil {
 			log.WithFields(log.Fields{
 				"Topic": "Zebra",
-			}).Debugf("recovered: %s", err)
+			}).Debugf("recovered: %s", r)
+			err = fmt.Errorf("failed to send %s: client closed", m.Header.Command)
 		}
 	}()
 	log.WithFields(log.Fields{
@@ -662,10 +1063,18 @@ func (c *Client) Send(m *Message) {
 		"Header": m.Header,
 		"Body":   m.Body,
 	}).Debug("send command to zebra")
-	c.outgoing <- m
+	if c.isAborted() {
+		return fmt.Errorf("failed to send %s: client aborted", m.Header.Command)
+	}
+	select {
+	case c.outgoing <- m:
+	case <-c.aborted:
+		return fmt.Errorf("failed to send %s: client aborted", m.Header.Command)
+	}
+	return nil
 }
 
-func (c *Client) SendCommand(command API_TYPE, vrfId uint16, body Body) error {
+func (c *Client) SendCommand(command API_TYPE, vrfId uint32, body Body) error {
 	var marker uint8 = HEADER_MARKER
 	if c.Version >= 4 {
 		marker = FRR_HEADER_MARKER
@@ -680,8 +1089,7 @@ func (c *Client) SendCommand(command API_TYPE, vrfId uint16, body Body) error {
 		},
 		Body: body,
 	}
-	c.Send(m)
-	return nil
+	return c.send(m)
 }
 
 func (c *Client) SendHello() error {
@@ -691,7 +1099,9 @@ func (c *Client) SendHello() error {
 			RedistDefault: c.redistDefault,
 			Instance:      0,
 		}
-		if c.Version >= 4 {
+		if c.Version >= 5 {
+			command = FRR_ZAPI5_HELLO
+		} else if c.Version >= 4 {
 			command = FRR_HELLO
 		}
 		return c.SendCommand(command, VRF_DEFAULT, body)
@@ -701,7 +1111,9 @@ func (c *Client) SendHello() error {
 
 func (c *Client) SendRouterIDAdd() error {
 	command := ROUTER_ID_ADD
-	if c.Version >= 4 {
+	if c.Version >= 5 {
+		command = FRR_ZAPI5_ROUTER_ID_ADD
+	} else if c.Version >= 4 {
 		command = FRR_ROUTER_ID_ADD
 	}
 	return c.SendCommand(command, VRF_DEFAULT, nil)
@@ -709,13 +1121,15 @@ func (c *Client) SendRouterIDAdd() error {
 
 func (c *Client) SendInterfaceAdd() error {
 	command := INTERFACE_ADD
-	if c.Version >= 4 {
+	if c.Version >= 5 {
+		command = FRR_ZAPI5_INTERFACE_ADD
+	} else if c.Version >= 4 {
 		command = FRR_INTERFACE_ADD
 	}
 	return c.SendCommand(command, VRF_DEFAULT, nil)
 }
 
-func (c *Client) SendRedistribute(t ROUTE_TYPE, vrfId uint16) error {
+func (c *Client) SendRedistribute(t ROUTE_TYPE, vrfId uint32) error {
 	command := REDISTRIBUTE_ADD
 	// Enable redistribute bgp.
 	//if c.redistDefault != t {
@@ -726,6 +1140,9 @@ func (c *Client) SendRedistribute(t ROUTE_TYPE, vrfId uint16) error {
 		})
 	} else { // version >= 4
 		command = FRR_REDISTRIBUTE_ADD
+		if c.Version >= 5 {
+			command = FRR_ZAPI5_REDISTRIBUTE_ADD
+		}
 		for _, afi := range []AFI{AFI_IP, AFI_IP6} {
 			bodies = append(bodies, &RedistributeBody{
 				Afi:      afi,
@@ -736,29 +1153,57 @@ func (c *Client) SendRedistribute(t ROUTE_TYPE, vrfId uint16) error {
 	}
 
 	for _, body := range bodies {
-		return c.SendCommand(command, vrfId, body)
+		if err := c.SendCommand(command, vrfId, body); err != nil {
+			return err
+		}
 	}
 	//}
 
 	return nil
 }
 
-func (c *Client) SendRedistributeDelete(t ROUTE_TYPE) error {
-	if t < ROUTE_MAX {
-		command := REDISTRIBUTE_DELETE
-		if c.Version >= 4 {
-			command = FRR_REDISTRIBUTE_DELETE
-		}
-		body := &RedistributeBody{
+func (c *Client) SendRedistributeDelete(t ROUTE_TYPE, vrfId uint32) error {
+	max := ROUTE_MAX
+	command := FRR_REDISTRIBUTE_DELETE
+	if c.Version >= 5 {
+		max = FRR_ZAPI5_ROUTE_MAX
+		command = FRR_ZAPI5_REDISTRIBUTE_DELETE
+	} else if c.Version >= 4 {
+		max = FRR_ROUTE_MAX
+	}
+	if t >= max {
+		return fmt.Errorf("unknown route type: %d", t)
+	}
+	if c.Version <= 3 {
+		return c.SendCommand(REDISTRIBUTE_DELETE, vrfId, &RedistributeBody{
 			Redist: t,
+		})
+	}
+	// As with SendRedistribute, the redistribution is removed for both
+	// address families.
+	for _, afi := range []AFI{AFI_IP, AFI_IP6} {
+		if err := c.SendCommand(command, vrfId, &RedistributeBody{
+			Afi:    afi,
+			Redist: t,
+		}); err != nil {
+			return err
 		}
-		return c.SendCommand(command, VRF_DEFAULT, body)
-	} else {
-		return fmt.Errorf("unknown route type: %d", t)
 	}
+	return nil
 }
 
-func (c *Client) SendIPRoute(vrfId uint16, body *IPRouteBody, isWithdraw bool) error {
+func (c *Client) SendIPRoute(vrfId uint32, body *IPRouteBody, isWithdraw bool) error {
+	if c.Version >= 5 {
+		// The routes of both address families are sent with the same
+		// commands, and their nexthops are in the VRF of the route.
+		command := FRR_ZAPI5_ROUTE_ADD
+		if isWithdraw {
+			command = FRR_ZAPI5_ROUTE_DELETE
+		}
+		b := *body
+		b.NexthopVrfId = vrfId
+		return c.SendCommand(command, vrfId, &b)
+	}
 	command := IPV4_ROUTE_ADD
 	if c.Version <= 3 {
 		if body.Prefix.To4() != nil {
@@ -790,7 +1235,7 @@ func (c *Client) SendIPRoute(vrfId uint16, body *IPRouteBody, isWithdraw bool) e
 	return c.SendCommand(command, vrfId, body)
 }
 
-func (c *Client) SendNexthopRegister(vrfId uint16, body *NexthopRegisterBody, isWithdraw bool) error {
+func (c *Client) SendNexthopRegister(vrfId uint32, body *NexthopRegisterBody, isWithdraw bool) error {
 	// Note: NEXTHOP_REGISTER and NEXTHOP_UNREGISTER messages are not
 	// supported in Zebra protocol version<3.
 	if c.Version < 3 {
@@ -801,26 +1246,55 @@ func (c *Client) SendNexthopRegister(vrfId uint16, body *NexthopRegisterBody, is
 		if isWithdraw {
 			command = NEXTHOP_UNREGISTER
 		}
-	} else { // version >= 4
+	} else if c.Version == 4 {
 		if isWithdraw {
 			command = FRR_NEXTHOP_UNREGISTER
 		} else {
 			command = FRR_NEXTHOP_REGISTER
 		}
+	} else { // version >= 5
+		if isWithdraw {
+			command = FRR_ZAPI5_NEXTHOP_UNREGISTER
+		} else {
+			command = FRR_ZAPI5_NEXTHOP_REGISTER
+		}
 	}
 	return c.SendCommand(command, vrfId, body)
 }
 
+func (c *Client) closeOutgoing() {
+	c.closeOnce.Do(func() { close(c.outgoing) })
+}
+
 func (c *Client) Close() error {
-	close(c.outgoing)
+	c.closeOutgoing()
+	// Wait for the buffered messages to be flushed.
+	<-c.writerDone
 	return c.conn.Close()
 }
 
+// Abort closes the connection to Zebra without waiting for the buffered
+// messages to be flushed, e.g. when Zebra stopped reading them. The
+// messages sent afterwards are dropped.
+func (c *Client) Abort() error {
+	c.abortOnce.Do(func() { close(c.aborted) })
+	return c.conn.Close()
+}
+
+func (c *Client) isAborted() bool {
+	select {
+	case <-c.aborted:
+		return true
+	default:
+		return false
+	}
+}
+
 type Header struct {
 	Len     uint16
 	Marker  uint8
 	Version uint8
-	VrfId   uint16
+	VrfId   uint32
 	Command API_TYPE
 }
 
@@ -833,8 +1307,14 @@ func (h *Header) Serialize() ([]byte, error) {
 	case 2:
 		binary.BigEndian.PutUint16(buf[4:6], uint16(h.Command))
 	case 3, 4:
+		if h.VrfId > MaxVrfId(h.Version) {
+			return nil, fmt.Errorf("VRF id %d is too large for ZAPI version %d", h.VrfId, h.Version)
+		}
 		binary.BigEndian.PutUint16(buf[4:6], uint16(h.VrfId))
 		binary.BigEndian.PutUint16(buf[6:8], uint16(h.Command))
+	case 5, 6:
+		binary.BigEndian.PutUint32(buf[4:8], h.VrfId)
+		binary.BigEndian.PutUint16(buf[8:10], uint16(h.Command))
 	default:
 		return nil, fmt.Errorf("Unsupported ZAPI version: %d", h.Version)
 	}
@@ -855,8 +1335,11 @@ func (h *Header) DecodeFromBytes(data []byte) error {
 	case 2:
 		h.Command = API_TYPE(binary.BigEndian.Uint16(data[4:6]))
 	case 3, 4:
-		h.VrfId = binary.BigEndian.Uint16(data[4:6])
+		h.VrfId = uint32(binary.BigEndian.Uint16(data[4:6]))
 		h.Command = API_TYPE(binary.BigEndian.Uint16(data[6:8]))
+	case 5, 6:
+		h.VrfId = binary.BigEndian.Uint32(data[4:8])
+		h.Command = API_TYPE(binary.BigEndian.Uint16(data[8:10]))
 	default:
 		return fmt.Errorf("Unsupported ZAPI version: %d", h.Version)
 	}
@@ -889,6 +1372,9 @@ func (b *UnknownBody) String() string {
 type HelloBody struct {
 	RedistDefault ROUTE_TYPE
 	Instance      uint16
+	// ReceiveNotify asks for FRR_ZAPI5_ROUTE_NOTIFY_OWNER messages since
+	// version 5.
+	ReceiveNotify uint8
 }
 
 func (b *HelloBody) DecodeFromBytes(data []byte, version uint8) error {
@@ -896,25 +1382,33 @@ func (b *HelloBody) DecodeFromBytes(data []byte, version uint8) error {
 	if version >= 4 {
 		b.Instance = binary.BigEndian.Uint16(data[1:3])
 	}
+	if version >= 5 {
+		b.ReceiveNotify = data[3]
+	}
 	return nil
 }
 
 func (b *HelloBody) Serialize(version uint8) ([]byte, error) {
 	if version <= 3 {
 		return []byte{uint8(b.RedistDefault)}, nil
-	} else { // version >= 4
+	} else if version == 4 {
 		buf := make([]byte, 3, 3)
 		buf[0] = uint8(b.RedistDefault)
 		binary.BigEndian.PutUint16(buf[1:3], b.Instance)
 		return buf, nil
-
+	} else { // version >= 5
+		buf := make([]byte, 4, 4)
+		buf[0] = uint8(b.RedistDefault)
+		binary.BigEndian.PutUint16(buf[1:3], b.Instance)
+		buf[3] = b.ReceiveNotify
+		return buf, nil
 	}
 }
 
 func (b *HelloBody) String() string {
 	return fmt.Sprintf(
-		"route_type: %s, instance :%d",
-		b.RedistDefault.String(), b.Instance)
+		"route_type: %s, instance :%d, receive_notify: %d",
+		b.RedistDefault.String(), b.Instance, b.ReceiveNotify)
 }
 
 type RedistributeBody struct {
@@ -1106,6 +1600,10 @@ type IPRouteBody struct {
 	Api             API_TYPE
 	Aux             []byte
 	PathId          uint32
+	// MPLS label stack from the top, sent with FRR_MESSAGE_LABEL.
+	Labels []uint32
+	// VRF id of the nexthops, sent since version 6.
+	NexthopVrfId uint32
 }
 
 func (b *IPRouteBody) RouteFamily() bgp.RouteFamily {
@@ -1114,6 +1612,12 @@ func (b *IPRouteBody) RouteFamily() bgp.RouteFamily {
 		return bgp.RF_IPv4_UC
 	case IPV6_ROUTE_ADD, IPV6_ROUTE_DELETE, FRR_REDISTRIBUTE_IPV6_ADD, FRR_REDISTRIBUTE_IPV6_DEL:
 		return bgp.RF_IPv6_UC
+	case FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD, FRR_ZAPI5_REDISTRIBUTE_ROUTE_DEL:
+		// The address family is carried by the body since version 5.
+		if len(b.Prefix) == net.IPv4len {
+			return bgp.RF_IPv4_UC
+		}
+		return bgp.RF_IPv6_UC
 	default:
 		return bgp.RF_OPAQUE
 	}
@@ -1121,7 +1625,7 @@ func (b *IPRouteBody) RouteFamily() bgp.RouteFamily {
 
 func (b *IPRouteBody) IsWithdraw() bool {
 	switch b.Api {
-	case IPV4_ROUTE_DELETE, FRR_REDISTRIBUTE_IPV4_DEL, IPV6_ROUTE_DELETE, FRR_REDISTRIBUTE_IPV6_DEL:
+	case IPV4_ROUTE_DELETE, FRR_REDISTRIBUTE_IPV4_DEL, IPV6_ROUTE_DELETE, FRR_REDISTRIBUTE_IPV6_DEL, FRR_ZAPI5_REDISTRIBUTE_ROUTE_DEL:
 		return true
 	default:
 		return false
@@ -1129,6 +1633,9 @@ func (b *IPRouteBody) IsWithdraw() bool {
 }
 
 func (b *IPRouteBody) Serialize(version uint8) ([]byte, error) {
+	if version >= 5 {
+		return b.serializeZapi5(version)
+	}
 
 	var buf []byte
 	nhfIPv4 := uint8(NEXTHOP_IPV4)
@@ -1162,6 +1669,10 @@ func (b *IPRouteBody) Serialize(version uint8) ([]byte, error) {
 	// 	buf = append(buf, b.SrcPrefix[:byteLen]...)
 	// }
 
+	// In version 4 and above, each IPv4/IPv6 nexthop of a labeled route is
+	// followed by the top label, the only one the message carries, and
+	// since version 5 by the number of labels and the whole stack.
+	withLabels := version >= 4 && b.Message&FRR_MESSAGE_LABEL > 0 && len(b.Labels) > 0
 	if b.Message&MESSAGE_NEXTHOP > 0 {
 		if b.Flags&FLAG_BLACKHOLE > 0 {
 			buf = append(buf, []byte{1, nhfBlkH}...)
@@ -1169,14 +1680,30 @@ func (b *IPRouteBody) Serialize(version uint8) ([]byte, error) {
 			buf = append(buf, uint8(len(b.Nexthops)+len(b.Ifindexs)))
 		}
 
+		// The nexthop of an IPv6 route is always encoded with the IPv6
+		// address length, even when it is an IPv4-mapped address (e.g. the
+		// nexthop of an IPv6 VPN route learned over an IPv4 core).
+		isV4 := b.Prefix.To4() != nil
 		for _, v := range b.Nexthops {
-			if v.To4() != nil {
+			if isV4 && v.To4() != nil {
 				buf = append(buf, nhfIPv4)
 				buf = append(buf, v.To4()...)
 			} else {
 				buf = append(buf, nhfIPv6)
 				buf = append(buf, v.To16()...)
 			}
+			if withLabels && version >= 5 {
+				buf = append(buf, uint8(len(b.Labels)))
+				for _, l := range b.Labels {
+					bbuf := make([]byte, 4)
+					binary.BigEndian.PutUint32(bbuf, l)
+					buf = append(buf, bbuf...)
+				}
+			} else if withLabels {
+				bbuf := make([]byte, 4)
+				binary.BigEndian.PutUint32(bbuf, b.Labels[0])
+				buf = append(buf, bbuf...)
+			}
 		}
 
 		for _, v := range b.Ifindexs {
@@ -1200,7 +1727,7 @@ func (b *IPRouteBody) Serialize(version uint8) ([]byte, error) {
 		binary.BigEndian.PutUint32(bbuf, b.PathId)
 		buf = append(buf, bbuf...)
 	}
-	if b.Message&MESSAGE_ASPATH > 0 {
+	if b.Message&MESSAGE_ASPATH > 0 && !withLabels {
 		bbuf := make([]byte, 4)
 		binary.BigEndian.PutUint32(bbuf, uint32(len(b.Aux)))
 		buf = append(buf, bbuf...)
@@ -1233,6 +1760,9 @@ func (b *IPRouteBody) Serialize(version uint8) ([]byte, error) {
 }
 
 func (b *IPRouteBody) DecodeFromBytes(data []byte, version uint8) error {
+	if version >= 5 {
+		return b.decodeZapi5FromBytes(data, version)
+	}
 	isV4 := true
 	if version <= 3 {
 		isV4 = b.Api == IPV4_ROUTE_ADD || b.Api == IPV4_ROUTE_DELETE
@@ -1379,6 +1909,303 @@ func (b *IPRouteBody) DecodeFromBytes(data []byte, version uint8) error {
 	return nil
 }
 
+// serializeZapi5 serializes the body in the format of versions 5 and 6,
+// where the address family of the prefix is carried by the body and each
+// nexthop is typed, with the labels of the route if any. AS_PATH and path
+// ids are not sent, FRRouting does not know them.
+func (b *IPRouteBody) serializeZapi5(version uint8) ([]byte, error) {
+	var message MESSAGE_FLAG
+	for _, f := range frrZapi5MessageFlags {
+		if b.Message&f.flag > 0 {
+			message |= f.zapi5
+		}
+	}
+	// FRR_MESSAGE_LABEL shares its bit with MESSAGE_ASPATH.
+	withLabels := b.Message&FRR_MESSAGE_LABEL > 0 && len(b.Labels) > 0
+	if !withLabels {
+		message &^= FRR_ZAPI5_MESSAGE_LABEL
+	}
+	var flags FLAG
+	for _, f := range frrZapi5Flags {
+		if b.Flags&f.flag > 0 {
+			flags |= f.zapi5
+		}
+	}
+	isBlackhole := b.Flags&(FLAG_BLACKHOLE|FLAG_REJECT) > 0
+	if isBlackhole {
+		message |= FRR_ZAPI5_MESSAGE_NEXTHOP
+	}
+
+	buf := make([]byte, 10)
+	buf[0] = uint8(b.Type)
+	binary.BigEndian.PutUint16(buf[1:3], b.Instance)
+	binary.BigEndian.PutUint32(buf[3:7], uint32(flags))
+	buf[7] = uint8(message)
+	buf[8] = uint8(b.SAFI)
+	isV4 := b.Prefix.To4() != nil
+	if isV4 {
+		buf[9] = syscall.AF_INET
+	} else {
+		buf[9] = syscall.AF_INET6
+	}
+	byteLen := (int(b.PrefixLength) + 7) / 8
+	buf = append(buf, b.PrefixLength)
+	if isV4 {
+		buf = append(buf, b.Prefix.To4()[:byteLen]...)
+	} else {
+		buf = append(buf, b.Prefix.To16()[:byteLen]...)
+	}
+
+	if message&FRR_ZAPI5_MESSAGE_NEXTHOP > 0 {
+		appendNexthop := func(typ NEXTHOP_FLAG) {
+			if version >= 6 {
+				bbuf := make([]byte, 4)
+				binary.BigEndian.PutUint32(bbuf, b.NexthopVrfId)
+				buf = append(buf, bbuf...)
+			}
+			buf = append(buf, uint8(typ))
+		}
+		if isBlackhole {
+			buf = append(buf, 0, 1)
+			appendNexthop(FRR_NEXTHOP_BLACKHOLE)
+			if b.Flags&FLAG_REJECT > 0 {
+				buf = append(buf, uint8(BLACKHOLE_REJECT))
+			} else {
+				buf = append(buf, uint8(BLACKHOLE_NULL))
+			}
+		} else {
+			bbuf := make([]byte, 2)
+			binary.BigEndian.PutUint16(bbuf, uint16(len(b.Nexthops)+len(b.Ifindexs)))
+			buf = append(buf, bbuf...)
+			for _, v := range b.Nexthops {
+				// As in version 4, the nexthop of an IPv6 route is
+				// always an IPv6 address.
+				if isV4 && v.To4() != nil {
+					appendNexthop(FRR_NEXTHOP_IPV4)
+					buf = append(buf, v.To4()...)
+				} else {
+					appendNexthop(FRR_NEXTHOP_IPV6)
+					buf = append(buf, v.To16()...)
+				}
+				if withLabels {
+					buf = append(buf, uint8(len(b.Labels)))
+					for _, l := range b.Labels {
+						bbuf := make([]byte, 4)
+						binary.BigEndian.PutUint32(bbuf, l)
+						buf = append(buf, bbuf...)
+					}
+				}
+			}
+			for _, v := range b.Ifindexs {
+				appendNexthop(FRR_NEXTHOP_IFINDEX)
+				bbuf := make([]byte, 4)
+				binary.BigEndian.PutUint32(bbuf, v)
+				buf = append(buf, bbuf...)
+				if withLabels {
+					buf = append(buf, 0)
+				}
+			}
+		}
+	}
+
+	if message&FRR_ZAPI5_MESSAGE_DISTANCE > 0 {
+		buf = append(buf, b.Distance)
+	}
+	for _, v := range []struct {
+		flag  MESSAGE_FLAG
+		value uint32
+	}{
+		{FRR_ZAPI5_MESSAGE_METRIC, b.Metric},
+		{FRR_ZAPI5_MESSAGE_TAG, b.Tag},
+		{FRR_ZAPI5_MESSAGE_MTU, b.Mtu},
+	} {
+		if message&v.flag > 0 {
+			bbuf := make([]byte, 4)
+			binary.BigEndian.PutUint32(bbuf, v.value)
+			buf = append(buf, bbuf...)
+		}
+	}
+	return buf, nil
+}
+
+// decodeZapi5FromBytes decodes the body in the format of versions 5 and 6.
+// The nexthops given by interface only are decoded as the unspecified
+// address, and the blackhole nexthops as FLAG_BLACKHOLE or FLAG_REJECT.
+func (b *IPRouteBody) decodeZapi5FromBytes(data []byte, version uint8) error {
+	if len(data) < 11 {
+		return fmt.Errorf("message length invalid")
+	}
+	b.Type = ROUTE_TYPE(data[0])
+	b.Instance = binary.BigEndian.Uint16(data[1:3])
+	flags := FLAG(binary.BigEndian.Uint32(data[3:7]))
+	message := MESSAGE_FLAG(data[7])
+	b.SAFI = SAFI(data[8])
+	var addrLen int
+	switch data[9] {
+	case syscall.AF_INET:
+		addrLen = net.IPv4len
+	case syscall.AF_INET6:
+		addrLen = net.IPv6len
+	default:
+		return fmt.Errorf("invalid address family: %d", data[9])
+	}
+	b.Flags = 0
+	for _, f := range frrZapi5Flags {
+		if flags&f.zapi5 > 0 {
+			b.Flags |= f.flag
+		}
+	}
+	b.Message = 0
+	for _, f := range frrZapi5MessageFlags {
+		if message&f.zapi5 > 0 {
+			b.Message |= f.flag
+		}
+	}
+
+	b.PrefixLength = data[10]
+	if int(b.PrefixLength) > addrLen*8 {
+		return fmt.Errorf("prefix length is greater than %d", addrLen*8)
+	}
+	pos := 11
+	byteLen := (int(b.PrefixLength) + 7) / 8
+	if len(data[pos:]) < byteLen {
+		return fmt.Errorf("message length invalid")
+	}
+	b.Prefix = make(net.IP, addrLen)
+	copy(b.Prefix, data[pos:pos+byteLen])
+	pos += byteLen
+
+	if message&FRR_ZAPI5_MESSAGE_SRCPFX > 0 {
+		if len(data[pos:]) < 1 {
+			return fmt.Errorf("message length invalid")
+		}
+		b.SrcPrefixLength = data[pos]
+		byteLen = (int(b.SrcPrefixLength) + 7) / 8
+		if len(data[pos+1:]) < byteLen {
+			return fmt.Errorf("message length invalid")
+		}
+		b.SrcPrefix = make(net.IP, addrLen)
+		copy(b.SrcPrefix, data[pos+1:pos+1+byteLen])
+		pos += 1 + byteLen
+	}
+
+	b.Nexthops = []net.IP{}
+	b.Ifindexs = []uint32{}
+	b.Labels = nil
+	if message&FRR_ZAPI5_MESSAGE_NEXTHOP > 0 {
+		if len(data[pos:]) < 2 {
+			return fmt.Errorf("message length invalid")
+		}
+		numNexthop := int(binary.BigEndian.Uint16(data[pos : pos+2]))
+		pos += 2
+		for i := 0; i < numNexthop; i++ {
+			if version >= 6 {
+				if len(data[pos:]) < 4 {
+					return fmt.Errorf("message length invalid")
+				}
+				b.NexthopVrfId = binary.BigEndian.Uint32(data[pos : pos+4])
+				pos += 4
+			}
+			if len(data[pos:]) < 1 {
+				return fmt.Errorf("message length invalid")
+			}
+			typ := NEXTHOP_FLAG(data[pos])
+			pos += 1
+			// address(0, 4 or 16) + ifindex(0 or 4) or blackhole type(1)
+			var nhAddrLen, ifindexLen int
+			switch typ {
+			case FRR_NEXTHOP_IFINDEX:
+				ifindexLen = 4
+			case FRR_NEXTHOP_IPV4, FRR_NEXTHOP_IPV4_IFINDEX:
+				nhAddrLen = net.IPv4len
+			case FRR_NEXTHOP_IPV6, FRR_NEXTHOP_IPV6_IFINDEX:
+				nhAddrLen = net.IPv6len
+			case FRR_NEXTHOP_BLACKHOLE:
+			default:
+				return fmt.Errorf("unknown nexthop type: %d", typ)
+			}
+			if typ == FRR_NEXTHOP_IPV4_IFINDEX || typ == FRR_NEXTHOP_IPV6_IFINDEX {
+				ifindexLen = 4
+			}
+			if typ == FRR_NEXTHOP_BLACKHOLE {
+				if len(data[pos:]) < 1 {
+					return fmt.Errorf("message length invalid")
+				}
+				if BLACKHOLE_TYPE(data[pos]) == BLACKHOLE_REJECT {
+					b.Flags |= FLAG_REJECT
+				} else {
+					b.Flags |= FLAG_BLACKHOLE
+				}
+				pos += 1
+			} else {
+				if len(data[pos:]) < nhAddrLen+ifindexLen {
+					return fmt.Errorf("message length invalid")
+				}
+				nexthop := net.IP(make([]byte, addrLen))
+				if nhAddrLen > 0 {
+					nexthop = net.IP(append([]byte{}, data[pos:pos+nhAddrLen]...))
+				}
+				pos += nhAddrLen
+				var ifidx uint32
+				if ifindexLen > 0 {
+					ifidx = binary.BigEndian.Uint32(data[pos : pos+4])
+					pos += 4
+				}
+				b.Nexthops = append(b.Nexthops, nexthop)
+				b.Ifindexs = append(b.Ifindexs, ifidx)
+			}
+			if message&FRR_ZAPI5_MESSAGE_LABEL > 0 {
+				if len(data[pos:]) < 1 {
+					return fmt.Errorf("message length invalid")
+				}
+				numLabel := int(data[pos])
+				pos += 1
+				if len(data[pos:]) < 4*numLabel {
+					return fmt.Errorf("message length invalid")
+				}
+				labels := make([]uint32, 0, numLabel)
+				for j := 0; j < numLabel; j++ {
+					labels = append(labels, binary.BigEndian.Uint32(data[pos:pos+4]))
+					pos += 4
+				}
+				if b.Labels == nil && len(labels) > 0 {
+					b.Labels = labels
+				}
+			}
+		}
+	}
+
+	rest := 0
+	if message&FRR_ZAPI5_MESSAGE_DISTANCE > 0 {
+		rest += 1
+	}
+	for _, f := range []MESSAGE_FLAG{FRR_ZAPI5_MESSAGE_METRIC, FRR_ZAPI5_MESSAGE_TAG, FRR_ZAPI5_MESSAGE_MTU} {
+		if message&f > 0 {
+			rest += 4
+		}
+	}
+	if len(data[pos:]) != rest {
+		return fmt.Errorf("message length invalid")
+	}
+	if message&FRR_ZAPI5_MESSAGE_DISTANCE > 0 {
+		b.Distance = data[pos]
+		pos += 1
+	}
+	if message&FRR_ZAPI5_MESSAGE_METRIC > 0 {
+		b.Metric = binary.BigEndian.Uint32(data[pos : pos+4])
+		pos += 4
+	}
+	if message&FRR_ZAPI5_MESSAGE_TAG > 0 {
+		b.Tag = binary.BigEndian.Uint32(data[pos : pos+4])
+		pos += 4
+	}
+	if message&FRR_ZAPI5_MESSAGE_MTU > 0 {
+		b.Mtu = binary.BigEndian.Uint32(data[pos : pos+4])
+		pos += 4
+	}
+	return nil
+}
+
 func (b *IPRouteBody) String() string {
 	s := fmt.Sprintf(
 		"type: %s, instance: %d, flags: %s, message: %d, safi: %s, prefix: %s/%d, src_prefix: %s/%d",
@@ -1389,6 +2216,9 @@ func (b *IPRouteBody) String() string {
 	for i, idx := range b.Ifindexs {
 		s += fmt.Sprintf(", ifindex[%d]: %d", i, idx)
 	}
+	if len(b.Labels) > 0 {
+		s += fmt.Sprintf(", labels: %v", b.Labels)
+	}
 	return s + fmt.Sprintf(
 		", distance: %d, metric: %d, mtu: %d, tag: %d",
 		b.Distance, b.Metric, b.Mtu, b.Tag)
@@ -1407,12 +2237,18 @@ type Nexthop struct {
 	Ifindex uint32
 	Type    NEXTHOP_FLAG
 	Addr    net.IP
+	// MPLS label stack from the top the nexthop is resolved with, carried
+	// by NEXTHOP_UPDATE since version 5.
+	Labels []uint32
 }
 
 func (n *Nexthop) String() string {
 	s := fmt.Sprintf(
 		"type: %s, addr: %s, ifindex: %d, ifname: %s",
 		n.Type.String(), n.Addr.String(), n.Ifindex, n.Ifname)
+	if len(n.Labels) > 0 {
+		s += fmt.Sprintf(", labels: %v", n.Labels)
+	}
 	return s
 }
 
@@ -1481,7 +2317,10 @@ func serializeNexthops(nexthops []*Nexthop, isV4 bool, version uint8) ([]byte, e
 	return buf, nil
 }
 
-func decodeNexthopsFromBytes(nexthops *[]*Nexthop, data []byte, isV4 bool, version uint8) (int, error) {
+// decodeNexthopsFromBytes decodes the nexthops at the beginning of data
+// and returns the number of bytes they take. If withLabels is true, each
+// nexthop is followed by the number of its labels and the labels.
+func decodeNexthopsFromBytes(nexthops *[]*Nexthop, data []byte, isV4 bool, version uint8, withLabels bool) (int, error) {
 	addrLen := net.IPv4len
 	if !isV4 {
 		addrLen = net.IPv6len
@@ -1543,6 +2382,20 @@ func decodeNexthopsFromBytes(nexthops *[]*Nexthop, data []byte, isV4 bool, versi
 			nh.Ifindex = binary.BigEndian.Uint32(data[offset : offset+4])
 			offset += 4
 		}
+		if withLabels {
+			if len(data[offset:]) < 1 {
+				return offset, fmt.Errorf("invalid message length: missing number of labels")
+			}
+			numLabel := int(data[offset])
+			offset += 1
+			if len(data[offset:]) < 4*numLabel {
+				return offset, fmt.Errorf("invalid message length: missing labels(%d bytes): %d<%d", 4*numLabel, len(data[offset:]), 4*numLabel)
+			}
+			for j := 0; j < numLabel; j++ {
+				nh.Labels = append(nh.Labels, binary.BigEndian.Uint32(data[offset:offset+4]))
+				offset += 4
+			}
+		}
 		*nexthops = append(*nexthops, nh)
 	}
 
@@ -1602,7 +2455,7 @@ func (b *NexthopLookupBody) DecodeFromBytes(data []byte, version uint8) error {
 		b.Metric = binary.BigEndian.Uint32(data[pos : pos+4])
 		pos += 4
 		b.Nexthops = []*Nexthop{}
-		if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[pos:], isV4, version); err != nil {
+		if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[pos:], isV4, version, false); err != nil {
 			return err
 		} else {
 			pos += nexthopsByteLen
@@ -1661,7 +2514,7 @@ func (b *ImportLookupBody) DecodeFromBytes(data []byte, version uint8) error {
 		b.Metric = binary.BigEndian.Uint32(data[pos : pos+4])
 		pos += 4
 		b.Nexthops = []*Nexthop{}
-		if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[pos:], isV4, version); err != nil {
+		if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[pos:], isV4, version, false); err != nil {
 			return err
 		} else {
 			pos += nexthopsByteLen
@@ -1691,6 +2544,10 @@ type RegisteredNexthop struct {
 	// - 32 if Address Family is AF_INET
 	// - 128 if Address Family is AF_INET6
 	Prefix net.IP
+	// Zone is the zone of a link-local Prefix. It is not sent to Zebra
+	// but tells apart the same nexthop reached through different
+	// interfaces.
+	Zone string
 }
 
 func (n *RegisteredNexthop) Len() int {
@@ -1813,7 +2670,11 @@ type NexthopUpdateBody struct {
 	// because this field should be always:
 	// - 32 if Address Family is AF_INET
 	// - 128 if Address Family is AF_INET6
-	Prefix   net.IP
+	Prefix net.IP
+	// Type and Instance of the route the nexthop is resolved with, carried
+	// since version 5.
+	Type     ROUTE_TYPE
+	Instance uint16
 	Distance uint8
 	Metric   uint32
 	Nexthops []*Nexthop
@@ -1858,6 +2719,16 @@ func (b *NexthopUpdateBody) DecodeFromBytes(data []byte, version uint8) error {
 	}
 	offset += addrLen
 
+	// Type (1 byte) and Instance (2 bytes) (if version>=5)
+	if version >= 5 {
+		if len(data[offset:]) < 3 {
+			return fmt.Errorf("invalid message length: missing type(1 byte) or instance(2 bytes): %d<3", len(data[offset:]))
+		}
+		b.Type = ROUTE_TYPE(data[offset])
+		b.Instance = binary.BigEndian.Uint16(data[offset+1 : offset+3])
+		offset += 3
+	}
+
 	// Distance (1 byte) (if version>=4)
 	// Metric (4 bytes)
 	// Number of Nexthops (1 byte)
@@ -1873,9 +2744,9 @@ func (b *NexthopUpdateBody) DecodeFromBytes(data []byte, version uint8) error {
 	b.Metric = binary.BigEndian.Uint32(data[offset : offset+4])
 	offset += 4
 
-	// List of Nexthops
+	// List of Nexthops, each followed by its labels since version 5
 	b.Nexthops = []*Nexthop{}
-	if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[offset:], isV4, version); err != nil {
+	if nexthopsByteLen, err := decodeNexthopsFromBytes(&b.Nexthops, data[offset:], isV4, version, version >= 5); err != nil {
 		return err
 	} else {
 		offset += nexthopsByteLen
@@ -1886,8 +2757,8 @@ func (b *NexthopUpdateBody) DecodeFromBytes(data []byte, version uint8) error {
 
 func (b *NexthopUpdateBody) String() string {
 	s := fmt.Sprintf(
-		"family: %d, prefix: %s, distance: %d, metric: %d",
-		b.Family, b.Prefix.String(), b.Distance, b.Metric)
+		"family: %d, prefix: %s, type: %s, instance: %d, distance: %d, metric: %d",
+		b.Family, b.Prefix.String(), b.Type.String(), b.Instance, b.Distance, b.Metric)
 	for _, nh := range b.Nexthops {
 		s = s + fmt.Sprintf(", nexthop:{%s}", nh.String())
 	}
@@ -1977,9 +2848,29 @@ func (m *Message) parseFrrMessage(data []byte) error {
 	return m.Body.DecodeFromBytes(data, m.Header.Version)
 }
 
+func (m *Message) parseFrrZapi5Message(data []byte) error {
+	switch m.Header.Command {
+	case FRR_ZAPI5_INTERFACE_ADD, FRR_ZAPI5_INTERFACE_DELETE, FRR_ZAPI5_INTERFACE_UP, FRR_ZAPI5_INTERFACE_DOWN:
+		m.Body = &InterfaceUpdateBody{}
+	case FRR_ZAPI5_INTERFACE_ADDRESS_ADD, FRR_ZAPI5_INTERFACE_ADDRESS_DELETE:
+		m.Body = &InterfaceAddressUpdateBody{}
+	case FRR_ZAPI5_ROUTER_ID_UPDATE:
+		m.Body = &RouterIDUpdateBody{}
+	case FRR_ZAPI5_NEXTHOP_UPDATE:
+		m.Body = &NexthopUpdateBody{Api: m.Header.Command}
+	case FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD, FRR_ZAPI5_REDISTRIBUTE_ROUTE_DEL:
+		m.Body = &IPRouteBody{Api: m.Header.Command}
+	default:
+		m.Body = &UnknownBody{}
+	}
+	return m.Body.DecodeFromBytes(data, m.Header.Version)
+}
+
 func ParseMessage(hdr *Header, data []byte) (m *Message, err error) {
 	m = &Message{Header: *hdr}
-	if m.Header.Version == 4 {
+	if m.Header.Version >= 5 {
+		err = m.parseFrrZapi5Message(data)
+	} else if m.Header.Version == 4 {
 		err = m.parseFrrMessage(data)
 	} else {
 		err = m.parseMessage(data)
@@ -17,10 +17,13 @@ package zebra
 
 import (
 	"encoding/binary"
+	"math"
 	"net"
 	"syscall"
 	"testing"
+	"time"
 
+	"github.com/osrg/gobgp/packet/bgp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -525,3 +528,568 @@ func Test_NexthopUpdateBody(t *testing.T) {
 	assert.Equal(1, len(b.Nexthops))
 	assert.Equal(nexthop, b.Nexthops[0])
 }
+
+func Test_NexthopUpdateBody_Labels(t *testing.T) {
+	assert := assert.New(t)
+
+	bufIn := []byte{
+		0x00, 0x02, 0x20, // afi(2 bytes)=AF_INET, prefix_len(1 byte)=32
+		0xc0, 0xa8, 0x01, 0x01, // prefix(4 bytes)="192.168.1.1"
+		byte(FRR_ZAPI5_ROUTE_ISIS),
+		0x00, 0x01, // instance(2 bytes)=1
+		0x01,                   // distance(1 byte)=1
+		0x00, 0x00, 0x00, 0x01, // metric(4 bytes)=1
+		0x02, // nexthops(1 byte)=2
+		byte(FRR_NEXTHOP_IPV4_IFINDEX),
+		0xc0, 0xa8, 0x00, 0x01, // nexthop_ip(4 bytes)="192.168.0.1"
+		0x00, 0x00, 0x00, 0x02, // nexthop_ifindex(4 byte)=2
+		0x02,                   // label_num(1 byte)=2
+		0x00, 0x00, 0x3e, 0x81, // label(4 bytes)=16001
+		0x00, 0x00, 0x3e, 0x82, // label(4 bytes)=16002
+		byte(FRR_NEXTHOP_IFINDEX),
+		0x00, 0x00, 0x00, 0x03, // nexthop_ifindex(4 byte)=3
+		0x00, // label_num(1 byte)=0
+	}
+
+	b := &NexthopUpdateBody{Api: FRR_ZAPI5_NEXTHOP_UPDATE}
+	assert.Nil(b.DecodeFromBytes(bufIn, 5))
+	assert.Equal(FRR_ZAPI5_ROUTE_ISIS, b.Type)
+	assert.Equal(uint16(1), b.Instance)
+	if assert.Len(b.Nexthops, 2) {
+		assert.Equal(&Nexthop{
+			Type:    FRR_NEXTHOP_IPV4_IFINDEX,
+			Addr:    net.ParseIP("192.168.0.1").To4(),
+			Ifindex: 2,
+			Labels:  []uint32{16001, 16002},
+		}, b.Nexthops[0])
+		assert.Equal(&Nexthop{
+			Type:    FRR_NEXTHOP_IFINDEX,
+			Ifindex: 3,
+		}, b.Nexthops[1])
+	}
+
+	// truncated labels
+	assert.NotNil(b.DecodeFromBytes(bufIn[:len(bufIn)-10], 5))
+
+	// no route type, instance nor labels before version 5
+	bufV4 := append(append([]byte{}, bufIn[:7]...), bufIn[10:25]...)
+	bufV4[12] = 1
+	b = &NexthopUpdateBody{Api: FRR_NEXTHOP_UPDATE}
+	assert.Nil(b.DecodeFromBytes(bufV4, 4))
+	if assert.Len(b.Nexthops, 1) {
+		assert.Nil(b.Nexthops[0].Labels)
+	}
+}
+
+func Test_IPRouteBody_IPv6MappedNexthop(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &IPRouteBody{
+		Type:         ROUTE_BGP,
+		Message:      MESSAGE_NEXTHOP,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.ParseIP("2001:db8::").To16(),
+		PrefixLength: 64,
+		Nexthops:     []net.IP{net.ParseIP("::ffff:192.0.2.1").To16()},
+	}
+	buf, err := r.Serialize(3)
+	assert.Nil(err)
+	// type(1) + flags(1) + message(1) + safi(2) + plen(1) + prefix(8)
+	// + nexthop num(1) + nexthop type(1) + nexthop(16)
+	assert.Equal(32, len(buf))
+	assert.Equal(byte(1), buf[14])
+	assert.Equal(byte(NEXTHOP_IPV6), buf[15])
+	assert.Equal([]byte(net.ParseIP("::ffff:192.0.2.1").To16()), buf[16:32])
+}
+
+func Test_IPRouteBody_Labels(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &IPRouteBody{
+		Type:         ROUTE_BGP,
+		Message:      MESSAGE_NEXTHOP | FRR_MESSAGE_LABEL,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 24,
+		Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4(), net.ParseIP("192.168.0.2").To4()},
+		Labels:       []uint32{100, 101},
+	}
+	buf, err := r.Serialize(4)
+	assert.Nil(err)
+	// type(1) + instance(2) + flags(4) + message(1) + safi(2) + plen(1)
+	// + prefix(3) + nexthop num(1) + (nexthop type(1) + nexthop(4)
+	// + label(4)) * 2
+	assert.Equal(33, len(buf))
+	assert.Equal(byte(2), buf[14])
+	assert.Equal(byte(FRR_NEXTHOP_IPV4), buf[15])
+	assert.Equal([]byte{0, 0, 0, 100}, buf[20:24])
+	assert.Equal(byte(FRR_NEXTHOP_IPV4), buf[24])
+	assert.Equal([]byte{0, 0, 0, 100}, buf[29:33])
+
+	// the whole stack since version 5.
+	buf, err = r.Serialize(5)
+	assert.Nil(err)
+	// type(1) + instance(2) + flags(4) + message(1) + safi(1) + family(1)
+	// + plen(1) + prefix(3) + nexthop num(2) + (nexthop type(1)
+	// + nexthop(4) + label num(1) + labels(4*2)) * 2
+	assert.Equal(44, len(buf))
+	assert.Equal(byte(FRR_ZAPI5_MESSAGE_NEXTHOP|FRR_ZAPI5_MESSAGE_LABEL), buf[7])
+	assert.Equal([]byte{0, 2}, buf[14:16])
+	assert.Equal(byte(FRR_NEXTHOP_IPV4), buf[16])
+	assert.Equal([]byte{2, 0, 0, 0, 100, 0, 0, 0, 101}, buf[21:30])
+	assert.Equal(byte(FRR_NEXTHOP_IPV4), buf[30])
+	assert.Equal([]byte{2, 0, 0, 0, 100, 0, 0, 0, 101}, buf[35:44])
+
+	// the bit is the one of MESSAGE_ASPATH before version 4.
+	r.Aux = []byte{1, 2, 3}
+	buf, err = r.Serialize(3)
+	assert.Nil(err)
+	assert.Equal([]byte{0, 0, 0, 3, 1, 2, 3}, buf[len(buf)-7:])
+}
+
+type countingConn struct {
+	net.Conn
+	writes int
+	buf    []byte
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	c.writes++
+	c.buf = append(c.buf, b...)
+	return len(b), nil
+}
+
+func (c *countingConn) Close() error {
+	return nil
+}
+
+func newTestClient(conn net.Conn, bufSize int, flushInterval time.Duration) *Client {
+	c := &Client{
+		outgoing:      make(chan *Message, 128),
+		conn:          conn,
+		Version:       3,
+		writeBufSize:  bufSize,
+		flushInterval: flushInterval,
+		writerDone:    make(chan struct{}),
+		aborted:       make(chan struct{}),
+	}
+	go c.writeLoop()
+	return c
+}
+
+func Test_ClientAbort(t *testing.T) {
+	assert := assert.New(t)
+
+	// nobody reads from the other end of the pipe.
+	conn, peer := net.Pipe()
+	defer peer.Close()
+	c := newTestClient(conn, 0, 0)
+	for i := 0; i < 129; i++ {
+		go c.SendIPRoute(VRF_DEFAULT, testIPRouteBody(i), false)
+	}
+
+	assert.Nil(c.Abort())
+	select {
+	case <-c.writerDone:
+	case <-time.After(time.Second):
+		t.Fatal("writer not stopped")
+	}
+	assert.NotNil(c.SendIPRoute(VRF_DEFAULT, testIPRouteBody(0), false))
+	// Close does not wait for the dropped messages.
+	c.Close()
+}
+
+func testIPRouteBody(i int) *IPRouteBody {
+	return &IPRouteBody{
+		Type:         ROUTE_BGP,
+		Message:      MESSAGE_NEXTHOP,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.IPv4(10, byte(i>>8), byte(i), 0).To4(),
+		PrefixLength: 24,
+		Nexthops:     []net.IP{net.ParseIP("192.168.0.1").To4()},
+	}
+}
+
+func Test_ClientWriteBuffer(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, interval := range []time.Duration{0, time.Hour} {
+		conn := &countingConn{}
+		c := newTestClient(conn, 4096, interval)
+		expected := make([]byte, 0)
+		for i := 0; i < 100; i++ {
+			body := testIPRouteBody(i)
+			c.SendIPRoute(0, body, false)
+			m := &Message{
+				Header: Header{
+					Len:     HeaderSize(3),
+					Marker:  HEADER_MARKER,
+					Version: 3,
+					Command: IPV4_ROUTE_ADD,
+				},
+				Body: body,
+			}
+			b, _ := m.Serialize()
+			expected = append(expected, b...)
+		}
+		// every message is flushed in order on close.
+		c.Close()
+		assert.Equal(expected, conn.buf)
+		if interval > 0 {
+			assert.Equal(1, conn.writes)
+		}
+	}
+}
+
+func Test_ClientWriteBufferOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	conn := &countingConn{}
+	c := newTestClient(conn, 4096, time.Hour)
+	// add, withdraw and add again the same prefix within a batch.
+	sends := []struct {
+		i          int
+		isWithdraw bool
+	}{
+		{0, false},
+		{0, true},
+		{1, false},
+		{0, false},
+		{1, true},
+	}
+	expected := make([]byte, 0)
+	for _, s := range sends {
+		body := testIPRouteBody(s.i)
+		c.SendIPRoute(0, body, s.isWithdraw)
+		command := IPV4_ROUTE_ADD
+		if s.isWithdraw {
+			command = IPV4_ROUTE_DELETE
+		}
+		m := &Message{
+			Header: Header{
+				Len:     HeaderSize(3),
+				Marker:  HEADER_MARKER,
+				Version: 3,
+				Command: command,
+			},
+			Body: body,
+		}
+		b, _ := m.Serialize()
+		expected = append(expected, b...)
+	}
+	c.Close()
+	assert.Equal(1, conn.writes)
+	assert.Equal(expected, conn.buf)
+}
+
+func Test_ClientSendClosed(t *testing.T) {
+	assert := assert.New(t)
+
+	c := newTestClient(&countingConn{}, 0, 0)
+	assert.Nil(c.SendIPRoute(0, testIPRouteBody(0), false))
+	c.Close()
+	assert.NotNil(c.SendIPRoute(0, testIPRouteBody(1), false))
+	assert.NotNil(c.SendCommand(VRF_REGISTER, VRF_DEFAULT, &UnknownBody{Data: []byte{0, 0, 0, 1}}))
+}
+
+func Test_ClientSendRedistribute(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, version := range []uint8{3, 4, 5} {
+		conn := &countingConn{}
+		c := newTestClient(conn, 0, 0)
+		c.Version = version
+		assert.Nil(c.SendRedistribute(ROUTE_CONNECT, 1))
+		assert.Nil(c.SendRedistributeDelete(ROUTE_CONNECT, 1))
+		assert.NotNil(c.SendRedistributeDelete(FRR_ZAPI5_ROUTE_MAX, 1))
+		c.Close()
+
+		// one message per address family since version 4.
+		expected := make([]byte, 0)
+		for _, command := range []API_TYPE{REDISTRIBUTE_ADD, REDISTRIBUTE_DELETE} {
+			marker := uint8(HEADER_MARKER)
+			bodies := []*RedistributeBody{{Redist: ROUTE_CONNECT}}
+			if version >= 4 {
+				marker = FRR_HEADER_MARKER
+				switch {
+				case command == REDISTRIBUTE_ADD && version >= 5:
+					command = FRR_ZAPI5_REDISTRIBUTE_ADD
+				case command == REDISTRIBUTE_ADD:
+					command = FRR_REDISTRIBUTE_ADD
+				case version >= 5:
+					command = FRR_ZAPI5_REDISTRIBUTE_DELETE
+				default:
+					command = FRR_REDISTRIBUTE_DELETE
+				}
+				bodies = []*RedistributeBody{{Afi: AFI_IP, Redist: ROUTE_CONNECT}, {Afi: AFI_IP6, Redist: ROUTE_CONNECT}}
+			}
+			for _, body := range bodies {
+				m := &Message{
+					Header: Header{
+						Len:     HeaderSize(version),
+						Marker:  marker,
+						Version: version,
+						VrfId:   1,
+						Command: command,
+					},
+					Body: body,
+				}
+				b, _ := m.Serialize()
+				expected = append(expected, b...)
+			}
+		}
+		assert.Equal(expected, conn.buf, "version: %d", version)
+	}
+}
+
+func benchmarkClientWrite(b *testing.B, bufSize int, flushInterval time.Duration) {
+	conn := &countingConn{}
+	c := newTestClient(conn, bufSize, flushInterval)
+	body := testIPRouteBody(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.SendIPRoute(0, body, false)
+	}
+	c.Close()
+	b.Logf("%d writes for %d messages", conn.writes, b.N)
+}
+
+func BenchmarkClientWriteUnbuffered(b *testing.B) {
+	benchmarkClientWrite(b, 0, 0)
+}
+
+func BenchmarkClientWriteBuffered(b *testing.B) {
+	benchmarkClientWrite(b, 65536, 0)
+}
+
+func BenchmarkClientWriteBufferedInterval(b *testing.B) {
+	benchmarkClientWrite(b, 65536, 5*time.Millisecond)
+}
+
+func Test_RouteTypesFromString(t *testing.T) {
+	assert := assert.New(t)
+
+	types, err := RouteTypesFromString("static", 3)
+	assert.Nil(err)
+	assert.Equal([]ROUTE_TYPE{ROUTE_STATIC}, types)
+
+	_, err = RouteTypesFromString("unknown", 3)
+	assert.NotNil(err)
+
+	// Quagga
+	types, err = RouteTypesFromString("all", 3)
+	assert.Nil(err)
+	assert.Len(types, int(ROUTE_MAX)-1)
+	for typ := ROUTE_SYSTEM; typ < ROUTE_MAX; typ++ {
+		if typ == ROUTE_BGP {
+			assert.NotContains(types, typ)
+		} else {
+			assert.Contains(types, typ)
+		}
+	}
+
+	// FRRouting
+	types, err = RouteTypesFromString("all", 4)
+	assert.Nil(err)
+	assert.Len(types, int(FRR_ROUTE_ALL)-1)
+	for typ := FRR_ROUTE_SYSTEM; typ < FRR_ROUTE_ALL; typ++ {
+		if typ == FRR_ROUTE_BGP {
+			assert.NotContains(types, typ)
+		} else {
+			assert.Contains(types, typ)
+		}
+	}
+	assert.NotContains(types, FRR_ROUTE_ALL)
+
+	// FRRouting 4 and later, whose route types are renumbered
+	types, err = RouteTypesFromString("babel", 5)
+	assert.Nil(err)
+	assert.Equal([]ROUTE_TYPE{FRR_ZAPI5_ROUTE_BABEL}, types)
+	types, err = RouteTypesFromString("all", 6)
+	assert.Nil(err)
+	assert.Len(types, int(FRR_ZAPI5_ROUTE_ALL)-1)
+	assert.NotContains(types, FRR_ZAPI5_ROUTE_BGP)
+	assert.NotContains(types, FRR_ZAPI5_ROUTE_ALL)
+}
+
+func Test_SerializeAux(t *testing.T) {
+	assert := assert.New(t)
+
+	aspath := []byte{0x02, 0x01, 0x00, 0x00, 0xfd, 0xe9}
+	metadata := []byte("policy-tag")
+	entries := []AuxEntry{
+		{Type: AUX_TYPE_ASPATH, Value: aspath},
+		{Type: AUX_TYPE_METADATA, Value: metadata},
+	}
+	buf, err := SerializeAux(entries)
+	assert.Nil(err)
+	assert.Len(buf, 3+len(aspath)+3+len(metadata))
+
+	parsed, err := ParseAux(buf)
+	assert.Nil(err)
+	assert.Equal(entries, parsed)
+
+	// carried as is in IPV4_ROUTE_ADD.
+	b := &IPRouteBody{
+		Type:         ROUTE_BGP,
+		Message:      MESSAGE_ASPATH,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 24,
+		Aux:          buf,
+	}
+	msg, err := b.Serialize(3)
+	assert.Nil(err)
+	assert.Equal(buf, msg[len(msg)-len(buf):])
+
+	_, err = ParseAux(buf[:len(buf)-1])
+	assert.NotNil(err)
+	_, err = ParseAux([]byte{0x01, 0x00})
+	assert.NotNil(err)
+	_, err = SerializeAux([]AuxEntry{{Type: AUX_TYPE_METADATA, Value: make([]byte, 0x10000)}})
+	assert.NotNil(err)
+}
+
+func Test_HeaderVrfId(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &Header{Len: HeaderSize(3), Marker: HEADER_MARKER, Version: 3, VrfId: MaxVrfId(3), Command: IPV4_ROUTE_ADD}
+	buf, err := h.Serialize()
+	assert.Nil(err)
+	decoded := &Header{}
+	assert.Nil(decoded.DecodeFromBytes(buf))
+	assert.Equal(h, decoded)
+
+	// never truncated.
+	h.VrfId = 70000
+	_, err = h.Serialize()
+	assert.NotNil(err)
+}
+
+func Test_HeaderVersion6(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &Header{Len: HeaderSize(6), Marker: FRR_HEADER_MARKER, Version: 6, VrfId: 70000, Command: FRR_ZAPI5_ROUTE_ADD}
+	buf, err := h.Serialize()
+	assert.Nil(err)
+	assert.Len(buf, 10)
+	assert.Equal(uint32(70000), binary.BigEndian.Uint32(buf[4:8]))
+	decoded := &Header{}
+	assert.Nil(decoded.DecodeFromBytes(buf))
+	assert.Equal(h, decoded)
+	assert.Equal(uint32(math.MaxUint32), MaxVrfId(6))
+
+	// header_size mismatch
+	assert.NotNil(decoded.DecodeFromBytes(buf[:8]))
+}
+
+func Test_IPRouteBody_Version5(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &IPRouteBody{
+		Api:          FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD,
+		Type:         ROUTE_BGP,
+		Flags:        FLAG_INTERNAL | FLAG_IBGP,
+		Message:      MESSAGE_NEXTHOP | FRR_MESSAGE_DISTANCE | FRR_MESSAGE_METRIC | FRR_MESSAGE_TAG | FRR_MESSAGE_MTU,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.ParseIP("2001:db8::").To16(),
+		PrefixLength: 32,
+		Nexthops:     []net.IP{net.ParseIP("2001:db8::1").To16()},
+		Ifindexs:     []uint32{},
+		Distance:     20,
+		Metric:       100,
+		Tag:          10,
+		Mtu:          1500,
+		NexthopVrfId: 2,
+	}
+	for _, version := range []uint8{5, 6} {
+		buf, err := r.Serialize(version)
+		assert.Nil(err)
+		assert.Equal(uint32(FRR_ZAPI5_FLAG_ALLOW_RECURSION|FRR_ZAPI5_FLAG_IBGP), binary.BigEndian.Uint32(buf[3:7]))
+		assert.Equal(byte(FRR_ZAPI5_MESSAGE_NEXTHOP|FRR_ZAPI5_MESSAGE_DISTANCE|FRR_ZAPI5_MESSAGE_METRIC|FRR_ZAPI5_MESSAGE_TAG|FRR_ZAPI5_MESSAGE_MTU), buf[7])
+		assert.Equal(byte(syscall.AF_INET6), buf[9])
+
+		decoded := &IPRouteBody{Api: FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD}
+		assert.Nil(decoded.DecodeFromBytes(buf, version))
+		expected := *r
+		expected.Ifindexs = []uint32{0}
+		if version < 6 {
+			expected.NexthopVrfId = 0
+		}
+		assert.Equal(&expected, decoded, "version: %d", version)
+		assert.Equal(bgp.RF_IPv6_UC, decoded.RouteFamily())
+		assert.False(decoded.IsWithdraw())
+
+		// truncated
+		assert.NotNil(decoded.DecodeFromBytes(buf[:len(buf)-1], version))
+	}
+
+	// the rejected routes are sent with a blackhole nexthop.
+	r = &IPRouteBody{
+		Type:         ROUTE_BGP,
+		Flags:        FLAG_REJECT,
+		Message:      FRR_MESSAGE_METRIC,
+		SAFI:         SAFI_UNICAST,
+		Prefix:       net.ParseIP("10.0.0.0").To4(),
+		PrefixLength: 8,
+	}
+	buf, err := r.Serialize(5)
+	assert.Nil(err)
+	// type(1) + instance(2) + flags(4) + message(1) + safi(1) + family(1)
+	// + plen(1) + prefix(1) + nexthop num(2) + nexthop type(1)
+	// + blackhole type(1) + metric(4)
+	assert.Equal(20, len(buf))
+	assert.Equal([]byte{0, 1, byte(FRR_NEXTHOP_BLACKHOLE), byte(BLACKHOLE_REJECT)}, buf[12:16])
+	decoded := &IPRouteBody{Api: FRR_ZAPI5_REDISTRIBUTE_ROUTE_DEL}
+	assert.Nil(decoded.DecodeFromBytes(buf, 5))
+	assert.Equal(FLAG_REJECT, decoded.Flags)
+	assert.Empty(decoded.Nexthops)
+	assert.Equal(bgp.RF_IPv4_UC, decoded.RouteFamily())
+	assert.True(decoded.IsWithdraw())
+}
+
+func Test_HelloBody_Version5(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &HelloBody{RedistDefault: ROUTE_BGP, Instance: 1, ReceiveNotify: 1}
+	buf, err := b.Serialize(4)
+	assert.Nil(err)
+	assert.Equal([]byte{byte(ROUTE_BGP), 0, 1}, buf)
+	buf, err = b.Serialize(5)
+	assert.Nil(err)
+	assert.Equal([]byte{byte(ROUTE_BGP), 0, 1, 1}, buf)
+	decoded := &HelloBody{}
+	assert.Nil(decoded.DecodeFromBytes(buf, 5))
+	assert.Equal(b, decoded)
+}
+
+func Test_ClientSendIPRouteVersion6(t *testing.T) {
+	assert := assert.New(t)
+
+	conn := &countingConn{}
+	c := newTestClient(conn, 0, 0)
+	c.Version = 6
+	body := testIPRouteBody(0)
+	assert.Nil(c.SendIPRoute(3, body, false))
+	assert.Nil(c.SendIPRoute(3, body, true))
+	c.Close()
+	// the body of the caller is left as is.
+	assert.Equal(uint32(0), body.NexthopVrfId)
+
+	for _, command := range []API_TYPE{FRR_ZAPI5_ROUTE_ADD, FRR_ZAPI5_ROUTE_DELETE} {
+		h := &Header{}
+		if !assert.Nil(h.DecodeFromBytes(conn.buf)) {
+			return
+		}
+		assert.Equal(command, h.Command)
+		assert.Equal(uint32(3), h.VrfId)
+		m, err := ParseMessage(h, conn.buf[HeaderSize(6):h.Len])
+		if !assert.Nil(err) {
+			return
+		}
+		decoded := &IPRouteBody{Api: FRR_ZAPI5_REDISTRIBUTE_ROUTE_ADD}
+		assert.Nil(decoded.DecodeFromBytes(m.Body.(*UnknownBody).Data, 6))
+		assert.Equal(uint32(3), decoded.NexthopVrfId)
+		conn.buf = conn.buf[h.Len:]
+	}
+}